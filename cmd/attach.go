@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/eko/monday/pkg/api"
+	"github.com/eko/monday/pkg/control"
+	"github.com/eko/monday/pkg/session"
+	"github.com/eko/monday/pkg/status"
+	"github.com/eko/monday/pkg/ui"
+	"github.com/jroimartin/gocui"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// attachRefreshInterval is the duration between two refreshes of the controls view of an attached terminal
+	attachRefreshInterval = time.Second
+)
+
+func attachCmd(ctx context.Context) *cobra.Command {
+	return &cobra.Command{
+		Use:   "attach [project]",
+		Short: "This command opens the terminal UI of a running project",
+		Long: `Opens the terminal UI of a project running in the background (or in another terminal), using its control API.
+Several terminals can be attached at once. Quitting the UI (Ctrl+C) only detaches from the project, which keeps running.`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			current, client := getSessionClient(args)
+
+			result, err := client.GetStatus(ctx)
+			if err != nil {
+				fmt.Printf("❌  %v\n", err)
+				os.Exit(1)
+			}
+
+			if stopped := attach(ctx, current, client, result); stopped {
+				fmt.Printf("👋  Project '%s' has stopped\n", current.Project)
+				return
+			}
+
+			fmt.Printf("👋  Detached from project '%s', it keeps running (use 'monday down %s' to stop it)\n", current.Project, current.Project)
+		},
+	}
+}
+
+// attach displays the terminal UI of the given session until it is quit, it indicates if the session has stopped
+func attach(ctx context.Context, current *session.Session, client api.Client, result *api.Status) bool {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	layout := ui.NewLayout(true, ui.OutputText)
+	layout.Init()
+	defer layout.GetGui().Close()
+
+	names := make([]string, 0, len(result.Applications))
+	for _, application := range result.Applications {
+		names = append(names, application.Name)
+	}
+	layout.SetApplications(names)
+
+	// The state of the local applications and forwards is mirrored in a local registry, refreshed periodically
+	mirror := status.NewRegistry()
+	syncRegistry(mirror, result)
+
+	go func() {
+		ticker := time.NewTicker(attachRefreshInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if result, err := client.GetStatus(ctx); err == nil {
+					syncRegistry(mirror, result)
+				}
+			}
+		}
+	}()
+
+	setControls(ctx, layout, mirror, &remoteController{client: client})
+
+	// The views display the output of the session, the UI being closed once the session has stopped
+	var stopped atomic.Bool
+
+	go func() {
+		if err := client.Output(ctx, layout.WriteRecord); err != nil {
+			layout.GetLogsView().WithComponent("attach").Writef("❌  %v\n", err)
+		}
+
+		if ctx.Err() == nil {
+			stopped.Store(true)
+			layout.GetGui().Update(func(g *gocui.Gui) error {
+				return gocui.ErrQuit
+			})
+		}
+	}()
+
+	if err := layout.GetGui().SetKeybinding("", gocui.KeyCtrlC, gocui.ModNone, quit(cancel)); err != nil {
+		panic(err)
+	}
+
+	mode := "foreground"
+	if current.Detached {
+		mode = "detached"
+	}
+
+	layout.GetStatusView().Writef(" ⇢  %s (attached to %s session, Ctrl+C: detach) | Commands: %s", current.Project, mode, commandsHelp)
+
+	if err := layout.GetGui().MainLoop(); err != nil && err != gocui.ErrQuit {
+		fmt.Println(err)
+	}
+
+	return stopped.Load()
+}
+
+// syncRegistry replaces the local applications and forwards of the given registry by the ones of the given status
+func syncRegistry(registry status.Registry, result *api.Status) {
+	applications := make(map[string]bool)
+	for _, application := range result.Applications {
+		application := application
+		applications[application.Name] = true
+
+		registry.UpdateApplication(application.Name, func(current *status.Application) {
+			*current = application
+		})
+	}
+
+	for _, application := range registry.GetApplications() {
+		if !applications[application.Name] {
+			registry.RemoveApplication(application.Name)
+		}
+	}
+
+	forwards := make(map[string]bool)
+	for _, forward := range result.Forwards {
+		forward := forward
+		forwards[forward.Name] = true
+
+		registry.UpdateForward(forward.Name, func(current *status.Forward) {
+			*current = forward
+		})
+	}
+
+	for _, forward := range registry.GetForwards() {
+		if !forwards[forward.Name] {
+			registry.RemoveForward(forward.Name)
+		}
+	}
+}
+
+// remoteController runs the actions of the controls view on a session, using its control API
+type remoteController struct {
+	client api.Client
+}
+
+func (c *remoteController) Application(ctx context.Context, action control.Action, name string) error {
+	_, err := c.client.Application(ctx, action, name)
+	return err
+}
+
+func (c *remoteController) Forward(ctx context.Context, action control.Action, name string) error {
+	_, err := c.client.Forward(ctx, action, name)
+	return err
+}
@@ -0,0 +1,250 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/eko/monday/pkg/api"
+	"github.com/eko/monday/pkg/config"
+	"github.com/eko/monday/pkg/control"
+	"github.com/eko/monday/pkg/session"
+	"github.com/spf13/cobra"
+)
+
+func statusCmd(ctx context.Context) *cobra.Command {
+	return &cobra.Command{
+		Use:   "status [project]",
+		Short: "This command prints the status of the local applications and forwards of a running project",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			current, client := getSessionClient(args)
+
+			status, err := client.GetStatus(ctx)
+			if err != nil {
+				fmt.Printf("❌  %v\n", err)
+				os.Exit(1)
+			}
+
+			printStatus(current, status)
+		},
+	}
+}
+
+func restartCmd(ctx context.Context) *cobra.Command {
+	return applicationActionCmd(ctx, control.ActionRestart, "🔁  Local app '%s' is restarting\n")
+}
+
+func stopCmd(ctx context.Context) *cobra.Command {
+	return applicationActionCmd(ctx, control.ActionStop, "⏹️  Local app '%s' is stopped\n")
+}
+
+// applicationActionCmd returns a command running the given action on a local application of a running project
+func applicationActionCmd(ctx context.Context, action control.Action, message string) *cobra.Command {
+	command := &cobra.Command{
+		Use:   fmt.Sprintf("%s <application>", action),
+		Short: fmt.Sprintf("This command %ss a local application of a running project", action),
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			project, _ := cmd.Flags().GetString("project")
+
+			_, client := getSessionClient([]string{project})
+
+			if _, err := client.Application(ctx, action, args[0]); err != nil {
+				fmt.Printf("❌  %v\n", err)
+				os.Exit(1)
+			}
+
+			fmt.Printf(message, args[0])
+		},
+	}
+
+	command.Flags().StringP("project", "p", "", "Project of the application, when several ones are running")
+
+	return command
+}
+
+func downCmd(ctx context.Context) *cobra.Command {
+	return &cobra.Command{
+		Use:   "down [project]",
+		Short: "This command stops a running project",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			current, client := getSessionClient(args)
+
+			status, err := client.GetStatus(ctx)
+			if err != nil {
+				fmt.Printf("❌  %v\n", err)
+				os.Exit(1)
+			}
+
+			if err := client.Shutdown(ctx); err != nil {
+				fmt.Printf("❌  %v\n", err)
+				os.Exit(1)
+			}
+
+			fmt.Printf("🛑  Stopping project '%s'...\n", current.Project)
+
+			// The session has stopped once its process has exited, local applications and forwards being stopped before
+			deadline := time.Now().Add(shutdownTimeout + 5*time.Second)
+
+			for isProcessRunning(status.PID) {
+				if time.Now().After(deadline) {
+					fmt.Printf("❌  Project '%s' is still stopping after %s (pid %d)\n", current.Project, shutdownTimeout, status.PID)
+					os.Exit(1)
+				}
+
+				time.Sleep(100 * time.Millisecond)
+			}
+
+			fmt.Printf("👋  Project '%s' is stopped\n", current.Project)
+		},
+	}
+}
+
+// getSessionClient returns the running session of the given project (or the only running one when no project
+// is given) and a client of its control API, it exits when no session can be found
+func getSessionClient(args []string) (*session.Session, api.Client) {
+	var project string
+	if len(args) > 0 {
+		project = args[0]
+	}
+
+	current, err := findSession(config.GetSessionsPath(), project)
+	if err != nil {
+		fmt.Printf("❌  %v\n", err)
+		os.Exit(1)
+	}
+
+	return current, api.NewClient(current.Socket)
+}
+
+// findSession returns the running session of the given project (which can run with other projects),
+// or the only running session when no project is given
+func findSession(directory, project string) (*session.Session, error) {
+	if project != "" {
+		if current, err := session.Read(directory, project); err == nil && current.IsRunning() {
+			return current, nil
+		}
+	}
+
+	sessions, err := session.List(directory)
+	if err != nil {
+		return nil, err
+	}
+
+	if project != "" {
+		for _, current := range sessions {
+			if contains(strings.Split(current.Project, config.ProjectSeparator), project) && current.IsRunning() {
+				return current, nil
+			}
+		}
+
+		return nil, fmt.Errorf("No Monday session is running for project '%s'", project)
+	}
+
+	running := make([]*session.Session, 0, len(sessions))
+	names := make([]string, 0, len(sessions))
+
+	for _, current := range sessions {
+		if current.IsRunning() {
+			running = append(running, current)
+			names = append(names, current.Project)
+		}
+	}
+
+	switch len(running) {
+	case 0:
+		return nil, errors.New("No Monday session is running, use 'monday up -d <project>' to start one")
+	case 1:
+		return running[0], nil
+	}
+
+	return nil, fmt.Errorf("Several Monday sessions are running (%s), please select a project", strings.Join(names, ", "))
+}
+
+// printStatus prints the local applications and forwards of the given session as a table
+func printStatus(current *session.Session, status *api.Status) {
+	mode := "foreground"
+	if current.Detached {
+		mode = "detached"
+	}
+
+	fmt.Printf("⇢  %s (pid %d, %s, up %s)\n\n", status.Project, status.PID, mode, formatUptime(current.StartedAt))
+
+	hostnames := make(map[string][]string)
+	addresses := make(map[string][]string)
+
+	for _, mapping := range status.Proxy {
+		address := mapping.LocalIP
+		if mapping.LocalPort != "" {
+			address += ":" + mapping.LocalPort
+		}
+
+		hostnames[mapping.Name] = append(hostnames[mapping.Name], mapping.Hostname)
+		addresses[mapping.Name] = append(addresses[mapping.Name], address)
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(writer, "KIND\tNAME\tSTATE\tHEALTH\tHOSTNAMES\tADDRESSES\tDETAILS")
+
+	for _, application := range status.Applications {
+		details := []string{fmt.Sprintf("%d restarts", application.Restarts)}
+		if application.PID != 0 {
+			details = append([]string{fmt.Sprintf("pid %d", application.PID), "up " + formatUptime(application.StartedAt)}, details...)
+		}
+
+		fmt.Fprintf(writer, "app\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			application.Name, application.State, orDash(application.Health),
+			orDash(strings.Join(hostnames[application.Name], ", ")), orDash(strings.Join(addresses[application.Name], ", ")),
+			strings.Join(details, ", "),
+		)
+	}
+
+	for _, forward := range status.Forwards {
+		details := []string{fmt.Sprintf("%d reconnects", forward.Reconnects)}
+		if forward.Target != "" {
+			details = append([]string{forward.Target}, details...)
+		}
+		if forward.LastError != "" {
+			details = append(details, "last error: "+forward.LastError)
+		}
+
+		fmt.Fprintf(writer, "forward\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			forward.Name, forward.State, "-",
+			orDash(strings.Join(hostnames[forward.Name], ", ")), orDash(strings.Join(addresses[forward.Name], ", ")),
+			strings.Join(details, ", "),
+		)
+	}
+
+	writer.Flush()
+}
+
+// formatUptime returns the duration elapsed since the given time, rounded to the second
+func formatUptime(since time.Time) string {
+	if since.IsZero() {
+		return "-"
+	}
+
+	return time.Since(since).Round(time.Second).String()
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+
+	return value
+}
+
+// isProcessRunning indicates if the process with the given identifier is still running, including when
+// it belongs to another user (when Monday is run using sudo)
+func isProcessRunning(pid int) bool {
+	err := syscall.Kill(pid, 0)
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
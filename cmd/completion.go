@@ -3,7 +3,9 @@ package main
 import (
 	"fmt"
 	"os"
+	"strings"
 
+	"github.com/eko/monday/pkg/config"
 	"github.com/spf13/cobra"
 )
 
@@ -39,3 +41,69 @@ func rootCmd(cmd *cobra.Command) *cobra.Command {
 
 	return parent
 }
+
+// completeProject completes the project given as single argument
+func completeProject(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return completeProjects(cmd, args, toComplete)
+}
+
+// completeProjects completes the projects given as arguments, each project being given once
+func completeProjects(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	conf, err := config.Load()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	names := make([]string, 0)
+	for _, name := range conf.GetProjectNames() {
+		if !contains(args, name) {
+			names = append(names, name)
+		}
+	}
+
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeProjectItems completes the comma-separated names of the applications and forwards of the projects
+// given as arguments (or of all projects)
+func completeProjectItems(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	conf, err := config.Load()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	projects := args
+	if len(projects) == 0 {
+		projects = conf.GetProjectNames()
+	}
+
+	// The names already given are kept as prefix
+	prefix := toComplete[:strings.LastIndex(toComplete, ",")+1]
+	given := strings.Split(prefix, ",")
+
+	names := make([]string, 0)
+	for _, name := range projects {
+		project, err := getProject(conf, selection{projects: []string{name}})
+		if err != nil {
+			continue
+		}
+
+		for _, application := range project.Applications {
+			if !contains(given, application.Name) && !contains(names, prefix+application.Name) {
+				names = append(names, prefix+application.Name)
+			}
+		}
+
+		for _, forward := range project.Forwards {
+			if !contains(given, forward.Name) && !contains(names, prefix+forward.Name) {
+				names = append(names, prefix+forward.Name)
+			}
+		}
+	}
+
+	return names, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/eko/monday/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "This command allows you to manage the configuration layers (team, user, repository and override)",
+}
+
+var configSyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "This command pulls the latest team configuration from its local git clone",
+	Long: `The team configuration is a git repository cloned in ~/.monday/team (or in the directory
+specified by the MONDAY_TEAM_CONFIG_PATH environment variable).`,
+	Run: func(cmd *cobra.Command, args []string) {
+		output, err := config.SyncTeamConfig()
+		if err != nil {
+			fmt.Printf("❌  %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Print(output)
+		fmt.Printf("✅  Team configuration is up to date in '%s'\n", config.TeamConfigPath)
+	},
+}
+
+var configPrintCmd = &cobra.Command{
+	Use:   "print",
+	Short: "This command prints the configuration files in the order they are merged",
+	Long: `Configuration files are merged layer after layer: team configuration, user configuration,
+nearest .monday.yaml file found from the current directory and its monday.override.yaml file.
+Use the --resolved option to print the final merged configuration with the source of every value.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if resolved, _ := strconv.ParseBool(cmd.Flag("resolved").Value.String()); resolved {
+			content, err := config.Resolved()
+			if err != nil {
+				fmt.Printf("❌  %v\n", err)
+				os.Exit(1)
+			}
+
+			fmt.Print(string(content))
+			return
+		}
+
+		for _, layer := range config.FindConfigLayers() {
+			fmt.Printf("%s:\n", layer.Name)
+
+			for _, file := range layer.Files {
+				fmt.Printf("  %s\n", file)
+			}
+		}
+	},
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+
+	"github.com/eko/monday/pkg/control"
+	"github.com/eko/monday/pkg/status"
+	"github.com/eko/monday/pkg/ui"
+)
+
+var (
+	// controlKeys lists the key and label of each action in the controls view of the terminal UI
+	controlKeys = map[control.Action]struct {
+		key   rune
+		label string
+	}{
+		control.ActionRestart:   {'r', "Restart"},
+		control.ActionStop:      {'x', "Stop"},
+		control.ActionStart:     {'s', "Start"},
+		control.ActionRebuild:   {'b', "Rebuild"},
+		control.ActionSetup:     {'u', "Setup"},
+		control.ActionReconnect: {'r', "Reconnect"},
+		control.ActionPause:     {'p', "Pause"},
+		control.ActionResume:    {'u', "Resume"},
+	}
+)
+
+// setControls lists the local applications and forwards in the controls view of the terminal UI, their actions
+// being run by the given controller
+func setControls(ctx context.Context, layout *ui.Layout, registry status.Registry, controller control.Controller) {
+	view := layout.GetLogsView().WithComponent("controller")
+
+	layout.SetControls(controlItems(registry), getControlActions(ctx, controller, view))
+}
+
+// controlItems returns a function listing the local applications and forwards of the given registry with their state
+func controlItems(registry status.Registry) func() []ui.ControlItem {
+	return func() []ui.ControlItem {
+		result := make([]ui.ControlItem, 0)
+
+		for _, application := range registry.GetApplications() {
+			result = append(result, ui.ControlItem{Kind: ui.ControlApplication, Name: application.Name, State: string(application.State)})
+		}
+
+		for _, forward := range registry.GetForwards() {
+			result = append(result, ui.ControlItem{Kind: ui.ControlForward, Name: forward.Name, State: string(forward.State)})
+		}
+
+		return result
+	}
+}
+
+// getControlActions returns the UI actions of the local applications and forwards, run by the given controller
+func getControlActions(ctx context.Context, controller control.Controller, view ui.View) map[string][]ui.ControlAction {
+	return map[string][]ui.ControlAction{
+		ui.ControlApplication: controlActions(control.ApplicationActions, func(action control.Action, name string) error {
+			return controller.Application(ctx, action, name)
+		}, view),
+		ui.ControlForward: controlActions(control.ForwardActions, func(action control.Action, name string) error {
+			return controller.Forward(ctx, action, name)
+		}, view),
+	}
+}
+
+// controlActions returns the UI actions running the given actions, errors being written to the given view
+func controlActions(actions []control.Action, run func(action control.Action, name string) error, view ui.View) []ui.ControlAction {
+	result := make([]ui.ControlAction, 0, len(actions))
+
+	for _, action := range actions {
+		action := action
+
+		result = append(result, ui.ControlAction{
+			Key:     controlKeys[action].key,
+			Label:   controlKeys[action].label,
+			Confirm: action.IsDestructive(),
+			Run: func(name string) {
+				if err := run(action, name); err != nil {
+					view.Writef("❌  %v\n", err)
+				}
+			},
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/eko/monday/pkg/control"
+	"github.com/eko/monday/pkg/status"
+	"github.com/eko/monday/pkg/ui"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestControlItems(t *testing.T) {
+	// Given
+	registry := status.NewRegistry()
+	registry.UpdateApplication("user-api", func(application *status.Application) {
+		application.State = status.StateRunning
+	})
+	registry.UpdateApplication("graphql", func(application *status.Application) {
+		application.State = status.StateCrashed
+	})
+	registry.UpdateForward("order-api", func(forward *status.Forward) {
+		forward.State = status.StatePaused
+	})
+
+	// When
+	items := controlItems(registry)
+
+	// Then
+	assert.Equal(t, []ui.ControlItem{
+		{Kind: ui.ControlApplication, Name: "graphql", State: "crashed"},
+		{Kind: ui.ControlApplication, Name: "user-api", State: "running"},
+		{Kind: ui.ControlForward, Name: "order-api", State: "paused"},
+	}, items())
+
+	// When
+	registry.RemoveApplication("graphql")
+
+	// Then
+	assert.Equal(t, []ui.ControlItem{
+		{Kind: ui.ControlApplication, Name: "user-api", State: "running"},
+		{Kind: ui.ControlForward, Name: "order-api", State: "paused"},
+	}, items())
+}
+
+func TestGetControlActions(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	controller := control.NewMockController(ctrl)
+	view := ui.NewMockView(ctrl)
+
+	// When
+	actions := getControlActions(context.Background(), controller, view)
+
+	// Then
+	assert.Len(t, actions, 2)
+
+	testCases := []struct {
+		kind            string
+		expectedKeys    []rune
+		expectedLabels  []string
+		expectedConfirm []bool
+	}{
+		{
+			kind:            ui.ControlApplication,
+			expectedKeys:    []rune{'r', 'x', 's', 'b', 'u'},
+			expectedLabels:  []string{"Restart", "Stop", "Start", "Rebuild", "Setup"},
+			expectedConfirm: []bool{true, true, false, true, false},
+		},
+		{
+			kind:            ui.ControlForward,
+			expectedKeys:    []rune{'r', 'p', 'u'},
+			expectedLabels:  []string{"Reconnect", "Pause", "Resume"},
+			expectedConfirm: []bool{true, true, false},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.kind, func(t *testing.T) {
+			keys := make([]rune, 0)
+			labels := make([]string, 0)
+			confirm := make([]bool, 0)
+
+			for _, action := range actions[testCase.kind] {
+				keys = append(keys, action.Key)
+				labels = append(labels, action.Label)
+				confirm = append(confirm, action.Confirm)
+			}
+
+			assert.Equal(t, testCase.expectedKeys, keys)
+			assert.Equal(t, testCase.expectedLabels, labels)
+			assert.Equal(t, testCase.expectedConfirm, confirm)
+		})
+	}
+}
+
+func TestGetControlActionsRun(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	testCases := []struct {
+		name   string
+		kind   string
+		index  int
+		expect func(controller *control.MockController)
+	}{
+		{
+			name:  "restart application",
+			kind:  ui.ControlApplication,
+			index: 0,
+			expect: func(controller *control.MockController) {
+				controller.EXPECT().Application(ctx, control.ActionRestart, "user-api").Return(nil)
+			},
+		},
+		{
+			name:  "stop application",
+			kind:  ui.ControlApplication,
+			index: 1,
+			expect: func(controller *control.MockController) {
+				controller.EXPECT().Application(ctx, control.ActionStop, "user-api").Return(nil)
+			},
+		},
+		{
+			name:  "setup application",
+			kind:  ui.ControlApplication,
+			index: 4,
+			expect: func(controller *control.MockController) {
+				controller.EXPECT().Application(ctx, control.ActionSetup, "user-api").Return(nil)
+			},
+		},
+		{
+			name:  "reconnect forward",
+			kind:  ui.ControlForward,
+			index: 0,
+			expect: func(controller *control.MockController) {
+				controller.EXPECT().Forward(ctx, control.ActionReconnect, "user-api").Return(nil)
+			},
+		},
+		{
+			name:  "resume forward",
+			kind:  ui.ControlForward,
+			index: 2,
+			expect: func(controller *control.MockController) {
+				controller.EXPECT().Forward(ctx, control.ActionResume, "user-api").Return(nil)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			controller := control.NewMockController(ctrl)
+			testCase.expect(controller)
+
+			view := ui.NewMockView(ctrl)
+
+			actions := getControlActions(ctx, controller, view)
+
+			// When - Then
+			actions[testCase.kind][testCase.index].Run("user-api")
+		})
+	}
+}
+
+func TestGetControlActionsRunWhenError(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	err := errors.New("unknown forward 'order-api'")
+
+	controller := control.NewMockController(ctrl)
+	controller.EXPECT().Forward(ctx, control.ActionPause, "order-api").Return(err)
+
+	view := ui.NewMockView(ctrl)
+	view.EXPECT().Writef("❌  %v\n", err)
+
+	actions := getControlActions(ctx, controller, view)
+
+	// When - Then
+	actions[ui.ControlForward][1].Run("order-api")
+}
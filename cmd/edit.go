@@ -15,19 +15,13 @@ var editCmd = &cobra.Command{
 	Long: `For more information about the configuration, see the "example" directory available
 in the source code repository at https://github.com/eko/monday.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		// Check for multiple configuration file
-		files := config.FindMultipleConfigFiles()
-
-		// Check for single configuration file
 		err := config.CheckConfigFileExists()
 		if err != nil {
 			fmt.Printf("❌  %v\n", err)
 			return
 		}
 
-		if len(files) == 0 {
-			files = []string{config.Filepath}
-		}
+		files := config.FindConfigFiles()
 
 		editorArgs := append(runtime.EditorArgs, files...)
 		editorCommand := exec.Command(runtime.EditorCommand, editorArgs...)
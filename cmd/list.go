@@ -0,0 +1,360 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/eko/monday/pkg/config"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	listOutputTable = "table"
+	listOutputJSON  = "json"
+	listOutputYAML  = "yaml"
+)
+
+var listOutputs = []string{listOutputTable, listOutputJSON, listOutputYAML}
+
+// projectOutput is a project printed by the list and show commands, with its resolved applications and forwards
+type projectOutput struct {
+	Name         string              `json:"name" yaml:"name"`
+	Extends      []string            `json:"extends,omitempty" yaml:"extends,omitempty"`
+	Applications []applicationOutput `json:"applications" yaml:"applications"`
+	Forwards     []forwardOutput     `json:"forwards" yaml:"forwards"`
+}
+
+// applicationOutput is a local application printed by the list and show commands
+type applicationOutput struct {
+	Project   string   `json:"project,omitempty" yaml:"project,omitempty"`
+	Name      string   `json:"name" yaml:"name"`
+	Path      string   `json:"path" yaml:"path"`
+	Hostname  string   `json:"hostname,omitempty" yaml:"hostname,omitempty"`
+	Watch     bool     `json:"watch" yaml:"watch"`
+	Command   string   `json:"command,omitempty" yaml:"command,omitempty"`
+	DependsOn []string `json:"depends_on,omitempty" yaml:"depends_on,omitempty"`
+}
+
+// forwardOutput is a forward printed by the list and show commands
+type forwardOutput struct {
+	Project   string            `json:"project,omitempty" yaml:"project,omitempty"`
+	Name      string            `json:"name" yaml:"name"`
+	Type      string            `json:"type" yaml:"type"`
+	Context   string            `json:"context,omitempty" yaml:"context,omitempty"`
+	Namespace string            `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Remote    string            `json:"remote,omitempty" yaml:"remote,omitempty"`
+	Hostname  string            `json:"hostname,omitempty" yaml:"hostname,omitempty"`
+	Ports     []string          `json:"ports,omitempty" yaml:"ports,omitempty"`
+	Proxified bool              `json:"proxified" yaml:"proxified"`
+}
+
+var listCmd = &cobra.Command{
+	Use:   "list projects|apps|forwards",
+	Short: "This command lists the projects, local applications or forwards of your configuration",
+	Long: `Local applications and forwards are listed as they run: resolved with the projects they extend and
+preceded by the global ones. Use --project (-p) to only list the ones of a project and --output (-o) to print
+them as a table, JSON or YAML.`,
+	Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	ValidArgs: []string{"projects", "apps", "forwards"},
+	Run: func(cmd *cobra.Command, args []string) {
+		format, conf := loadListConfig(cmd)
+
+		names := conf.GetProjectNames()
+		if name, _ := cmd.Flags().GetString("project"); name != "" {
+			names = []string{name}
+		}
+
+		projects := make([]*projectOutput, 0, len(names))
+		for _, name := range names {
+			project, err := getProjectOutput(conf, name)
+			if err != nil {
+				fmt.Printf("❌  %v\n", err)
+				os.Exit(1)
+			}
+
+			projects = append(projects, project)
+		}
+
+		var err error
+
+		switch args[0] {
+		case "projects":
+			err = printProjects(os.Stdout, format, projects)
+		case "apps":
+			applications := make([]applicationOutput, 0)
+			for _, project := range projects {
+				for _, application := range project.Applications {
+					application.Project = project.Name
+					applications = append(applications, application)
+				}
+			}
+
+			err = printApplications(os.Stdout, format, applications)
+		case "forwards":
+			forwards := make([]forwardOutput, 0)
+			for _, project := range projects {
+				for _, forward := range project.Forwards {
+					forward.Project = project.Name
+					forwards = append(forwards, forward)
+				}
+			}
+
+			err = printForwards(os.Stdout, format, forwards)
+		}
+
+		if err != nil {
+			fmt.Printf("❌  %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+var showCmd = &cobra.Command{
+	Use:   "show <project>",
+	Short: "This command prints the local applications and forwards of a project",
+	Long: `Prints what a project runs: its local applications and forwards resolved with the projects it extends
+and preceded by the global ones, with their paths, hostnames, ports, forward types and contexts.
+Use --output (-o) to print them as a table, JSON or YAML.`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeProject,
+	Run: func(cmd *cobra.Command, args []string) {
+		format, conf := loadListConfig(cmd)
+
+		project, err := getProjectOutput(conf, args[0])
+		if err != nil {
+			fmt.Printf("❌  %v\n", err)
+			os.Exit(1)
+		}
+
+		if format != listOutputTable {
+			err = encode(os.Stdout, format, project)
+		} else {
+			err = printProject(os.Stdout, project)
+		}
+
+		if err != nil {
+			fmt.Printf("❌  %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// loadListConfig returns the output format given as flag and the configuration, it exits when one of them is invalid
+func loadListConfig(cmd *cobra.Command) (string, *config.Config) {
+	format, _ := cmd.Flags().GetString("output")
+	if !contains(listOutputs, format) {
+		fmt.Printf("❌  Unknown output format '%s', please use %s\n", format, strings.Join(listOutputs, ", "))
+		os.Exit(1)
+	}
+
+	conf, err := config.Load()
+	if err != nil {
+		fmt.Printf("❌  %v\n", err)
+		os.Exit(1)
+	}
+
+	return format, conf
+}
+
+// getProjectOutput returns the given project as printed, with the applications and forwards it runs
+func getProjectOutput(conf *config.Config, name string) (*projectOutput, error) {
+	project, err := getProject(conf, selection{projects: []string{name}})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &projectOutput{
+		Name:         project.Name,
+		Applications: make([]applicationOutput, 0, len(project.Applications)),
+		Forwards:     make([]forwardOutput, 0, len(project.Forwards)),
+	}
+
+	for _, declared := range conf.Projects {
+		if declared.Name == name {
+			result.Extends = declared.Extends
+		}
+	}
+
+	for _, application := range project.Applications {
+		output := applicationOutput{
+			Name:      application.Name,
+			Path:      application.GetPath(),
+			Hostname:  application.Hostname,
+			Watch:     application.Watch,
+			DependsOn: application.DependsOn,
+		}
+
+		if application.Run != nil {
+			output.Command = application.Run.Command
+		}
+
+		result.Applications = append(result.Applications, output)
+	}
+
+	for _, forward := range project.Forwards {
+		result.Forwards = append(result.Forwards, forwardOutput{
+			Name:      forward.Name,
+			Type:      forward.Type,
+			Context:   forward.Values.Context,
+			Namespace: forward.Values.Namespace,
+			Labels:    forward.Values.Labels,
+			Remote:    forward.Values.Remote,
+			Hostname:  forward.Values.Hostname,
+			Ports:     forward.Values.Ports,
+			Proxified: forward.IsProxified(),
+		})
+	}
+
+	return result, nil
+}
+
+// printProject prints the local applications and forwards of a project as tables
+func printProject(w io.Writer, project *projectOutput) error {
+	fmt.Fprintf(w, "⇢  %s", project.Name)
+	if len(project.Extends) > 0 {
+		fmt.Fprintf(w, " (extends %s)", strings.Join(project.Extends, ", "))
+	}
+	if config.ProfileName != "" {
+		fmt.Fprintf(w, " (profile %s)", config.ProfileName)
+	}
+	fmt.Fprint(w, "\n")
+
+	if len(project.Applications) > 0 {
+		fmt.Fprint(w, "\nLocal applications:\n")
+		if err := printApplications(w, listOutputTable, project.Applications); err != nil {
+			return err
+		}
+	}
+
+	if len(project.Forwards) > 0 {
+		fmt.Fprint(w, "\nForwards:\n")
+		if err := printForwards(w, listOutputTable, project.Forwards); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// printProjects prints the given projects, with the names of their applications and forwards
+func printProjects(w io.Writer, format string, projects []*projectOutput) error {
+	if format != listOutputTable {
+		return encode(w, format, projects)
+	}
+
+	writer := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(writer, "NAME\tEXTENDS\tAPPS\tFORWARDS")
+
+	for _, project := range projects {
+		applications := make([]string, 0, len(project.Applications))
+		for _, application := range project.Applications {
+			applications = append(applications, application.Name)
+		}
+
+		forwards := make([]string, 0, len(project.Forwards))
+		for _, forward := range project.Forwards {
+			forwards = append(forwards, forward.Name)
+		}
+
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n",
+			project.Name, orDash(strings.Join(project.Extends, ", ")),
+			orDash(strings.Join(applications, ", ")), orDash(strings.Join(forwards, ", ")),
+		)
+	}
+
+	return writer.Flush()
+}
+
+// printApplications prints the given local applications, with their project when they have one
+func printApplications(w io.Writer, format string, applications []applicationOutput) error {
+	if format != listOutputTable {
+		return encode(w, format, applications)
+	}
+
+	writer := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	withProject := len(applications) > 0 && applications[0].Project != ""
+
+	if withProject {
+		fmt.Fprint(writer, "PROJECT\t")
+	}
+	fmt.Fprintln(writer, "NAME\tPATH\tHOSTNAME\tWATCH\tDEPENDS ON\tCOMMAND")
+
+	for _, application := range applications {
+		if withProject {
+			fmt.Fprintf(writer, "%s\t", application.Project)
+		}
+
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%t\t%s\t%s\n",
+			application.Name, orDash(application.Path), orDash(application.Hostname), application.Watch,
+			orDash(strings.Join(application.DependsOn, ", ")), orDash(application.Command),
+		)
+	}
+
+	return writer.Flush()
+}
+
+// printForwards prints the given forwards, with their project when they have one
+func printForwards(w io.Writer, format string, forwards []forwardOutput) error {
+	if format != listOutputTable {
+		return encode(w, format, forwards)
+	}
+
+	writer := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	withProject := len(forwards) > 0 && forwards[0].Project != ""
+
+	if withProject {
+		fmt.Fprint(writer, "PROJECT\t")
+	}
+	fmt.Fprintln(writer, "NAME\tTYPE\tCONTEXT\tNAMESPACE\tTARGET\tHOSTNAME\tPORTS")
+
+	for _, forward := range forwards {
+		if withProject {
+			fmt.Fprintf(writer, "%s\t", forward.Project)
+		}
+
+		// The target is the pods selected by their labels, or the remote host
+		target := forward.Remote
+		if len(forward.Labels) > 0 {
+			labels := make([]string, 0, len(forward.Labels))
+			for key, value := range forward.Labels {
+				labels = append(labels, key+"="+value)
+			}
+			sort.Strings(labels)
+
+			target = strings.Join(labels, ",")
+		}
+
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			forward.Name, forward.Type, orDash(forward.Context), orDash(forward.Namespace), orDash(target),
+			orDash(forward.Hostname), orDash(strings.Join(forward.Ports, ", ")),
+		)
+	}
+
+	return writer.Flush()
+}
+
+// encode writes the given value as JSON or YAML
+func encode(w io.Writer, format string, value interface{}) error {
+	if format == listOutputYAML {
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+
+		if err := encoder.Encode(value); err != nil {
+			return err
+		}
+
+		return encoder.Close()
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(value)
+}
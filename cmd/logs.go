@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/eko/monday/pkg/api"
+	"github.com/eko/monday/pkg/config"
+	"github.com/eko/monday/pkg/log"
+	"github.com/eko/monday/pkg/session"
+	"github.com/spf13/cobra"
+)
+
+func logsCmd(ctx context.Context) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "logs <application>",
+		Short: "This command prints the output of a local application, even after Monday has exited",
+		Long: `The output of local applications (including their build and setup commands) is written in
+~/.monday/logs/<project>/<application>.log (the directory can be changed with the 'logs.directory' configuration).
+Use --follow to print new lines as they are written and --since to only print the recent ones (10m, 2h or a RFC3339 date).
+When the project is running, the lines are read by its session.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			follow, _ := cmd.Flags().GetBool("follow")
+			project, _ := cmd.Flags().GetString("project")
+
+			since, err := log.ParseSince(cmd.Flag("since").Value.String())
+			if err != nil {
+				fmt.Printf("❌  %v\n", err)
+				os.Exit(1)
+			}
+
+			// Logs can still be read when the configuration is invalid, using the default directory
+			var logs *config.GlobalLogs
+			if conf, err := config.Load(); err == nil {
+				logs = conf.Logs
+			}
+
+			path, err := findLogFilepath(logs.GetDirectory(), project, args[0])
+			if err != nil {
+				fmt.Printf("❌  %v\n", err)
+				os.Exit(1)
+			}
+
+			// When the project is running, its session reads the file (which may only be readable by the user running it)
+			socket := session.GetSocketPath(config.GetSessionsPath(), filepath.Base(filepath.Dir(path)))
+			if session.IsListening(socket) {
+				if err := api.NewClient(socket).Logs(ctx, os.Stdout, args[0], 0, cmd.Flag("since").Value.String(), follow); err != nil {
+					fmt.Printf("❌  %v\n", err)
+					os.Exit(1)
+				}
+
+				return
+			}
+
+			if err := log.Read(ctx, os.Stdout, path, since, follow); err != nil {
+				fmt.Printf("❌  %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	command.Flags().BoolP("follow", "f", false, "Print new lines as they are written")
+	command.Flags().String("since", "", "Only print lines written since a duration (10m, 2h) or a RFC3339 date")
+	command.Flags().StringP("project", "p", "", "Project of the application, when it belongs to several ones")
+
+	return command
+}
+
+// findLogFilepath returns the log file of the given application, looking into all projects when none is given
+func findLogFilepath(directory, project, name string) (string, error) {
+	if project != "" {
+		return log.GetFilepath(directory, project, name), nil
+	}
+
+	pattern := log.GetFilepath(directory, "*", name)
+	matches, _ := filepath.Glob(pattern)
+	rotated, _ := filepath.Glob(pattern + ".*")
+
+	projects := make(map[string]bool)
+	for _, match := range append(matches, rotated...) {
+		projects[filepath.Base(filepath.Dir(match))] = true
+	}
+
+	names := make([]string, 0, len(projects))
+	for project := range projects {
+		names = append(names, project)
+	}
+	sort.Strings(names)
+
+	switch len(names) {
+	case 0:
+		return "", fmt.Errorf("No log file found for application '%s' in '%s'", name, directory)
+	case 1:
+		return log.GetFilepath(directory, names[0], name), nil
+	}
+
+	return "", fmt.Errorf("Application '%s' has logs in several projects (%s), please select one using --project", name, strings.Join(names, ", "))
+}
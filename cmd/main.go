@@ -7,15 +7,23 @@ import (
 	"os/signal"
 	"strconv"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/eko/monday/internal/runtime"
+	"github.com/eko/monday/pkg/api"
 	"github.com/eko/monday/pkg/build"
 	"github.com/eko/monday/pkg/config"
+	"github.com/eko/monday/pkg/control"
 	"github.com/eko/monday/pkg/forward"
+	"github.com/eko/monday/pkg/health"
 	"github.com/eko/monday/pkg/hostfile"
+	"github.com/eko/monday/pkg/log"
 	"github.com/eko/monday/pkg/proxy"
 	"github.com/eko/monday/pkg/run"
+	"github.com/eko/monday/pkg/session"
 	"github.com/eko/monday/pkg/setup"
+	"github.com/eko/monday/pkg/status"
 	"github.com/eko/monday/pkg/ui"
 	"github.com/eko/monday/pkg/watch"
 	"github.com/eko/monday/pkg/write"
@@ -27,30 +35,63 @@ import (
 
 const (
 	name = "Monday"
+
+	// shutdownTimeout is the maximum duration given to all components to stop and clean up their resources
+	shutdownTimeout = 30 * time.Second
+
+	// commandsHelp lists the key bindings of the terminal UI, displayed in its status bar
+	commandsHelp = "←/→: select view | ↑/↓: scroll up/down | a: toggle autoscroll | f: toggle fullscreen | /: search (n/N: next/previous) | tab: next app | s: select apps | e: errors only | enter: app/forward actions"
 )
 
 var (
 	Version string
 
-	proxyfier proxy.Proxy
-	forwarder forward.Forwarder
-	setuper   setup.Setuper
-	builder   build.Builder
-	writer    write.Writer
-	runner    run.Runner
-	watcher   watch.Watcher
+	proxyfier  proxy.Proxy
+	forwarder  forward.Forwarder
+	setuper    setup.Setuper
+	builder    build.Builder
+	writer     write.Writer
+	runner     run.Runner
+	checker    health.Checker
+	watcher    watch.Watcher
+	controller control.Controller
+	server     api.Server
+	registry   status.Registry
+	files      log.Files
+
+	// current is the session file of the running project, once written
+	current *session.Session
+
+	// broadcast receives the output of the views, sent to the terminals attached to the session
+	broadcast *ui.Broadcast
+
+	// exitView displays the shutdown messages, once the UI is closed
+	exitView ui.View
 
 	uiEnabled = len(os.Getenv("MONDAY_ENABLE_UI")) > 0
+	output    = ui.OutputText
+
+	// detached is set when the project runs in the background, started using 'monday up -d'
+	detached bool
 )
 
 func main() {
-	ctx := context.Background()
+	// The root context is cancelled on exit signals (or when quitting the UI), stopping all the components
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+	defer cancel()
+
 	runtime.InitRuntimeEnvironment()
 
 	rootCmd := &cobra.Command{
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			if profile := cmd.Flag("profile").Value.String(); profile != "" {
+				config.ProfileName = profile
+			}
+		},
 		Run: func(cmd *cobra.Command, args []string) {
-			if !uiEnabled {
-				uiEnabled, _ = strconv.ParseBool(cmd.Flag("ui").Value.String())
+			if err := parseOutputFlags(cmd); err != nil {
+				fmt.Printf("❌  %v\n", err)
+				os.Exit(1)
 			}
 
 			conf, err := config.Load()
@@ -59,22 +100,60 @@ func main() {
 				return
 			}
 
-			runProject(ctx, conf, selectProject(conf))
+			runProject(ctx, cancel, conf, getSelection(cmd, conf, args))
 
-			handleExitSignal(ctx)
+			handleExitSignal(ctx, cancel)
 		},
 	}
 
-	// UI-enable flag (for both root and run commands)
-	runCommand := runCmd(ctx)
-	runCommand.Flags().Bool("ui", false, "Enable the terminal UI")
-	rootCmd.Flags().Bool("ui", false, "Enable the terminal UI")
+	// UI-enable, output and filter flags (for root, run and up commands)
+	runCommand := runCmd(ctx, cancel)
+	upCommand := upCmd(ctx, cancel)
+	for _, command := range []*cobra.Command{rootCmd, runCommand, upCommand} {
+		command.Flags().Bool("ui", false, "Enable the terminal UI")
+		command.Flags().String("output", ui.OutputText, "Output format when the UI is not enabled (text or json)")
+		command.Flags().StringSlice("only", nil, "Only run these applications and forwards (comma-separated names)")
+		command.Flags().StringSlice("except", nil, "Do not run these applications and forwards (comma-separated names)")
+
+		command.RegisterFlagCompletionFunc("output", cobra.FixedCompletions([]string{ui.OutputText, ui.OutputJSON}, cobra.ShellCompDirectiveNoFileComp))
+		command.RegisterFlagCompletionFunc("only", completeProjectItems)
+		command.RegisterFlagCompletionFunc("except", completeProjectItems)
+	}
+	runCommand.ValidArgsFunction = completeProjects
+	upCommand.ValidArgsFunction = completeProjects
 
+	// Output flag (for list and show commands)
+	for _, command := range []*cobra.Command{listCmd, showCmd} {
+		command.Flags().StringP("output", "o", listOutputTable, "Output format (table, json or yaml)")
+		command.RegisterFlagCompletionFunc("output", cobra.FixedCompletions(listOutputs, cobra.ShellCompDirectiveNoFileComp))
+	}
+	listCmd.Flags().StringP("project", "p", "", "Only list the applications and forwards of this project")
+	listCmd.RegisterFlagCompletionFunc("project", completeProjects)
+
+	// Profile flag (for all commands loading the configuration)
+	rootCmd.PersistentFlags().String("profile", "", "Apply a configuration profile (staging, preprod, ...)")
+
+	// Config subcommands
+	configPrintCmd.Flags().Bool("resolved", false, "Print the final merged configuration with the source of every value")
+	configCmd.AddCommand(configPrintCmd)
+	configCmd.AddCommand(configSyncCmd)
+
+	rootCmd.AddCommand(attachCmd(ctx))
 	rootCmd.AddCommand(completionCmd)
+	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(downCmd(ctx))
 	rootCmd.AddCommand(editCmd)
 	rootCmd.AddCommand(initCmd)
+	rootCmd.AddCommand(listCmd)
+	rootCmd.AddCommand(logsCmd(ctx))
+	rootCmd.AddCommand(restartCmd(ctx))
 	rootCmd.AddCommand(runCommand)
+	rootCmd.AddCommand(showCmd)
+	rootCmd.AddCommand(statusCmd(ctx))
+	rootCmd.AddCommand(stopCmd(ctx))
+	rootCmd.AddCommand(upCommand)
 	rootCmd.AddCommand(upgradeCmd)
+	rootCmd.AddCommand(validateCmd)
 	rootCmd.AddCommand(versionCmd)
 
 	if err := rootCmd.Execute(); err != nil {
@@ -83,49 +162,123 @@ func main() {
 	}
 }
 
-func selectProject(conf *config.Config) string {
+// selectProjects asks for the project to run, several ones being selectable using the last item
+func selectProjects(conf *config.Config) []string {
 	projects := conf.GetProjectNames()
+	items := append(append([]string{}, projects...), "✚  Run several projects together...")
 
 	prompt := promptui.Select{
-		Label: "Which project do you want to work on?",
-		Items: projects,
-		Size:  20,
-		Searcher: func(input string, index int) bool {
-			return strings.Contains(
-				strings.Replace(strings.ToLower(projects[index]), " ", "", -1),
-				strings.Replace(strings.ToLower(input), " ", "", -1),
-			)
-		},
+		Label:    "Which project do you want to work on?",
+		Items:    items,
+		Size:     20,
+		Searcher: newSearcher(items),
 	}
 
-	_, choice, err := prompt.Run()
+	index, choice, err := prompt.Run()
 	if err != nil {
-		if err.Error() == "^C" {
-			fmt.Println("\n👋  Bye")
-			os.Exit(0)
-		} else {
-			panic(fmt.Sprintf("selection error:\n%v", err))
+		handleSelectionError(err)
+	}
+
+	if index < len(projects) {
+		fmt.Print("\n")
+		return []string{choice}
+	}
+
+	// Each project is selected or unselected in turn, until the first item is chosen
+	selected := make(map[string]bool)
+	cursor, scroll := 1, 0
+
+	for {
+		names := make([]string, 0, len(projects))
+		items := []string{"▶  Run the selected projects"}
+
+		for _, project := range projects {
+			if selected[project] {
+				names = append(names, project)
+				items = append(items, "[x] "+project)
+			} else {
+				items = append(items, "[ ] "+project)
+			}
 		}
+
+		if len(names) > 0 {
+			items[0] = fmt.Sprintf("▶  Run the selected projects (%s)", strings.Join(names, ", "))
+		}
+
+		prompt := promptui.Select{
+			Label:        "Which projects do you want to run together? (enter: select/unselect)",
+			HideSelected: true,
+			Items:        items,
+			Size:         20,
+			Searcher:     newSearcher(items),
+		}
+
+		index, _, err := prompt.RunCursorAt(cursor, scroll)
+		if err != nil {
+			handleSelectionError(err)
+		}
+
+		if index == 0 {
+			if len(names) == 0 {
+				continue
+			}
+
+			fmt.Print("\n")
+			return names
+		}
+
+		project := projects[index-1]
+		selected[project] = !selected[project]
+		cursor, scroll = index, prompt.ScrollPosition()
 	}
+}
+
+// newSearcher returns a search function of a selection prompt, matching the given items ignoring case and spaces
+func newSearcher(items []string) func(input string, index int) bool {
+	return func(input string, index int) bool {
+		return strings.Contains(
+			strings.Replace(strings.ToLower(items[index]), " ", "", -1),
+			strings.Replace(strings.ToLower(input), " ", "", -1),
+		)
+	}
+}
 
-	fmt.Print("\n")
+// handleSelectionError exits when the selection has been cancelled, or panics
+func handleSelectionError(err error) {
+	if err.Error() == "^C" {
+		fmt.Println("\n👋  Bye")
+		os.Exit(0)
+	}
 
-	return choice
+	panic(fmt.Sprintf("selection error:\n%v", err))
 }
 
-func runProject(ctx context.Context, conf *config.Config, choice string) {
-	layout := ui.NewLayout(uiEnabled)
+func runProject(ctx context.Context, cancel context.CancelFunc, conf *config.Config, choice selection) {
+	// Colliding or unknown applications and forwards are reported before anything starts
+	project, err := getProject(conf, choice)
+	if err != nil {
+		fmt.Printf("❌  %v\n", err)
+		os.Exit(1)
+	}
+
+	checkSession(choice.projects)
+
+	layout := ui.NewLayout(uiEnabled, output)
 	layout.Init()
 
-	// Retrieve selected project configuration by its name
-	project, err := conf.GetProjectByName(choice)
-	if err != nil {
-		panic(err)
+	broadcast = ui.NewBroadcast()
+	layout.SetBroadcast(broadcast)
+
+	exitView = ui.NewEmptyView("exit")
+	if !uiEnabled {
+		exitView = layout.GetLogsView().WithComponent("monday")
 	}
 
-	// Prepend global configurations
-	project.PrependApplications(conf.Applications)
-	project.PrependForwards(conf.Forwards)
+	applicationNames := make([]string, 0, len(project.Applications))
+	for _, application := range project.Applications {
+		applicationNames = append(applicationNames, application.Name)
+	}
+	layout.SetApplications(applicationNames)
 
 	// Initializes hosts file manager
 	hostfile, err := hostfile.NewClient()
@@ -133,57 +286,173 @@ func runProject(ctx context.Context, conf *config.Config, choice string) {
 		panic(err)
 	}
 
-	proxyfier = proxy.NewProxy(layout.GetProxyView(), hostfile)
-	setuper = setup.NewSetuper(layout.GetLogsView(), project, conf.Setup)
-	builder = build.NewBuilder(layout.GetLogsView(), project, conf.Build)
-	writer = write.NewWriter(layout.GetLogsView(), project)
-	runner = run.NewRunner(layout.GetLogsView(), proxyfier, project, conf.Run)
-	forwarder = forward.NewForwarder(layout.GetForwardsView(), proxyfier, project)
+	registry = status.NewRegistry()
+	files = log.NewFiles(layout.GetLogsView().WithComponent("logs"), conf.Logs.GetDirectory(), project.Name, conf.Logs.GetMaxSize(), conf.Logs.GetMaxFiles())
 
-	watcher = watch.NewWatcher(setuper, builder, writer, runner, forwarder, conf.Watch, project)
+	proxyfier = proxy.NewProxy(layout.GetProxyView().WithComponent("proxy"), hostfile)
+	setuper = setup.NewSetuper(layout.GetLogsView().WithComponent("setuper"), files, project, conf.Setup)
+	builder = build.NewBuilder(layout.GetLogsView().WithComponent("builder"), registry, files, project, conf.Build)
+	writer = write.NewWriter(layout.GetLogsView().WithComponent("writer"), project)
+	checker = health.NewChecker(layout.GetLogsView().WithComponent("checker"), registry)
+	runner = run.NewRunner(layout.GetLogsView().WithComponent("runner"), proxyfier, checker, registry, files, project, conf.Run)
+	forwarder = forward.NewForwarder(layout.GetForwardsView().WithComponent("forwarder"), proxyfier, registry, project)
+
+	watcher = watch.NewWatcher(layout.GetLogsView().WithComponent("watcher"), setuper, builder, writer, runner, forwarder, conf.Watch, project)
 	go watcher.Watch(ctx)
 
+	controller = control.NewController(layout.GetLogsView().WithComponent("controller"), registry, watcher, setuper, builder, runner, forwarder)
+	setControls(ctx, layout, registry, controller)
+
+	startSession(ctx, cancel, layout, project, conf)
+
+	// Reload the project each time the configuration changes
+	err = watcher.WatchConfig(ctx, config.FindConfigPatterns(), func() (*config.Project, error) {
+		conf, err := config.Load()
+		if err != nil {
+			return nil, err
+		}
+
+		return getProject(conf, choice)
+	})
+	if err != nil {
+		layout.GetLogsView().WithComponent("watcher").Writef("❌  %v\n", err)
+	}
+
 	if uiEnabled {
 		defer layout.GetGui().Close()
 
-		if err := layout.GetGui().SetKeybinding("", gocui.KeyCtrlC, gocui.ModNone, quit(ctx)); err != nil {
+		if err := layout.GetGui().SetKeybinding("", gocui.KeyCtrlC, gocui.ModNone, quit(cancel)); err != nil {
 			panic(err)
 		}
 
-		layout.GetStatusView().Writef(" ⇢  %s | Commands: ←/→: select view | ↑/↓: scroll up/down | a: toggle autoscroll | f: toggle fullscreen", choice)
+		// Close the UI when an exit signal is received
+		go func() {
+			<-ctx.Done()
+			layout.GetGui().Update(func(g *gocui.Gui) error {
+				return gocui.ErrQuit
+			})
+		}()
+
+		status := project.Name
+		if config.ProfileName != "" {
+			status = fmt.Sprintf("%s (%s)", project.Name, config.ProfileName)
+		}
+
+		layout.GetStatusView().Writef(" ⇢  %s | Commands: %s", status, commandsHelp)
 
 		if err := layout.GetGui().MainLoop(); err != nil && err != gocui.ErrQuit {
 			fmt.Println(err)
-			stopAll(ctx)
+			cancel()
 		}
 	}
 }
 
 // Handle for an exit signal in order to quit application on a proper way (shutting down connections and servers).
-func handleExitSignal(ctx context.Context) {
-	stop := make(chan os.Signal, 1)
-	signal.Notify(stop, os.Interrupt, os.Kill)
+func handleExitSignal(ctx context.Context, cancel context.CancelFunc) {
+	<-ctx.Done()
+
+	// Keep catching signals until everything is stopped: a second one (sent by wrappers such as 'timeout')
+	// would otherwise terminate Monday, leaving local applications and hosts entries behind
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+
+	go func() {
+		for range signals {
+			exitView.Write("⏳  Still closing your local applications and remote connections, please wait\n")
+		}
+	}()
 
-	<-stop
+	cancel()
 
-	stopAll(ctx)
+	stopAll()
 }
 
-func stopAll(ctx context.Context) {
-	fmt.Println("\n👋  Bye, closing your local applications and remote connections now")
+// stopAll stops all the components within the shutdown deadline and reports the resources
+// (hosts entries, loopback aliases, remote deployments, ...) that could not be cleaned up
+func stopAll() {
+	exitView.Write("\n👋  Bye, closing your local applications and remote connections now\n")
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
 
-	watcher.Stop()
-	forwarder.Stop(ctx)
-	proxyfier.Stop()
-	runner.Stop()
+	done := make(chan []error, 1)
 
-	os.Exit(0)
+	go func() {
+		done <- []error{
+			stopSession(),
+			watcher.Stop(),
+			runner.Stop(ctx),
+			forwarder.Stop(ctx),
+			proxyfier.Stop(),
+			files.Close(),
+		}
+	}()
+
+	var errs []error
+
+	select {
+	case errs = <-done:
+	case <-ctx.Done():
+		errs = []error{fmt.Errorf("shutdown did not complete within %s, some local applications or remote connections may still be running", shutdownTimeout)}
+	}
+
+	errs = flattenErrors(errs)
+	if len(errs) == 0 {
+		os.Exit(0)
+	}
+
+	report := "❌  Some resources could not be cleaned up, you may have to remove them manually:\n"
+	for _, err := range errs {
+		report += fmt.Sprintf("   - %s\n", strings.ReplaceAll(err.Error(), "\n", "\n     "))
+	}
+
+	exitView.Write(report)
+
+	os.Exit(1)
+}
+
+// flattenErrors returns the non-nil errors of the given list, joined errors being split
+func flattenErrors(errs []error) []error {
+	result := make([]error, 0)
+
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+
+		if joined, ok := err.(interface{ Unwrap() []error }); ok {
+			result = append(result, flattenErrors(joined.Unwrap())...)
+			continue
+		}
+
+		result = append(result, err)
+	}
+
+	return result
 }
 
-func quit(ctx context.Context) func(*gocui.Gui, *gocui.View) error {
+func quit(cancel context.CancelFunc) func(*gocui.Gui, *gocui.View) error {
 	return func(g *gocui.Gui, v *gocui.View) error {
-		g.Close()
-		stopAll(ctx)
+		cancel()
 		return gocui.ErrQuit
 	}
 }
+
+// parseOutputFlags enables the UI and selects the output format given the command flags
+func parseOutputFlags(cmd *cobra.Command) error {
+	if !uiEnabled {
+		uiEnabled, _ = strconv.ParseBool(cmd.Flag("ui").Value.String())
+	}
+
+	output = cmd.Flag("output").Value.String()
+
+	if !ui.AvailableOutputs[output] {
+		return fmt.Errorf("Unknown output format '%s', please use 'text' or 'json'", output)
+	}
+
+	if uiEnabled && output != ui.OutputText {
+		return fmt.Errorf("The '%s' output cannot be used with the terminal UI", output)
+	}
+
+	return nil
+}
@@ -3,21 +3,23 @@ package main
 import (
 	"context"
 	"fmt"
-	"strconv"
+	"os"
 
 	"github.com/eko/monday/pkg/config"
 	"github.com/spf13/cobra"
 )
 
-func runCmd(ctx context.Context) *cobra.Command {
+func runCmd(ctx context.Context, cancel context.CancelFunc) *cobra.Command {
 	return &cobra.Command{
-		Use:   "run",
+		Use:   "run [project...]",
 		Short: "This command allows you to run a specific project directly",
 		Long: `In case you already have the project name you want to launch, you can launch it directly by using the run command
-	and passing it as an argument`,
+	and passing it as an argument. Several projects can be given to run them together, and --only or --except
+	(comma-separated names) select some of their local applications and forwards`,
 		Run: func(cmd *cobra.Command, args []string) {
-			if !uiEnabled {
-				uiEnabled, _ = strconv.ParseBool(cmd.Flag("ui").Value.String())
+			if err := parseOutputFlags(cmd); err != nil {
+				fmt.Printf("❌  %v\n", err)
+				os.Exit(1)
 			}
 
 			conf, err := config.Load()
@@ -26,15 +28,8 @@ func runCmd(ctx context.Context) *cobra.Command {
 				return
 			}
 
-			var choice string
-			if len(args) > 0 {
-				choice = args[0]
-			} else {
-				choice = selectProject(conf)
-			}
-
-			runProject(ctx, conf, choice)
-			handleExitSignal(ctx)
+			runProject(ctx, cancel, conf, getSelection(cmd, conf, args))
+			handleExitSignal(ctx, cancel)
 		},
 	}
 }
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/eko/monday/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+// selection represents the projects to run together, filtered to some of their applications and forwards
+type selection struct {
+	projects []string
+	only     []string
+	except   []string
+}
+
+// getSelection returns the projects given as arguments (or selected in a prompt when none is given)
+// and the application and forward filters given as flags
+func getSelection(cmd *cobra.Command, conf *config.Config, args []string) selection {
+	choice := selection{projects: args}
+	if len(choice.projects) == 0 {
+		choice.projects = selectProjects(conf)
+	}
+
+	choice.only, _ = cmd.Flags().GetStringSlice("only")
+	choice.except, _ = cmd.Flags().GetStringSlice("except")
+
+	return choice
+}
+
+// name returns the name of the running project, identifying its session and log files
+func (s selection) name() string {
+	return strings.Join(s.projects, config.ProjectSeparator)
+}
+
+// args returns the command arguments and flags to run the same selection in another process
+func (s selection) args() []string {
+	args := append([]string{}, s.projects...)
+
+	if len(s.only) > 0 {
+		args = append(args, "--only", strings.Join(s.only, ","))
+	}
+
+	if len(s.except) > 0 {
+		args = append(args, "--except", strings.Join(s.except, ","))
+	}
+
+	return args
+}
+
+// getProject retrieves the configuration of the selected projects, combined in a single project
+// with the global applications and forwards, and filters its applications and forwards
+func getProject(conf *config.Config, choice selection) (*config.Project, error) {
+	project, err := conf.CombineProjects(choice.projects)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := project.Filter(choice.only, choice.except); err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/eko/monday/pkg/api"
+	"github.com/eko/monday/pkg/config"
+	"github.com/eko/monday/pkg/session"
+	"github.com/eko/monday/pkg/ui"
+)
+
+// checkSession exits when a running session already runs one of the given projects, as a project can only run once
+func checkSession(projects []string) {
+	sessions, err := session.List(config.GetSessionsPath())
+	if err != nil {
+		return
+	}
+
+	for _, running := range sessions {
+		for _, name := range strings.Split(running.Project, config.ProjectSeparator) {
+			if !contains(projects, name) || !running.IsRunning() {
+				continue
+			}
+
+			fmt.Printf("❌  A Monday session is already running for project '%s', use 'monday status %s' to see it or 'monday down %s' to stop it\n", running.Project, running.Project, running.Project)
+			os.Exit(1)
+		}
+	}
+}
+
+// startSession exposes the running project over the control API and writes its session file,
+// the session being stopped using the given cancel function when requested over the API
+func startSession(ctx context.Context, cancel context.CancelFunc, layout *ui.Layout, project *config.Project, conf *config.Config) {
+	view := layout.GetLogsView().WithComponent("api")
+	directory := config.GetSessionsPath()
+	socket := session.GetSocketPath(directory, project.Name)
+
+	server = api.NewServer(view, socket, project.Name, conf.Logs.GetDirectory(), registry, controller, proxyfier, broadcast, cancel)
+	if err := server.Listen(ctx); err != nil {
+		view.Writef("❌  %v\n", err)
+		return
+	}
+
+	current = &session.Session{
+		Project:   project.Name,
+		Profile:   config.ProfileName,
+		PID:       os.Getpid(),
+		Socket:    socket,
+		Detached:  detached,
+		StartedAt: time.Now(),
+	}
+
+	if detached {
+		current.LogFile = session.GetLogFilepath(directory, project.Name)
+	}
+
+	if err := session.Write(directory, current); err != nil {
+		view.Writef("❌  %v\n", err)
+	}
+}
+
+// stopSession closes the control API and removes the session file
+func stopSession() error {
+	if server == nil {
+		return nil
+	}
+
+	err := server.Stop()
+
+	if current != nil {
+		err = errors.Join(err, session.Remove(config.GetSessionsPath(), current.Project))
+	}
+
+	return err
+}
+
+func contains(values []string, value string) bool {
+	for _, current := range values {
+		if current == value {
+			return true
+		}
+	}
+
+	return false
+}
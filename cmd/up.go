@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"syscall"
+	"time"
+
+	"github.com/eko/monday/pkg/config"
+	"github.com/eko/monday/pkg/session"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// detachTimeout is the maximum duration given to a detached session to start its control API
+	detachTimeout = 30 * time.Second
+)
+
+func upCmd(ctx context.Context, cancel context.CancelFunc) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "up [project...]",
+		Short: "This command runs a project, in the background when detached",
+		Long: `Runs a project like the run command. Using --detach (-d), the project keeps running in the background once
+the terminal is closed, its output being written in ~/.monday/sessions/<project>.log. Use the status, restart, stop,
+logs and down commands to manage it. Several projects and the --only and --except filters can be given, like the run command.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := parseOutputFlags(cmd); err != nil {
+				fmt.Printf("❌  %v\n", err)
+				os.Exit(1)
+			}
+
+			conf, err := config.Load()
+			if err != nil {
+				fmt.Printf("❌  %v\n", err)
+				return
+			}
+
+			choice := getSelection(cmd, conf, args)
+
+			if detach, _ := cmd.Flags().GetBool("detach"); detach {
+				if uiEnabled {
+					fmt.Println("❌  The terminal UI cannot be enabled when the project runs in the background")
+					os.Exit(1)
+				}
+
+				// Colliding or unknown applications and forwards are reported before starting the background process
+				if _, err := getProject(conf, choice); err != nil {
+					fmt.Printf("❌  %v\n", err)
+					os.Exit(1)
+				}
+
+				if err := detachProject(choice); err != nil {
+					fmt.Printf("❌  %v\n", err)
+					os.Exit(1)
+				}
+
+				return
+			}
+
+			// The background process has no terminal to display the UI in
+			if detached, _ = cmd.Flags().GetBool("detached"); detached {
+				uiEnabled = false
+			}
+
+			runProject(ctx, cancel, conf, choice)
+			handleExitSignal(ctx, cancel)
+		},
+	}
+
+	command.Flags().BoolP("detach", "d", false, "Run the project in the background")
+	command.Flags().Bool("detached", false, "Run as the background process of a detached project")
+	command.Flags().MarkHidden("detached")
+
+	return command
+}
+
+// detachProject runs the selected projects in a new background process, returning once its control API is listening
+func detachProject(choice selection) error {
+	checkSession(choice.projects)
+
+	project := choice.name()
+
+	directory := config.GetSessionsPath()
+	if err := session.MkdirAll(directory); err != nil {
+		return err
+	}
+
+	logPath := session.GetLogFilepath(directory, project)
+
+	logFile, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("Unable to open the session log file '%s': %v", logPath, err)
+	}
+	defer logFile.Close()
+
+	session.SetOwner(logPath)
+
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("Unable to find the Monday executable: %v", err)
+	}
+
+	args := append(append([]string{"up"}, choice.args()...), "--detached", "--output", output)
+	if config.ProfileName != "" {
+		args = append(args, "--profile", config.ProfileName)
+	}
+
+	// The process runs in its own session so it is not stopped with the terminal
+	cmd := exec.Command(executable, args...)
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("Unable to start the Monday session in the background: %v", err)
+	}
+
+	exited := make(chan error, 1)
+	go func() {
+		exited <- cmd.Wait()
+	}()
+
+	socket := session.GetSocketPath(directory, project)
+	timeout := time.After(detachTimeout)
+
+	for !session.IsListening(socket) {
+		select {
+		case <-exited:
+			return fmt.Errorf("Monday session has exited before being ready, see '%s' for details", logPath)
+		case <-timeout:
+			return fmt.Errorf("Monday session is still not reachable after %s, see '%s' for details", detachTimeout, logPath)
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+
+	fmt.Printf("🚀  Project '%s' is running in the background (pid %d), its output is written in '%s'\n", project, cmd.Process.Pid, logPath)
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/eko/monday/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "This command checks your configuration files and reports every problem found",
+	Long: `Projects, local applications, forwards and files declared in your configuration files are checked
+(known types, ports syntax, required sections, duplicate names and hostnames) and each problem is reported
+with the file and line it comes from.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		_, err := config.Load()
+		if err == nil {
+			fmt.Println("✅  Your configuration is valid")
+			return
+		}
+
+		if validationErrors, ok := err.(config.ValidationErrors); ok {
+			for _, validationError := range validationErrors {
+				fmt.Printf("❌  %v\n", validationError)
+			}
+
+			fmt.Printf("\n%d problem(s) found in your configuration\n", len(validationErrors))
+		} else {
+			fmt.Printf("❌  %v\n", err)
+		}
+
+		os.Exit(1)
+	},
+}
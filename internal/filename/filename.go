@@ -0,0 +1,10 @@
+package filename
+
+import "strings"
+
+var replacer = strings.NewReplacer("/", "-", "\\", "-")
+
+// Sanitize returns the given name without any path separator so it can be used as a file name
+func Sanitize(name string) string {
+	return replacer.Replace(name)
+}
@@ -0,0 +1,15 @@
+package filename
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitize(t *testing.T) {
+	// When - Then
+	assert.Equal(t, "my-project", Sanitize("my-project"))
+	assert.Equal(t, "team-my-project", Sanitize("team/my-project"))
+	assert.Equal(t, "team-my-project", Sanitize("team\\my-project"))
+	assert.Equal(t, "..-..-etc", Sanitize("../../etc"))
+}
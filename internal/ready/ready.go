@@ -0,0 +1,90 @@
+package ready
+
+import (
+	"context"
+	"sync"
+)
+
+var (
+	mutex    sync.Mutex
+	channels = make(map[string]chan struct{})
+)
+
+// Set marks the application or forward having the given name as ready
+func Set(name string) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	ch := channel(name)
+
+	select {
+	case <-ch:
+		// Already ready
+	default:
+		close(ch)
+	}
+}
+
+// Unset marks the application or forward having the given name as not ready anymore
+func Unset(name string) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if ch, ok := channels[name]; ok {
+		select {
+		case <-ch:
+			delete(channels, name)
+		default:
+			// Still waited, keep the channel for current waiters
+		}
+	}
+}
+
+// IsReady indicates if the application or forward having the given name is ready
+func IsReady(name string) bool {
+	mutex.Lock()
+	ch := channel(name)
+	mutex.Unlock()
+
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+// Wait blocks until all the given applications or forwards are ready, or until the context is done
+func Wait(ctx context.Context, names ...string) error {
+	for _, name := range names {
+		mutex.Lock()
+		ch := channel(name)
+		mutex.Unlock()
+
+		select {
+		case <-ch:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
+
+// Reset marks all the applications and forwards as not ready
+func Reset() {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	channels = make(map[string]chan struct{})
+}
+
+func channel(name string) chan struct{} {
+	ch, ok := channels[name]
+	if !ok {
+		ch = make(chan struct{})
+		channels[name] = ch
+	}
+
+	return ch
+}
@@ -0,0 +1,67 @@
+package ready
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWait(t *testing.T) {
+	// Given
+	defer Reset()
+
+	done := make(chan error)
+
+	go func() {
+		done <- Wait(context.Background(), "user-api", "postgres")
+	}()
+
+	// When
+	Set("user-api")
+
+	select {
+	case <-done:
+		t.Fatal("Wait has returned before all dependencies are ready")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	Set("postgres")
+	Set("postgres")
+
+	// Then
+	assert.Nil(t, <-done)
+	assert.True(t, IsReady("user-api"))
+	assert.True(t, IsReady("postgres"))
+}
+
+func TestWaitWhenContextDone(t *testing.T) {
+	// Given
+	defer Reset()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// When
+	err := Wait(ctx, "user-api")
+
+	// Then
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestUnset(t *testing.T) {
+	// Given
+	defer Reset()
+
+	Set("user-api")
+
+	// When
+	Unset("user-api")
+
+	// Then
+	assert.False(t, IsReady("user-api"))
+
+	Set("user-api")
+	assert.True(t, IsReady("user-api"))
+}
@@ -0,0 +1,60 @@
+package redact
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	// Mask is the value displayed in place of secret values
+	Mask = "******"
+)
+
+var (
+	mutex  sync.RWMutex
+	values = make([]string, 0)
+)
+
+// Add registers a secret value that must never be displayed
+func Add(value string) {
+	if value == "" {
+		return
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	for _, existing := range values {
+		if existing == value {
+			return
+		}
+	}
+
+	values = append(values, value)
+
+	// Longest values are masked first so a value containing another one is fully masked
+	sort.Slice(values, func(i, j int) bool {
+		return len(values[i]) > len(values[j])
+	})
+}
+
+// String returns the given string with all the registered secret values masked
+func String(str string) string {
+	mutex.RLock()
+	defer mutex.RUnlock()
+
+	for _, value := range values {
+		str = strings.Replace(str, value, Mask, -1)
+	}
+
+	return str
+}
+
+// Reset removes all the registered secret values
+func Reset() {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	values = make([]string, 0)
+}
@@ -0,0 +1,30 @@
+package redact
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestString(t *testing.T) {
+	// Given
+	defer Reset()
+
+	Add("p4ssw0rd")
+	Add("p4ssw0rd-with-suffix")
+	Add("")
+
+	// When
+	result := String("password is p4ssw0rd and p4ssw0rd-with-suffix\n")
+
+	// Then
+	assert.Equal(t, "password is ****** and ******\n", result)
+}
+
+func TestStringWhenNoSecret(t *testing.T) {
+	// When
+	result := String("nothing to hide")
+
+	// Then
+	assert.Equal(t, "nothing to hide", result)
+}
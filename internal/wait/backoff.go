@@ -28,6 +28,11 @@ func (b *Backoff) Duration() time.Duration {
 	return d
 }
 
+// Reset restarts the backoff from its first attempt
+func (b *Backoff) Reset() {
+	atomic.StoreUint64(&b.attempt, 0)
+}
+
 func (b *Backoff) ForAttempt(attempt float64) time.Duration {
 	min := b.Min
 	if min <= 0 {
@@ -0,0 +1,174 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/eko/monday/pkg/control"
+	"github.com/eko/monday/pkg/status"
+	"github.com/eko/monday/pkg/ui"
+)
+
+const (
+	// baseURL is the URL of the requests sent over the socket, its host is ignored
+	baseURL = "http://monday"
+)
+
+// Client sends requests to the control API of a running session
+type Client interface {
+	GetStatus(ctx context.Context) (*Status, error)
+	Application(ctx context.Context, action control.Action, name string) (*status.Application, error)
+	Forward(ctx context.Context, action control.Action, name string) (*status.Forward, error)
+	Logs(ctx context.Context, w io.Writer, name string, lines int, since string, follow bool) error
+	Output(ctx context.Context, handle func(record ui.Record)) error
+	Shutdown(ctx context.Context) error
+}
+
+type client struct {
+	path       string
+	httpClient *http.Client
+}
+
+// NewClient instanciates a client of the control API listening on the socket at the given path
+func NewClient(path string) *client {
+	return &client{
+		path: path,
+		httpClient: &http.Client{
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					return (&net.Dialer{}).DialContext(ctx, "unix", path)
+				},
+			},
+		},
+	}
+}
+
+// GetStatus returns the status of the project, its local applications, forwards and proxified hostnames
+func (c *client) GetStatus(ctx context.Context) (*Status, error) {
+	var result Status
+	if err := c.do(ctx, http.MethodGet, "/status", &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// Application runs the given action on a local application and returns its status
+func (c *client) Application(ctx context.Context, action control.Action, name string) (*status.Application, error) {
+	var result status.Application
+	if err := c.do(ctx, http.MethodPost, fmt.Sprintf("/applications/%s/%s", url.PathEscape(name), action), &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// Forward runs the given action on a forward and returns its status
+func (c *client) Forward(ctx context.Context, action control.Action, name string) (*status.Forward, error) {
+	var result status.Forward
+	if err := c.do(ctx, http.MethodPost, fmt.Sprintf("/forwards/%s/%s", url.PathEscape(name), action), &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// Logs writes the recent lines of the log file of an application, then the new ones until the context
+// is done when follow is set
+func (c *client) Logs(ctx context.Context, w io.Writer, name string, lines int, since string, follow bool) error {
+	query := url.Values{}
+	query.Set("lines", strconv.Itoa(lines))
+	query.Set("since", since)
+	query.Set("follow", strconv.FormatBool(follow))
+
+	response, err := c.send(ctx, http.MethodGet, fmt.Sprintf("/logs/%s?%s", url.PathEscape(name), query.Encode()))
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if _, err := io.Copy(w, response.Body); err != nil && ctx.Err() == nil {
+		return err
+	}
+
+	return nil
+}
+
+// Output calls the given function with the last lines written to the views of the session, then with the new ones
+// until the context is done or the session has stopped
+func (c *client) Output(ctx context.Context, handle func(record ui.Record)) error {
+	response, err := c.send(ctx, http.MethodGet, "/output")
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	decoder := json.NewDecoder(response.Body)
+
+	for {
+		var record ui.Record
+		if err := decoder.Decode(&record); err != nil {
+			// The stream is interrupted when the session stops
+			if ctx.Err() != nil || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return nil
+			}
+
+			return err
+		}
+
+		handle(record)
+	}
+}
+
+// Shutdown stops the session, it returns once the session has acknowledged the request
+func (c *client) Shutdown(ctx context.Context) error {
+	return c.do(ctx, http.MethodPost, "/shutdown", nil)
+}
+
+// do sends a request and decodes the JSON response in the given value
+func (c *client) do(ctx context.Context, method, path string, value interface{}) error {
+	response, err := c.send(ctx, method, path)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if value == nil {
+		return nil
+	}
+
+	return json.NewDecoder(response.Body).Decode(value)
+}
+
+// send sends a request, returning the error message of the response when it has failed
+func (c *client) send(ctx context.Context, method, path string) (*http.Response, error) {
+	request, err := http.NewRequestWithContext(ctx, method, baseURL+path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to reach the Monday session on socket '%s': %v", c.path, err)
+	}
+
+	if response.StatusCode >= http.StatusBadRequest {
+		defer response.Body.Close()
+
+		var apiError Error
+		if err := json.NewDecoder(response.Body).Decode(&apiError); err != nil || apiError.Error == "" {
+			return nil, fmt.Errorf("Monday session has responded with status %d", response.StatusCode)
+		}
+
+		return nil, errors.New(apiError.Error)
+	}
+
+	return response, nil
+}
@@ -0,0 +1,89 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/eko/monday/pkg/control"
+	"github.com/eko/monday/pkg/proxy"
+	"github.com/eko/monday/pkg/session"
+	"github.com/eko/monday/pkg/status"
+	"github.com/eko/monday/pkg/ui"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestClient(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	path := session.GetSocketPath(t.TempDir(), "my-project")
+
+	view := ui.NewMockView(ctrl)
+	view.EXPECT().Writef("🎛️  Control API listening on '%s'\n", path)
+	view.EXPECT().Writef("🛑  Stopping the session, as requested over the control API\n")
+
+	registry := status.NewRegistry()
+	registry.UpdateApplication("graphql", func(application *status.Application) {
+		application.State = status.StateRunning
+	})
+
+	proxyfier := proxy.NewMockProxy(ctrl)
+	proxyfier.EXPECT().GetProxyForwards().Return(map[string][]proxy.ProxyForward{})
+
+	controller := control.NewMockController(ctrl)
+	controller.EXPECT().Application(ctx, control.ActionStop, "graphql").DoAndReturn(func(_ context.Context, _ control.Action, name string) error {
+		registry.UpdateApplication(name, func(application *status.Application) {
+			application.State = status.StateStopped
+		})
+		return nil
+	})
+
+	shutdown := make(chan struct{})
+
+	server := NewServer(view, path, "my-project", t.TempDir(), registry, controller, proxyfier, ui.NewBroadcast(), func() { close(shutdown) })
+	assert.Nil(t, server.Listen(ctx))
+	defer server.Stop()
+
+	client := NewClient(path)
+
+	// When - Then
+	result, err := client.GetStatus(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "my-project", result.Project)
+	assert.Equal(t, []status.Application{{Name: "graphql", State: status.StateRunning}}, result.Applications)
+
+	application, err := client.Application(ctx, control.ActionStop, "graphql")
+	assert.Nil(t, err)
+	assert.Equal(t, status.StateStopped, application.State)
+
+	_, err = client.Application(ctx, control.ActionStop, "unknown")
+	assert.EqualError(t, err, "unknown local app 'unknown'")
+
+	_, err = client.Forward(ctx, control.ActionReconnect, "unknown")
+	assert.EqualError(t, err, "unknown forward 'unknown'")
+
+	assert.Nil(t, client.Shutdown(ctx))
+
+	select {
+	case <-shutdown:
+	case <-time.After(5 * time.Second):
+		t.Fatal("session has not been shut down")
+	}
+}
+
+func TestClientWhenNoSession(t *testing.T) {
+	// Given
+	path := session.GetSocketPath(t.TempDir(), "my-project")
+
+	client := NewClient(path)
+
+	// When
+	_, err := client.GetStatus(context.Background())
+
+	// Then
+	assert.ErrorContains(t, err, "Unable to reach the Monday session on socket '"+path+"'")
+}
@@ -0,0 +1,365 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/eko/monday/pkg/control"
+	"github.com/eko/monday/pkg/log"
+	"github.com/eko/monday/pkg/status"
+)
+
+const (
+	// defaultLogLines is the number of recent log lines returned when none is requested
+	defaultLogLines = 100
+)
+
+// Status is the status of the running project
+type Status struct {
+	Project      string               `json:"project"`
+	PID          int                  `json:"pid"`
+	Applications []status.Application `json:"applications"`
+	Forwards     []status.Forward     `json:"forwards"`
+	Proxy        []Mapping            `json:"proxy"`
+}
+
+// Mapping is a hostname proxified on a local IP and port
+type Mapping struct {
+	Name        string `json:"name"`
+	Hostname    string `json:"hostname"`
+	LocalIP     string `json:"local_ip"`
+	LocalPort   string `json:"local_port,omitempty"`
+	ProxyPort   string `json:"proxy_port,omitempty"`
+	ForwardPort string `json:"forward_port,omitempty"`
+}
+
+// Error is the body of the responses of the requests that failed
+type Error struct {
+	Error string `json:"error"`
+}
+
+func (s *server) routes() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /status", s.getStatus)
+	mux.HandleFunc("GET /applications", s.getApplications)
+	mux.HandleFunc("GET /applications/{name}", s.getApplication)
+	mux.HandleFunc("POST /applications/{name}/{action}", s.runApplicationAction)
+	mux.HandleFunc("GET /forwards", s.getForwards)
+	mux.HandleFunc("GET /forwards/{name}", s.getForward)
+	mux.HandleFunc("POST /forwards/{name}/{action}", s.runForwardAction)
+	mux.HandleFunc("GET /proxy", s.getProxy)
+	mux.HandleFunc("GET /logs/{name}", s.getLogs)
+	mux.HandleFunc("GET /events", s.getEvents)
+	mux.HandleFunc("GET /output", s.getOutput)
+	mux.HandleFunc("POST /shutdown", s.postShutdown)
+
+	return mux
+}
+
+func (s *server) getStatus(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, Status{
+		Project:      s.project,
+		PID:          os.Getpid(),
+		Applications: s.registry.GetApplications(),
+		Forwards:     s.registry.GetForwards(),
+		Proxy:        s.getMappings(),
+	})
+}
+
+func (s *server) getApplications(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.registry.GetApplications())
+}
+
+func (s *server) getApplication(w http.ResponseWriter, r *http.Request) {
+	application, ok := s.registry.GetApplication(r.PathValue("name"))
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("unknown local app '%s'", r.PathValue("name")))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, application)
+}
+
+// runApplicationAction runs an action (restart, stop, start, rebuild or setup) on a local application, using the
+// context of the session as the application keeps running once the request is done
+func (s *server) runApplicationAction(w http.ResponseWriter, r *http.Request) {
+	name, action := r.PathValue("name"), control.Action(r.PathValue("action"))
+
+	if _, ok := s.registry.GetApplication(name); !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("unknown local app '%s'", name))
+		return
+	}
+
+	if !slices.Contains(control.ApplicationActions, action) {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("unknown action '%s' for local app '%s'", action, name))
+		return
+	}
+
+	if err := s.controller.Application(s.ctx, action, name); err != nil {
+		writeError(w, http.StatusConflict, err)
+		return
+	}
+
+	application, _ := s.registry.GetApplication(name)
+	writeJSON(w, http.StatusOK, application)
+}
+
+func (s *server) getForwards(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.registry.GetForwards())
+}
+
+func (s *server) getForward(w http.ResponseWriter, r *http.Request) {
+	forward, ok := s.registry.GetForward(r.PathValue("name"))
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("unknown forward '%s'", r.PathValue("name")))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, forward)
+}
+
+// runForwardAction runs an action (reconnect, pause or resume) on a forward
+func (s *server) runForwardAction(w http.ResponseWriter, r *http.Request) {
+	name, action := r.PathValue("name"), control.Action(r.PathValue("action"))
+
+	if _, ok := s.registry.GetForward(name); !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("unknown forward '%s'", name))
+		return
+	}
+
+	if !slices.Contains(control.ForwardActions, action) {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("unknown action '%s' for forward '%s'", action, name))
+		return
+	}
+
+	if err := s.controller.Forward(s.ctx, action, name); err != nil {
+		writeError(w, http.StatusConflict, err)
+		return
+	}
+
+	forward, _ := s.registry.GetForward(name)
+	writeJSON(w, http.StatusOK, forward)
+}
+
+func (s *server) getProxy(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.getMappings())
+}
+
+// getLogs writes the recent lines of the log file of an application (the last 100 by default, see the
+// "lines" and "since" parameters, 0 lines meaning all of them) then, when "follow" is set, streams the new ones
+func (s *server) getLogs(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	since, err := log.ParseSince(query.Get("since"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	lines := defaultLogLines
+	if value := query.Get("lines"); value != "" {
+		if lines, err = strconv.Atoi(value); err != nil || lines < 0 {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid 'lines' value '%s'", value))
+			return
+		}
+	}
+
+	follow, _ := strconv.ParseBool(query.Get("follow"))
+
+	path := log.GetFilepath(s.logsDirectory, s.project, r.PathValue("name"))
+
+	// Lines written from now on are only streamed when following the file, so they are not written twice
+	now := time.Now()
+
+	tail := &tailWriter{max: lines, until: now}
+	if err := log.Read(r.Context(), tail, path, since, false); err != nil {
+		writeError(w, http.StatusNotFound, fmt.Errorf("no log file found for '%s'", r.PathValue("name")))
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+
+	for _, line := range tail.lines {
+		w.Write(line)
+	}
+
+	if !follow {
+		return
+	}
+
+	http.NewResponseController(w).Flush()
+
+	log.Read(r.Context(), &flushWriter{w: w}, path, now, true)
+}
+
+// getEvents streams the lifecycle events of the local applications and forwards as JSON objects, one per line
+func (s *server) getEvents(w http.ResponseWriter, r *http.Request) {
+	events, unsubscribe := s.registry.Subscribe()
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+
+	controller := http.NewResponseController(w)
+	controller.Flush()
+
+	encoder := json.NewEncoder(w)
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+
+			if err := encoder.Encode(event); err != nil {
+				return
+			}
+
+			controller.Flush()
+		}
+	}
+}
+
+// getOutput streams what is written to the logs, forwards and proxy views as JSON objects, one per line,
+// starting with the last lines kept so attached terminals display the same views
+func (s *server) getOutput(w http.ResponseWriter, r *http.Request) {
+	history, records, unsubscribe := s.broadcast.Subscribe()
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+
+	controller := http.NewResponseController(w)
+	encoder := json.NewEncoder(w)
+
+	for _, record := range history {
+		if err := encoder.Encode(record); err != nil {
+			return
+		}
+	}
+
+	controller.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case record, ok := <-records:
+			if !ok {
+				return
+			}
+
+			if err := encoder.Encode(record); err != nil {
+				return
+			}
+
+			controller.Flush()
+		}
+	}
+}
+
+// postShutdown stops the session, the response being sent before the applications and forwards are stopped
+func (s *server) postShutdown(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusAccepted)
+
+	s.view.Writef("🛑  Stopping the session, as requested over the control API\n")
+	s.shutdown()
+}
+
+// getMappings returns the proxified hostnames, sorted by name and hostname
+func (s *server) getMappings() []Mapping {
+	result := make([]Mapping, 0)
+
+	for name, proxyForwards := range s.proxy.GetProxyForwards() {
+		for _, proxyForward := range proxyForwards {
+			result = append(result, Mapping{
+				Name:        name,
+				Hostname:    proxyForward.GetHostname(),
+				LocalIP:     proxyForward.LocalIP,
+				LocalPort:   proxyForward.LocalPort,
+				ProxyPort:   proxyForward.ProxyPort,
+				ForwardPort: proxyForward.ForwardPort,
+			})
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Name != result[j].Name {
+			return result[i].Name < result[j].Name
+		}
+
+		return result[i].Hostname < result[j].Hostname
+	})
+
+	return result
+}
+
+func writeJSON(w http.ResponseWriter, code int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+
+	json.NewEncoder(w).Encode(value)
+}
+
+func writeError(w http.ResponseWriter, code int, err error) {
+	writeJSON(w, code, Error{Error: err.Error()})
+}
+
+// tailWriter keeps the last lines written to it (all of them when no maximum is given) until the given time
+type tailWriter struct {
+	max   int
+	until time.Time
+	skip  bool
+	lines [][]byte
+}
+
+func (t *tailWriter) Write(p []byte) (int, error) {
+	for _, line := range bytes.SplitAfter(p, []byte("\n")) {
+		if len(line) == 0 {
+			continue
+		}
+
+		if date, _, ok := bytes.Cut(line, []byte(" ")); ok {
+			if lineTime, err := time.Parse(log.TimeFormat, string(date)); err == nil {
+				t.skip = !lineTime.Before(t.until)
+			}
+		}
+
+		if t.skip {
+			continue
+		}
+
+		t.lines = append(t.lines, bytes.Clone(line))
+		if t.max > 0 && len(t.lines) > t.max {
+			t.lines = t.lines[1:]
+		}
+	}
+
+	return len(p), nil
+}
+
+// flushWriter sends each write to the client right away
+type flushWriter struct {
+	w http.ResponseWriter
+}
+
+func (f *flushWriter) Write(p []byte) (int, error) {
+	n, err := f.w.Write(p)
+	if err == nil {
+		err = http.NewResponseController(f.w).Flush()
+	}
+
+	return n, err
+}
@@ -0,0 +1,130 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/eko/monday/pkg/control"
+	"github.com/eko/monday/pkg/proxy"
+	"github.com/eko/monday/pkg/session"
+	"github.com/eko/monday/pkg/status"
+	"github.com/eko/monday/pkg/ui"
+)
+
+// Server exposes the running project over a HTTP/JSON API listening on a Unix socket, only accessible
+// by the user running Monday
+type Server interface {
+	Listen(ctx context.Context) error
+	Stop() error
+}
+
+type server struct {
+	ctx           context.Context
+	view          ui.View
+	path          string
+	project       string
+	logsDirectory string
+	registry      status.Registry
+	controller    control.Controller
+	proxy         proxy.Proxy
+	broadcast     *ui.Broadcast
+	shutdown      func()
+	httpServer    *http.Server
+}
+
+// NewServer instanciates an API server of the given project listening on the socket at the given path,
+// application logs being read from the given directory and the output of the views sent from the given
+// broadcast. The shutdown function is called to stop the session.
+func NewServer(
+	view ui.View,
+	path string,
+	project string,
+	logsDirectory string,
+	registry status.Registry,
+	controller control.Controller,
+	proxy proxy.Proxy,
+	broadcast *ui.Broadcast,
+	shutdown func(),
+) *server {
+	return &server{
+		view:          view,
+		path:          path,
+		project:       project,
+		logsDirectory: logsDirectory,
+		registry:      registry,
+		controller:    controller,
+		proxy:         proxy,
+		broadcast:     broadcast,
+		shutdown:      shutdown,
+	}
+}
+
+// Listen opens the socket and serves the API until the server is stopped, requests being cancelled once
+// the given context is done. It returns an error when a session of the same project is already listening.
+func (s *server) Listen(ctx context.Context) error {
+	directory := filepath.Dir(s.path)
+
+	if err := session.MkdirAll(directory); err != nil {
+		return err
+	}
+
+	// A socket left by a session that did not exit properly is replaced, unless the session is still running
+	if _, err := os.Stat(s.path); err == nil {
+		if session.IsListening(s.path) {
+			return fmt.Errorf("A Monday session is already running for project '%s' (socket '%s')", s.project, s.path)
+		}
+
+		os.Remove(s.path)
+	}
+
+	listener, err := net.Listen("unix", s.path)
+	if err != nil {
+		return fmt.Errorf("Unable to listen on socket '%s': %v", s.path, err)
+	}
+
+	if err := os.Chmod(s.path, 0600); err != nil {
+		listener.Close()
+		return fmt.Errorf("Unable to restrict the permissions of socket '%s': %v", s.path, err)
+	}
+
+	// When Monday is run using sudo, the socket belongs to the user who ran it
+	session.SetOwner(s.path)
+
+	s.ctx = ctx
+	s.httpServer = &http.Server{
+		Handler: s.routes(),
+		BaseContext: func(net.Listener) context.Context {
+			return ctx
+		},
+	}
+
+	go func() {
+		if err := s.httpServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.view.Writef("❌  Control API has stopped: %v\n", err)
+		}
+	}()
+
+	s.view.Writef("🎛️  Control API listening on '%s'\n", s.path)
+
+	return nil
+}
+
+// Stop closes the socket and all the opened connections (events and logs streams included)
+func (s *server) Stop() error {
+	if s.httpServer == nil {
+		return nil
+	}
+
+	err := s.httpServer.Close()
+
+	if removeErr := os.Remove(s.path); removeErr != nil && !os.IsNotExist(removeErr) {
+		err = errors.Join(err, fmt.Errorf("socket '%s' could not be removed: %v", s.path, removeErr))
+	}
+
+	return err
+}
@@ -0,0 +1,375 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/eko/monday/pkg/control"
+	"github.com/eko/monday/pkg/proxy"
+	"github.com/eko/monday/pkg/session"
+	"github.com/eko/monday/pkg/status"
+	"github.com/eko/monday/pkg/ui"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func newTestClient(path string) *http.Client {
+	return &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return (&net.Dialer{}).DialContext(ctx, "unix", path)
+			},
+		},
+	}
+}
+
+func TestListen(t *testing.T) {
+	// Given
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	path := session.GetSocketPath(filepath.Join(t.TempDir(), "sessions"), "my-project")
+
+	view := ui.NewMockView(ctrl)
+	view.EXPECT().Writef("🎛️  Control API listening on '%s'\n", path)
+
+	registry := status.NewRegistry()
+	registry.UpdateApplication("graphql", func(application *status.Application) {
+		application.State = status.StateRunning
+	})
+	registry.UpdateForward("kubernetes-pod", func(forward *status.Forward) {
+		forward.State = status.StateConnected
+	})
+
+	proxyfier := proxy.NewMockProxy(ctrl)
+	proxyfier.EXPECT().GetProxyForwards().Return(map[string][]proxy.ProxyForward{
+		"kubernetes-pod": {{Name: "kubernetes-pod", Hostname: "my-pod.svc.local", LocalIP: "127.1.2.1", LocalPort: "8080", ProxyPort: "8080"}},
+	})
+
+	controller := control.NewMockController(ctrl)
+	controller.EXPECT().Application(ctx, control.ActionRestart, "graphql").Return(nil)
+	controller.EXPECT().Forward(ctx, control.ActionPause, "kubernetes-pod").Return(errors.New("forward 'kubernetes-pod' is not connected"))
+
+	server := NewServer(view, path, "my-project", t.TempDir(), registry, controller, proxyfier, ui.NewBroadcast(), func() {})
+
+	// When
+	err := server.Listen(ctx)
+	defer server.Stop()
+
+	// Then
+	assert.Nil(t, err)
+
+	info, err := os.Stat(path)
+	assert.Nil(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	client := newTestClient(path)
+
+	response, err := client.Get("http://monday/status")
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
+	var result Status
+	assert.Nil(t, json.NewDecoder(response.Body).Decode(&result))
+	response.Body.Close()
+
+	assert.Equal(t, "my-project", result.Project)
+	assert.Equal(t, os.Getpid(), result.PID)
+	assert.Len(t, result.Applications, 1)
+	assert.Equal(t, status.StateRunning, result.Applications[0].State)
+	assert.Len(t, result.Forwards, 1)
+	assert.Equal(t, []Mapping{{Name: "kubernetes-pod", Hostname: "my-pod.svc.local", LocalIP: "127.1.2.1", LocalPort: "8080", ProxyPort: "8080"}}, result.Proxy)
+
+	response, err = client.Post("http://monday/applications/graphql/restart", "", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	response.Body.Close()
+
+	response, err = client.Post("http://monday/applications/graphql/pause", "", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	response.Body.Close()
+
+	response, err = client.Post("http://monday/forwards/kubernetes-pod/pause", "", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusConflict, response.StatusCode)
+
+	var apiError Error
+	assert.Nil(t, json.NewDecoder(response.Body).Decode(&apiError))
+	response.Body.Close()
+
+	assert.Equal(t, "forward 'kubernetes-pod' is not connected", apiError.Error)
+
+	response, err = client.Get("http://monday/applications/unknown")
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+	response.Body.Close()
+}
+
+func TestListenWhenAlreadyRunning(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	path := session.GetSocketPath(t.TempDir(), "my-project")
+
+	view := ui.NewMockView(ctrl)
+	view.EXPECT().Writef("🎛️  Control API listening on '%s'\n", path)
+
+	registry := status.NewRegistry()
+
+	first := NewServer(view, path, "my-project", t.TempDir(), registry, control.NewMockController(ctrl), proxy.NewMockProxy(ctrl), ui.NewBroadcast(), func() {})
+	assert.Nil(t, first.Listen(ctx))
+	defer first.Stop()
+
+	second := NewServer(view, path, "my-project", t.TempDir(), registry, control.NewMockController(ctrl), proxy.NewMockProxy(ctrl), ui.NewBroadcast(), func() {})
+
+	// When
+	err := second.Listen(ctx)
+
+	// Then
+	assert.EqualError(t, err, "A Monday session is already running for project 'my-project' (socket '"+path+"')")
+
+	// The socket of the running session is kept
+	assert.Nil(t, second.Stop())
+
+	_, err = os.Stat(path)
+	assert.Nil(t, err)
+}
+
+func TestListenWhenStaleSocket(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	path := session.GetSocketPath(t.TempDir(), "my-project")
+	assert.Nil(t, os.WriteFile(path, nil, 0600))
+
+	view := ui.NewMockView(ctrl)
+	view.EXPECT().Writef("🎛️  Control API listening on '%s'\n", path)
+
+	server := NewServer(view, path, "my-project", t.TempDir(), status.NewRegistry(), control.NewMockController(ctrl), proxy.NewMockProxy(ctrl), ui.NewBroadcast(), func() {})
+
+	// When
+	err := server.Listen(ctx)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Nil(t, server.Stop())
+
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestEvents(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	path := session.GetSocketPath(t.TempDir(), "my-project")
+
+	view := ui.NewMockView(ctrl)
+	view.EXPECT().Writef("🎛️  Control API listening on '%s'\n", path)
+
+	registry := status.NewRegistry()
+
+	server := NewServer(view, path, "my-project", t.TempDir(), registry, control.NewMockController(ctrl), proxy.NewMockProxy(ctrl), ui.NewBroadcast(), func() {})
+	assert.Nil(t, server.Listen(ctx))
+	defer server.Stop()
+
+	response, err := newTestClient(path).Get("http://monday/events")
+	assert.Nil(t, err)
+	defer response.Body.Close()
+
+	// When
+	registry.UpdateApplication("graphql", func(application *status.Application) {
+		application.State = status.StateRunning
+	})
+
+	// Then
+	lines := make(chan string)
+	go func() {
+		scanner := bufio.NewScanner(response.Body)
+		if scanner.Scan() {
+			lines <- scanner.Text()
+		}
+	}()
+
+	select {
+	case line := <-lines:
+		var event status.Event
+		assert.Nil(t, json.Unmarshal([]byte(line), &event))
+		assert.Equal(t, status.KindApplication, event.Kind)
+		assert.Equal(t, "graphql", event.Name)
+		assert.Equal(t, status.StateRunning, event.State)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event has been received")
+	}
+}
+
+func TestGetLogs(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	path := session.GetSocketPath(t.TempDir(), "my-project")
+	logsDirectory := t.TempDir()
+
+	logPath := filepath.Join(logsDirectory, "my-project", "graphql.log")
+	assert.Nil(t, os.MkdirAll(filepath.Dir(logPath), 0700))
+	assert.Nil(t, os.WriteFile(logPath, []byte(
+		"2026-01-01T10:00:00.000Z stdout first line\n"+
+			"2026-01-01T10:00:01.000Z stdout second line\n"+
+			"2026-01-01T10:00:02.000Z stdout third line\n",
+	), 0600))
+
+	view := ui.NewMockView(ctrl)
+	view.EXPECT().Writef("🎛️  Control API listening on '%s'\n", path)
+
+	server := NewServer(view, path, "my-project", logsDirectory, status.NewRegistry(), control.NewMockController(ctrl), proxy.NewMockProxy(ctrl), ui.NewBroadcast(), func() {})
+	assert.Nil(t, server.Listen(ctx))
+	defer server.Stop()
+
+	client := newTestClient(path)
+
+	// When
+	response, err := client.Get("http://monday/logs/graphql?lines=2")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
+	scanner := bufio.NewScanner(response.Body)
+	result := make([]string, 0)
+	for scanner.Scan() {
+		result = append(result, scanner.Text())
+	}
+	response.Body.Close()
+
+	assert.Equal(t, []string{
+		"2026-01-01T10:00:01.000Z stdout second line",
+		"2026-01-01T10:00:02.000Z stdout third line",
+	}, result)
+
+	response, err = client.Get("http://monday/logs/unknown")
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+	response.Body.Close()
+}
+
+func TestGetLogsWhenFollow(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	path := session.GetSocketPath(t.TempDir(), "my-project")
+	logsDirectory := t.TempDir()
+
+	logPath := filepath.Join(logsDirectory, "my-project", "graphql.log")
+	assert.Nil(t, os.MkdirAll(filepath.Dir(logPath), 0700))
+	assert.Nil(t, os.WriteFile(logPath, []byte(
+		"2026-01-01T10:00:00.000Z stdout first line\n"+
+			"2026-01-01T10:00:01.000Z stdout second line\n",
+	), 0600))
+
+	view := ui.NewMockView(ctrl)
+	view.EXPECT().Writef("🎛️  Control API listening on '%s'\n", path)
+
+	server := NewServer(view, path, "my-project", logsDirectory, status.NewRegistry(), control.NewMockController(ctrl), proxy.NewMockProxy(ctrl), ui.NewBroadcast(), func() {})
+	assert.Nil(t, server.Listen(ctx))
+	defer server.Stop()
+
+	response, err := newTestClient(path).Get("http://monday/logs/graphql?lines=1&follow=true")
+	assert.Nil(t, err)
+	defer response.Body.Close()
+
+	lines := make(chan string)
+	go func() {
+		scanner := bufio.NewScanner(response.Body)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+	}()
+
+	// When
+	file, err := os.OpenFile(logPath, os.O_WRONLY|os.O_APPEND, 0600)
+	assert.Nil(t, err)
+	defer file.Close()
+
+	_, err = file.WriteString(time.Now().Add(time.Minute).UTC().Format("2006-01-02T15:04:05.000Z07:00") + " stdout new line\n")
+	assert.Nil(t, err)
+
+	// Then
+	for _, expected := range []string{"second line", "new line"} {
+		select {
+		case line := <-lines:
+			assert.Contains(t, line, expected)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("line '%s' has not been received", expected)
+		}
+	}
+}
+
+func TestGetOutput(t *testing.T) {
+	// Given
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	path := session.GetSocketPath(t.TempDir(), "my-project")
+
+	view := ui.NewMockView(ctrl)
+	view.EXPECT().Writef("🎛️  Control API listening on '%s'\n", path)
+
+	layout := ui.NewLayout(false, ui.OutputJSON)
+	layout.Init()
+
+	broadcast := ui.NewBroadcast()
+	layout.SetBroadcast(broadcast)
+
+	layout.GetLogsView().WithComponent("runner").Writef("🏁  Running local app '%s' (%s)...\n", "graphql", "/")
+
+	server := NewServer(view, path, "my-project", t.TempDir(), status.NewRegistry(), control.NewMockController(ctrl), proxy.NewMockProxy(ctrl), broadcast, func() {})
+	assert.Nil(t, server.Listen(ctx))
+	defer server.Stop()
+
+	records := make(chan ui.Record)
+	go NewClient(path).Output(ctx, func(record ui.Record) {
+		records <- record
+	})
+
+	// When
+	received := <-records
+	layout.GetProxyView().WithComponent("proxy").Writef("✅  Successfully mapped hostname '%s' with IP '%s'\n", "graphql.svc.local", "127.1.2.1")
+
+	// Then
+	assert.Equal(t, "logs", received.View)
+	assert.Equal(t, "🏁  Running local app 'graphql' (/)...\n", received.Message)
+
+	select {
+	case received = <-records:
+		assert.Equal(t, "proxy", received.View)
+		assert.Equal(t, "proxy", received.Component)
+		assert.Equal(t, "✅  Successfully mapped hostname 'graphql.svc.local' with IP '127.1.2.1'\n", received.Message)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no record has been received")
+	}
+}
@@ -2,10 +2,13 @@ package build
 
 import (
 	"sync"
+	"time"
 
 	"github.com/eko/monday/pkg/build/command"
 	"github.com/eko/monday/pkg/config"
 	"github.com/eko/monday/pkg/helper"
+	"github.com/eko/monday/pkg/log"
+	"github.com/eko/monday/pkg/status"
 	"github.com/eko/monday/pkg/ui"
 )
 
@@ -16,15 +19,20 @@ type Builder interface {
 }
 
 type builder struct {
+	registry     status.Registry
+	files        log.Files
 	projectName  string
 	applications []*config.Application
 	view         ui.View
 	conf         *config.GlobalBuild
 }
 
-// NewBuilder instanciates a new builder instance
-func NewBuilder(view ui.View, project *config.Project, conf *config.GlobalBuild) *builder {
+// NewBuilder instanciates a new builder instance, keeping the build results in the given status registry
+// and recording the build output in the given log files
+func NewBuilder(view ui.View, registry status.Registry, files log.Files, project *config.Project, conf *config.GlobalBuild) *builder {
 	return &builder{
+		registry:     registry,
+		files:        files,
 		projectName:  project.Name,
 		applications: project.Applications,
 		view:         view,
@@ -63,14 +71,38 @@ func (b *builder) Build(application *config.Application) {
 
 	b.view.Writef("⚙️   Building application '%s' via %s...\n", application.Name, build.Type)
 
+	// Keep the state the application had before the build, to restore it once built
+	var previousState status.State
+
+	b.registry.UpdateApplication(application.Name, func(app *status.Application) {
+		previousState = app.State
+		app.State = status.StateBuilding
+	})
+
+	startedAt := time.Now()
+
 	switch build.Type {
 	case command.BuilderType:
-		err = command.Build(application, b.view, b.conf)
+		err = command.Build(application, b.view, b.files, b.conf)
 
 	default:
-		err = command.Build(application, b.view, b.conf)
+		err = command.Build(application, b.view, b.files, b.conf)
 	}
 
+	b.registry.UpdateApplication(application.Name, func(app *status.Application) {
+		app.Build = &status.Build{
+			Duration:   time.Since(startedAt),
+			FinishedAt: time.Now(),
+		}
+
+		if err != nil {
+			app.Build.Error = err.Error()
+			app.State = status.StateBuildFailed
+		} else if app.State == status.StateBuilding {
+			app.State = previousState
+		}
+	})
+
 	if err != nil {
 		b.view.Writef("❌  Error while building application '%s': %v\n", application.Name, err)
 		return
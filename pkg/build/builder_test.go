@@ -5,6 +5,7 @@ import (
 
 	"github.com/eko/monday/pkg/config"
 	"github.com/eko/monday/pkg/log"
+	"github.com/eko/monday/pkg/status"
 	"github.com/eko/monday/pkg/ui"
 	"go.uber.org/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -16,17 +17,19 @@ func TestNewBuilder(t *testing.T) {
 	defer ctrl.Finish()
 
 	view := ui.NewMockView(ctrl)
+	registry := status.NewRegistry()
 
 	project := getMockedProjectWithApplication()
 
 	// When
-	b := NewBuilder(view, project, &config.GlobalBuild{})
+	b := NewBuilder(view, registry, log.NewMockFiles(ctrl), project, &config.GlobalBuild{})
 
 	// Then
 	assert.IsType(t, new(builder), b)
 	assert.Implements(t, new(Builder), b)
 
 	assert.Equal(t, view, b.view)
+	assert.Equal(t, registry, b.registry)
 	assert.Equal(t, project.Name, b.projectName)
 	assert.Equal(t, project.Applications, b.applications)
 }
@@ -43,12 +46,24 @@ func TestBuildAll(t *testing.T) {
 	view.EXPECT().Write(log.ColorGreen + "test-app" + log.ColorWhite + " yes it's ok\n")
 	view.EXPECT().Writef("\n✅  Build of application '%s' complete!\n\n", "test-app")
 
+	files := log.NewMockFiles(ctrl)
+	files.EXPECT().Write("test-app", log.StdOut, "'ok it works'\n")
+	files.EXPECT().Write("test-app", log.StdOut, "yes it's ok\n")
+
 	project := getMockedProjectWithApplication()
+	registry := status.NewRegistry()
 
-	builder := NewBuilder(view, project, &config.GlobalBuild{})
+	builder := NewBuilder(view, registry, files, project, &config.GlobalBuild{})
 
-	// When - Then
+	// When
 	builder.BuildAll()
+
+	// Then
+	state, ok := registry.GetApplication("test-app")
+	assert.True(t, ok)
+	assert.Equal(t, status.StatePending, state.State)
+	assert.True(t, state.Build.Succeeded())
+	assert.NotZero(t, state.Build.Duration)
 }
 
 func getMockedProjectWithApplication() *config.Project {
@@ -11,10 +11,10 @@ import (
 )
 
 const (
-	BuilderType = "command"
+	BuilderType = config.BuilderCommand
 )
 
-func Build(application *config.Application, view ui.View, conf *config.GlobalBuild) error {
+func Build(application *config.Application, view ui.View, files log.Files, conf *config.GlobalBuild) error {
 	var build = application.Build
 
 	var buildPath = build.GetPath()
@@ -29,6 +29,8 @@ func Build(application *config.Application, view ui.View, conf *config.GlobalBui
 
 	stdoutStream := log.NewStreamer(log.StdOut, application.Name, view)
 	stderrStream := log.NewStreamer(log.StdErr, application.Name, view)
+	stdoutStream.Record(files)
+	stderrStream.Record(files)
 
 	cmd := helper.BuildCmd(build.Commands, buildPath, stdoutStream, stderrStream)
 
@@ -38,7 +40,9 @@ func Build(application *config.Application, view ui.View, conf *config.GlobalBui
 		envs = helper.MergeMapString(build.Env, conf.Env)
 	}
 
-	helper.AddEnvVariables(cmd, envs)
+	if err := helper.AddEnvVariables(cmd, envs); err != nil {
+		return err
+	}
 	if err := helper.AddEnvVariablesFromFile(cmd, build.GetEnvFile()); err != nil {
 		return err
 	}
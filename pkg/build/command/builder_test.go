@@ -20,10 +20,14 @@ func TestBuild(t *testing.T) {
 	view.EXPECT().Write(log.ColorGreen + "test-app" + log.ColorWhite + " 'ok it works'\n")
 	view.EXPECT().Write(log.ColorGreen + "test-app" + log.ColorWhite + " yes it's ok\n")
 
+	files := log.NewMockFiles(ctrl)
+	files.EXPECT().Write("test-app", log.StdOut, "'ok it works'\n")
+	files.EXPECT().Write("test-app", log.StdOut, "yes it's ok\n")
+
 	application := getMockedApplication()
 
 	// When
-	err := Build(application, view, &config.GlobalBuild{})
+	err := Build(application, view, files, &config.GlobalBuild{})
 
 	// Then
 	assert := assert.New(t)
@@ -0,0 +1,172 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// UnmarshalYAML decodes an application, which can also be declared by the name of its definition
+func (a *Application) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		a.reference = node.Value
+		return nil
+	}
+
+	options, node, err := decodeWatchOptions(node)
+	if err != nil {
+		return err
+	}
+
+	a.WatchOptions = options
+
+	type plain Application
+	return node.Decode((*plain)(a))
+}
+
+// UnmarshalYAML decodes the values patched on an application, 'watch' being a boolean or a mapping of options
+func (o *ApplicationOverride) UnmarshalYAML(node *yaml.Node) error {
+	options, node, err := decodeWatchOptions(node)
+	if err != nil {
+		return err
+	}
+
+	o.WatchOptions = options
+
+	type plain ApplicationOverride
+	return node.Decode((*plain)(o))
+}
+
+// decodeWatchOptions decodes the 'watch' section of the given node when declared as a mapping of options. It returns them
+// with a copy of the node in which this mapping is replaced by a boolean, the file watcher being enabled unless
+// disabled explicitly.
+func decodeWatchOptions(node *yaml.Node) (*WatchOptions, *yaml.Node, error) {
+	watch := mappingValue(node, "watch")
+	if watch == nil || watch.Kind != yaml.MappingNode {
+		return nil, node, nil
+	}
+
+	options := &WatchOptions{}
+	if err := watch.Decode(options); err != nil {
+		return nil, nil, err
+	}
+
+	enabled := options.Enabled == nil || *options.Enabled
+
+	copied := *resolveAlias(node)
+	copied.Content = append([]*yaml.Node{}, copied.Content...)
+
+	for i := 0; i+1 < len(copied.Content); i += 2 {
+		if copied.Content[i].Value == "watch" {
+			copied.Content[i+1] = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: strconv.FormatBool(enabled)}
+		}
+	}
+
+	return options, &copied, nil
+}
+
+// UnmarshalYAML decodes a forward, which can also be declared by the name of its definition
+func (f *Forward) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		f.reference = node.Value
+		return nil
+	}
+
+	type plain Forward
+	return node.Decode((*plain)(f))
+}
+
+// resolveGlobals replaces the references of the global applications and forwards by their definition
+func (c *Config) resolveGlobals() error {
+	applications, err := c.resolveApplications("global 'local' list", c.Applications)
+	if err != nil {
+		return err
+	}
+
+	forwards, err := c.resolveForwards("global 'forward' list", c.Forwards)
+	if err != nil {
+		return err
+	}
+
+	c.Applications, c.Forwards = applications, forwards
+
+	return nil
+}
+
+// nameDefinitions gives their key as name to the definitions declared without any name
+func (c *Config) nameDefinitions() {
+	for name, application := range c.ApplicationDefinitions {
+		if application != nil && application.Name == "" {
+			application.Name = name
+		}
+	}
+
+	for name, forward := range c.ForwardDefinitions {
+		if forward != nil && forward.Name == "" {
+			forward.Name = name
+		}
+	}
+}
+
+// dereferenceApplication returns the definition referenced by an application (nil if unknown)
+// or the application itself when it is not a reference
+func (c *Config) dereferenceApplication(application *Application) *Application {
+	if application == nil || application.reference == "" {
+		return application
+	}
+
+	return c.ApplicationDefinitions[application.reference]
+}
+
+// dereferenceForward returns the definition referenced by a forward (nil if unknown)
+// or the forward itself when it is not a reference
+func (c *Config) dereferenceForward(forward *Forward) *Forward {
+	if forward == nil || forward.reference == "" {
+		return forward
+	}
+
+	return c.ForwardDefinitions[forward.reference]
+}
+
+// resolveApplications returns a copy of the given applications, references being replaced
+// by their definition. The owner of the applications is used in the returned error.
+func (c *Config) resolveApplications(owner string, applications []*Application) ([]*Application, error) {
+	resolved := make([]*Application, 0, len(applications))
+
+	for _, application := range applications {
+		if application == nil {
+			continue
+		}
+
+		definition := c.dereferenceApplication(application)
+		if definition == nil {
+			return nil, fmt.Errorf("%s references an unknown application '%s'", owner, application.reference)
+		}
+
+		resolved = append(resolved, definition.copy())
+	}
+
+	return resolved, nil
+}
+
+// resolveForwards returns a copy of the given forwards, references being replaced
+// by their definition. The owner of the forwards is used in the returned error.
+func (c *Config) resolveForwards(owner string, forwards []*Forward) ([]*Forward, error) {
+	resolved := make([]*Forward, 0, len(forwards))
+
+	for _, forward := range forwards {
+		if forward == nil {
+			continue
+		}
+
+		definition := c.dereferenceForward(forward)
+		if definition == nil {
+			return nil, fmt.Errorf("%s references an unknown forward '%s'", owner, forward.reference)
+		}
+
+		resolved = append(resolved, definition.copy())
+	}
+
+	return resolved, nil
+}
@@ -0,0 +1,188 @@
+package config
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestLoadWhenDefinitions(t *testing.T) {
+	// Given
+	dir, _ := os.Getwd()
+	Filepath = dir + "/../../internal/test/config/definitions/monday.yaml"
+	MultipleFilepath = dir + "/../../internal/test/config/definitions/monday.*.yaml"
+
+	// When
+	conf, err := Load()
+
+	// Then
+	assert.Nil(t, err)
+
+	// Global applications references are resolved
+	assert.Len(t, conf.Applications, 1)
+	assert.Equal(t, "mailer-app", conf.Applications[0].Name)
+	assert.Equal(t, "github.com/eko/mailer", conf.Applications[0].Path)
+
+	project, err := conf.GetProjectByName("full")
+	assert.Nil(t, err)
+
+	assert.Equal(t, []*Application{
+		{
+			Name:     "graphql",
+			Path:     "github.com/eko/graphql",
+			Hostname: "graphql.svc.local",
+			Run:      &Run{Command: "go run main.go"},
+		},
+		{
+			Name: "inline",
+			Path: "github.com/eko/inline",
+			Run:  &Run{Command: "go run main.go"},
+		},
+	}, project.Applications)
+
+	assert.Len(t, project.Forwards, 1)
+	assert.Equal(t, "user-api", project.Forwards[0].Name)
+	assert.Equal(t, "preprod", project.Forwards[0].Values.Context)
+
+	// Definitions are not altered by resolved projects
+	project.Applications[0].Path = "altered"
+	assert.Equal(t, "github.com/eko/graphql", conf.ApplicationDefinitions["graphql"].Path)
+}
+
+func TestGetProjectByNameWhenUnknownReference(t *testing.T) {
+	// Given
+	conf := &Config{
+		ApplicationDefinitions: map[string]*Application{
+			"graphql": {Name: "graphql"},
+		},
+		Projects: []*Project{
+			{
+				Name:         "graphql",
+				Applications: []*Application{{reference: "graphql"}},
+				Forwards:     []*Forward{{reference: "user-api"}},
+			},
+		},
+	}
+
+	// When
+	project, err := conf.GetProjectByName("graphql")
+
+	// Then
+	assert.Nil(t, project)
+	assert.EqualError(t, err, "project 'graphql' references an unknown forward 'user-api'")
+}
+
+func TestValidateWhenUnknownReferences(t *testing.T) {
+	// Given
+	conf := &Config{
+		Applications: []*Application{{reference: "mailer"}},
+		Projects: []*Project{
+			{
+				Name:         "graphql",
+				Applications: []*Application{{reference: "graphql"}},
+			},
+		},
+	}
+
+	// When
+	err := conf.Validate()
+
+	// Then
+	assert.EqualError(t, err, "global 'local' list references an unknown application 'mailer'\n"+
+		"project 'graphql' references an unknown application 'graphql'")
+}
+
+func TestLoadWhenDefinitionDeclaredInMultipleFiles(t *testing.T) {
+	// Given
+	dir := t.TempDir()
+
+	os.WriteFile(dir+"/monday.yaml", []byte("applications:\n  graphql:\n    path: github.com/eko/graphql\n"), 0644)
+	os.WriteFile(dir+"/monday.apps.yaml", []byte("\napplications:\n  graphql:\n    path: github.com/eko/other\n"), 0644)
+
+	Filepath = dir + "/monday.yaml"
+	MultipleFilepath = dir + "/monday.*.yaml"
+
+	// When
+	conf, err := Load()
+
+	// Then
+	assert.Nil(t, conf)
+	assert.Equal(t, &MergeConflictError{
+		Kind:     "application",
+		Name:     "graphql",
+		Source:   Source{File: dir + "/monday.apps.yaml", Line: 3},
+		Previous: Source{File: dir + "/monday.yaml", Line: 2},
+	}, err)
+}
+
+func TestApplicationUnmarshalYAMLWhenWatchOptions(t *testing.T) {
+	// Given
+	content := `
+- name: graphql
+  watch: true
+- name: user-api
+  watch:
+    include: ["**/*.go"]
+    exclude: [tmp/]
+    paths: [../library]
+    gitignore: false
+- name: order-api
+  watch:
+    enabled: false
+    paths: [../library]
+`
+
+	// When
+	var applications []*Application
+	err := yaml.Unmarshal([]byte(content), &applications)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Len(t, applications, 3)
+
+	assert.True(t, applications[0].Watch)
+	assert.Nil(t, applications[0].WatchOptions)
+	assert.True(t, applications[0].WatchOptions.IsGitignoreEnabled())
+
+	gitignore := false
+	assert.True(t, applications[1].Watch)
+	assert.Equal(t, &WatchOptions{
+		Include:   []string{"**/*.go"},
+		Exclude:   []string{"tmp/"},
+		Paths:     []string{"../library"},
+		Gitignore: &gitignore,
+	}, applications[1].WatchOptions)
+	assert.False(t, applications[1].WatchOptions.IsGitignoreEnabled())
+
+	assert.False(t, applications[2].Watch)
+	assert.Equal(t, []string{"../library"}, applications[2].WatchOptions.Paths)
+}
+
+func TestApplicationOverrideUnmarshalYAMLWhenWatchOptions(t *testing.T) {
+	// Given
+	content := `
+graphql:
+  watch: false
+user-api:
+  watch:
+    include: ["**/*.go"]
+  env:
+    DEBUG: "true"
+`
+
+	// When
+	var overrides map[string]*ApplicationOverride
+	err := yaml.Unmarshal([]byte(content), &overrides)
+
+	// Then
+	assert.Nil(t, err)
+
+	assert.False(t, *overrides["graphql"].Watch)
+	assert.Nil(t, overrides["graphql"].WatchOptions)
+
+	assert.True(t, *overrides["user-api"].Watch)
+	assert.Equal(t, &WatchOptions{Include: []string{"**/*.go"}}, overrides["user-api"].WatchOptions)
+	assert.Equal(t, map[string]string{"DEBUG": "true"}, overrides["user-api"].Env)
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SortByDependencies returns the given applications ordered so that each application comes after
+// the applications it depends on. Dependencies on forwards (or unknown names) are ignored here.
+// An error is returned when applications depend on each other in a cycle.
+func SortByDependencies(applications []*Application) ([]*Application, error) {
+	byName := make(map[string]*Application, len(applications))
+	for _, application := range applications {
+		byName[application.Name] = application
+	}
+
+	sorted := make([]*Application, 0, len(applications))
+	visited := make(map[string]bool, len(applications))
+
+	var visit func(application *Application, path []string) error
+	visit = func(application *Application, path []string) error {
+		for i, name := range path {
+			if name == application.Name {
+				return fmt.Errorf("dependency cycle: %s -> %s", strings.Join(path[i:], " -> "), application.Name)
+			}
+		}
+
+		if visited[application.Name] {
+			return nil
+		}
+
+		path = append(path, application.Name)
+
+		for _, name := range application.DependsOn {
+			if dependency, ok := byName[name]; ok {
+				if err := visit(dependency, path); err != nil {
+					return err
+				}
+			}
+		}
+
+		visited[application.Name] = true
+		sorted = append(sorted, application)
+
+		return nil
+	}
+
+	for _, application := range applications {
+		if err := visit(application, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return sorted, nil
+}
@@ -0,0 +1,38 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortByDependencies(t *testing.T) {
+	// Given
+	graphql := &Application{Name: "graphql", DependsOn: []string{"user-api", "postgres"}}
+	userAPI := &Application{Name: "user-api", DependsOn: []string{"auth-api"}}
+	authAPI := &Application{Name: "auth-api"}
+	front := &Application{Name: "front", DependsOn: []string{"graphql"}}
+
+	// When
+	sorted, err := SortByDependencies([]*Application{front, graphql, userAPI, authAPI})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, []*Application{authAPI, userAPI, graphql, front}, sorted)
+}
+
+func TestSortByDependenciesWhenCycle(t *testing.T) {
+	// Given
+	applications := []*Application{
+		{Name: "graphql", DependsOn: []string{"user-api"}},
+		{Name: "user-api", DependsOn: []string{"auth-api"}},
+		{Name: "auth-api", DependsOn: []string{"graphql"}},
+	}
+
+	// When
+	sorted, err := SortByDependencies(applications)
+
+	// Then
+	assert.Nil(t, sorted)
+	assert.EqualError(t, err, "dependency cycle: graphql -> user-api -> auth-api -> graphql")
+}
@@ -0,0 +1,150 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+const (
+	// RepositoryFilename is the name of the configuration file that can be committed in a repository
+	RepositoryFilename = ".monday.yaml"
+	// OverrideFilename is the name of the personal (and ignored) configuration file that
+	// overrides the repository one
+	OverrideFilename = "monday.override.yaml"
+
+	LayerTeam       = "team"
+	LayerUser       = "user"
+	LayerRepository = "repository"
+	LayerOverride   = "override"
+)
+
+var (
+	// TeamConfigPath is the path of the local clone of the team configuration repository
+	TeamConfigPath = getTeamConfigPath()
+
+	gitCommand = func(dir string, args ...string) *exec.Cmd {
+		return exec.Command("git", append([]string{"-C", dir}, args...)...)
+	}
+)
+
+// Layer represents a set of configuration files. A project (or a definition) declared in a layer
+// is merged over the one declared in a previous layer, while it is a conflict in the same layer.
+type Layer struct {
+	Name  string
+	Files []string
+}
+
+// FindConfigLayers returns the configuration layers, in the order they are merged:
+// the team configuration, the user one (single and multiple files), the nearest repository
+// configuration found from the current directory and its personal override
+func FindConfigLayers() []*Layer {
+	layers := make([]*Layer, 0)
+	seen := make(map[string]bool)
+
+	add := func(name string, files ...string) {
+		layer := &Layer{Name: name, Files: make([]string, 0, len(files))}
+
+		for _, file := range files {
+			if seen[filepath.Clean(file)] {
+				continue
+			}
+			seen[filepath.Clean(file)] = true
+
+			layer.Files = append(layer.Files, file)
+		}
+
+		if len(layer.Files) > 0 {
+			layers = append(layers, layer)
+		}
+	}
+
+	if TeamConfigPath != "" {
+		files, _ := filepath.Glob(filepath.Join(TeamConfigPath, MultipleFilenamePattern))
+		add(LayerTeam, files...)
+	}
+
+	userFiles := make([]string, 0)
+	if _, err := os.Stat(Filepath); err == nil {
+		userFiles = append(userFiles, Filepath)
+	}
+	add(LayerUser, append(userFiles, FindMultipleConfigFiles()...)...)
+
+	if dir := findRepositoryDirectory(); dir != "" {
+		add(LayerRepository, filepath.Join(dir, RepositoryFilename))
+
+		if override := filepath.Join(dir, OverrideFilename); fileExists(override) {
+			add(LayerOverride, override)
+		}
+	}
+
+	return layers
+}
+
+// FindConfigPatterns returns the paths (or glob patterns) of all the configuration files that can be loaded,
+// including the ones that do not exist yet
+func FindConfigPatterns() []string {
+	patterns := make([]string, 0)
+
+	if TeamConfigPath != "" {
+		patterns = append(patterns, filepath.Join(TeamConfigPath, MultipleFilenamePattern))
+	}
+
+	patterns = append(patterns, Filepath, MultipleFilepath)
+
+	if dir := findRepositoryDirectory(); dir != "" {
+		patterns = append(patterns, filepath.Join(dir, RepositoryFilename), filepath.Join(dir, OverrideFilename))
+	}
+
+	return patterns
+}
+
+// findRepositoryDirectory walks up from the current directory and returns the first directory
+// containing a repository configuration file
+func findRepositoryDirectory() string {
+	dir, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+
+	for {
+		if fileExists(filepath.Join(dir, RepositoryFilename)) {
+			return dir
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+
+		dir = parent
+	}
+}
+
+// SyncTeamConfig pulls the latest changes of the team configuration repository
+func SyncTeamConfig() (string, error) {
+	if !fileExists(filepath.Join(TeamConfigPath, ".git")) {
+		return "", fmt.Errorf("No team configuration repository found in '%s', please clone it there first (or set MONDAY_TEAM_CONFIG_PATH)", TeamConfigPath)
+	}
+
+	output, err := gitCommand(TeamConfigPath, "pull", "--ff-only").CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("Unable to pull the team configuration in '%s': %v\n%s", TeamConfigPath, err, output)
+	}
+
+	return string(output), nil
+}
+
+func getTeamConfigPath() string {
+	if value := os.Getenv("MONDAY_TEAM_CONFIG_PATH"); value != "" {
+		return value
+	}
+
+	return filepath.Join(defaultConfigPath, ".monday", "team")
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,137 @@
+package config
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func setLayersPaths(t *testing.T) string {
+	cwd, _ := os.Getwd()
+	dir := filepath.Clean(cwd + "/../../internal/test/config/layers")
+
+	TeamConfigPath = dir + "/team"
+	Filepath = dir + "/user/monday.yaml"
+	MultipleFilepath = dir + "/user/monday.*.yaml"
+
+	os.Chdir(dir + "/repository/service")
+
+	t.Cleanup(func() {
+		os.Chdir(cwd)
+		TeamConfigPath = getTeamConfigPath()
+	})
+
+	return dir
+}
+
+func TestFindConfigLayers(t *testing.T) {
+	// Given
+	dir := setLayersPaths(t)
+
+	// When
+	layers := FindConfigLayers()
+
+	// Then
+	assert.Equal(t, []*Layer{
+		{Name: LayerTeam, Files: []string{dir + "/team/monday.yaml"}},
+		{Name: LayerUser, Files: []string{dir + "/user/monday.yaml"}},
+		{Name: LayerRepository, Files: []string{dir + "/repository/.monday.yaml"}},
+		{Name: LayerOverride, Files: []string{dir + "/repository/monday.override.yaml"}},
+	}, layers)
+}
+
+func TestFindConfigPatterns(t *testing.T) {
+	// Given
+	dir := setLayersPaths(t)
+
+	// When
+	patterns := FindConfigPatterns()
+
+	// Then
+	assert.Equal(t, []string{
+		filepath.Join(dir, "team", MultipleFilenamePattern),
+		dir + "/user/monday.yaml",
+		dir + "/user/monday.*.yaml",
+		dir + "/repository/.monday.yaml",
+		dir + "/repository/monday.override.yaml",
+	}, patterns)
+}
+
+func TestLoadWhenLayers(t *testing.T) {
+	// Given
+	setLayersPaths(t)
+
+	// When
+	conf, err := Load()
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, []string{".git"}, conf.Watch.Exclude)
+
+	// Projects and definitions are merged over the previous layers ones
+	project, err := conf.GetProjectByName("graphql")
+	assert.Nil(t, err)
+
+	assert.Len(t, project.Applications, 1)
+	assert.Equal(t, "github.com/eko/graphql", project.Applications[0].Path)
+	assert.Equal(t, map[string]string{"HTTP_PORT": "8005", "DEBUG": "false"}, project.Applications[0].Run.Env)
+
+	assert.Len(t, project.Forwards, 1)
+	assert.Equal(t, "staging", project.Forwards[0].Values.Context)
+	assert.Equal(t, "backend", project.Forwards[0].Values.Namespace)
+}
+
+func TestResolved(t *testing.T) {
+	// Given
+	dir := setLayersPaths(t)
+
+	// When
+	content, err := Resolved()
+
+	// Then
+	assert.Nil(t, err)
+	assert.Contains(t, string(content), "path: github.com/eko/graphql # "+dir+"/team/monday.yaml:3\n")
+	assert.Contains(t, string(content), "DEBUG: \"false\" # "+dir+"/repository/.monday.yaml:5\n")
+	assert.Contains(t, string(content), "context: staging # "+dir+"/repository/monday.override.yaml:6\n")
+}
+
+func TestSyncTeamConfig(t *testing.T) {
+	// Given
+	TeamConfigPath = t.TempDir()
+	defer func() { TeamConfigPath = getTeamConfigPath() }()
+
+	os.Mkdir(TeamConfigPath+"/.git", 0755)
+
+	defer func(original func(string, ...string) *exec.Cmd) { gitCommand = original }(gitCommand)
+
+	var gitArgs []string
+	gitCommand = func(dir string, args ...string) *exec.Cmd {
+		gitArgs = append([]string{dir}, args...)
+		return exec.Command("echo", "Already up to date.")
+	}
+
+	// When
+	output, err := SyncTeamConfig()
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "Already up to date.\n", output)
+	assert.Equal(t, []string{TeamConfigPath, "pull", "--ff-only"}, gitArgs)
+}
+
+func TestSyncTeamConfigWhenNotCloned(t *testing.T) {
+	// Given
+	TeamConfigPath = t.TempDir()
+	defer func() { TeamConfigPath = getTeamConfigPath() }()
+
+	// When
+	output, err := SyncTeamConfig()
+
+	// Then
+	assert.Equal(t, "", output)
+	assert.True(t, strings.HasPrefix(err.Error(), "No team configuration repository found in"))
+}
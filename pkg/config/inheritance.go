@@ -0,0 +1,219 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// resolveProject returns the final project: applications and forwards inherited from the
+// extended projects (in order) are followed by the project ones, an application or forward
+// of the project replacing an inherited one with the same name. Overrides are applied at last.
+func (c *Config) resolveProject(project *Project, path []string) (*Project, error) {
+	for _, name := range path {
+		if name == project.Name {
+			return nil, fmt.Errorf("project '%s' has an inheritance cycle: %s -> %s", path[0], strings.Join(path, " -> "), project.Name)
+		}
+	}
+	path = append(path, project.Name)
+
+	resolved := &Project{Name: project.Name}
+
+	for _, name := range project.Extends {
+		parent := c.findProject(name)
+		if parent == nil {
+			return nil, fmt.Errorf("project '%s' extends an unknown project '%s'", project.Name, name)
+		}
+
+		resolvedParent, err := c.resolveProject(parent, path)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, application := range resolvedParent.Applications {
+			resolved.Applications = appendApplication(resolved.Applications, application)
+		}
+
+		for _, forward := range resolvedParent.Forwards {
+			resolved.Forwards = appendForward(resolved.Forwards, forward)
+		}
+	}
+
+	owner := fmt.Sprintf("project '%s'", project.Name)
+
+	applications, err := c.resolveApplications(owner, project.Applications)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, application := range applications {
+		resolved.Applications = appendApplication(resolved.Applications, application)
+	}
+
+	forwards, err := c.resolveForwards(owner, project.Forwards)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, forward := range forwards {
+		resolved.Forwards = appendForward(resolved.Forwards, forward)
+	}
+
+	if err := resolved.applyOverrides(project.Overrides); err != nil {
+		return nil, err
+	}
+
+	return resolved, nil
+}
+
+// applyOverrides patches the project applications and forwards with the given overrides
+func (p *Project) applyOverrides(overrides *Overrides) error {
+	if overrides == nil {
+		return nil
+	}
+
+	for _, name := range sortedKeys(overrides.Applications) {
+		override := overrides.Applications[name]
+		application := p.findApplication(name)
+		if application == nil {
+			return fmt.Errorf("project '%s' overrides an unknown application '%s'", p.Name, name)
+		}
+
+		if override == nil {
+			continue
+		}
+
+		if override.Watch != nil {
+			application.Watch = *override.Watch
+		}
+
+		if override.WatchOptions != nil {
+			application.WatchOptions = override.WatchOptions
+		}
+
+		if len(override.Env) > 0 {
+			if application.Run == nil {
+				application.Run = &Run{}
+			}
+
+			env := make(map[string]string, len(application.Run.Env)+len(override.Env))
+			for key, value := range application.Run.Env {
+				env[key] = value
+			}
+			for key, value := range override.Env {
+				env[key] = value
+			}
+
+			application.Run.Env = env
+		}
+	}
+
+	for _, name := range sortedKeys(overrides.Forwards) {
+		override := overrides.Forwards[name]
+		forward := p.findForward(name)
+		if forward == nil {
+			return fmt.Errorf("project '%s' overrides an unknown forward '%s'", p.Name, name)
+		}
+
+		if override == nil {
+			continue
+		}
+
+		if override.Context != "" {
+			forward.Values.Context = override.Context
+		}
+
+		if override.Namespace != "" {
+			forward.Values.Namespace = override.Namespace
+		}
+
+		if len(override.Ports) > 0 {
+			forward.Values.Ports = override.Ports
+		}
+	}
+
+	return nil
+}
+
+// sortedKeys returns the names of the overridden items in a stable order
+func sortedKeys[T any](items map[string]T) []string {
+	keys := make([]string, 0, len(items))
+	for key := range items {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func (c *Config) findProject(name string) *Project {
+	for _, project := range c.Projects {
+		if project != nil && project.Name == name {
+			return project
+		}
+	}
+
+	return nil
+}
+
+func (p *Project) findApplication(name string) *Application {
+	for _, application := range p.Applications {
+		if application.Name == name {
+			return application
+		}
+	}
+
+	return nil
+}
+
+func (p *Project) findForward(name string) *Forward {
+	for _, forward := range p.Forwards {
+		if forward.Name == name {
+			return forward
+		}
+	}
+
+	return nil
+}
+
+// appendApplication appends an application or replaces the one having the same name
+func appendApplication(applications []*Application, application *Application) []*Application {
+	for i, existing := range applications {
+		if existing.Name == application.Name {
+			applications[i] = application
+			return applications
+		}
+	}
+
+	return append(applications, application)
+}
+
+// appendForward appends a forward or replaces the one having the same name
+func appendForward(forwards []*Forward, forward *Forward) []*Forward {
+	for i, existing := range forwards {
+		if existing.Name == forward.Name {
+			forwards[i] = forward
+			return forwards
+		}
+	}
+
+	return append(forwards, forward)
+}
+
+// copy returns a copy of the application that can be patched without altering the original one
+func (a *Application) copy() *Application {
+	copied := *a
+
+	if a.Run != nil {
+		run := *a.Run
+		copied.Run = &run
+	}
+
+	return &copied
+}
+
+// copy returns a copy of the forward that can be patched without altering the original one
+func (f *Forward) copy() *Forward {
+	copied := *f
+	return &copied
+}
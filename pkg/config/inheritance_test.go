@@ -0,0 +1,182 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetProjectByNameWhenExtendsAndOverrides(t *testing.T) {
+	// Given
+	watch := false
+
+	graphql := &Application{
+		Name:  "graphql",
+		Path:  "/",
+		Watch: true,
+		Run:   &Run{Command: "./graphql", Env: map[string]string{"HTTP_PORT": "8005", "DEBUG": "false"}},
+	}
+	userAPI := &Forward{
+		Name:   "user-api",
+		Type:   ForwarderKubernetes,
+		Values: ForwardValues{Context: "preprod", Namespace: "backend", Ports: []string{"8080:8080"}},
+	}
+
+	conf := &Config{
+		Projects: []*Project{
+			{Name: "base", Applications: []*Application{graphql}, Forwards: []*Forward{userAPI}},
+			{
+				Name:    "graphql-debug",
+				Extends: []string{"base"},
+				Overrides: &Overrides{
+					Applications: map[string]*ApplicationOverride{
+						"graphql": {Watch: &watch, Env: map[string]string{"DEBUG": "true"}},
+					},
+					Forwards: map[string]*ForwardOverride{
+						"user-api": {Context: "staging"},
+					},
+				},
+			},
+		},
+	}
+
+	// When
+	project, err := conf.GetProjectByName("graphql-debug")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, &Project{
+		Name: "graphql-debug",
+		Applications: []*Application{
+			{
+				Name: "graphql",
+				Path: "/",
+				Run:  &Run{Command: "./graphql", Env: map[string]string{"HTTP_PORT": "8005", "DEBUG": "true"}},
+			},
+		},
+		Forwards: []*Forward{
+			{
+				Name:   "user-api",
+				Type:   ForwarderKubernetes,
+				Values: ForwardValues{Context: "staging", Namespace: "backend", Ports: []string{"8080:8080"}},
+			},
+		},
+	}, project)
+
+	// Inherited applications and forwards are not altered
+	assert.True(t, graphql.Watch)
+	assert.Equal(t, "false", graphql.Run.Env["DEBUG"])
+	assert.Equal(t, "preprod", userAPI.Values.Context)
+}
+
+func TestGetProjectByNameWhenWatchOptionsOverride(t *testing.T) {
+	// Given
+	graphql := &Application{
+		Name:         "graphql",
+		Path:         "/",
+		Watch:        true,
+		WatchOptions: &WatchOptions{Include: []string{"*.go"}},
+		Run:          &Run{Command: "./graphql"},
+	}
+
+	conf := &Config{
+		Projects: []*Project{
+			{Name: "base", Applications: []*Application{graphql}},
+			{
+				Name:    "graphql-debug",
+				Extends: []string{"base"},
+				Overrides: &Overrides{
+					Applications: map[string]*ApplicationOverride{
+						"graphql": {Watch: &graphql.Watch, WatchOptions: &WatchOptions{Paths: []string{"../library"}}},
+					},
+				},
+			},
+		},
+	}
+
+	// When
+	project, err := conf.GetProjectByName("graphql-debug")
+
+	// Then
+	assert.Nil(t, err)
+	assert.True(t, project.Applications[0].Watch)
+	assert.Equal(t, &WatchOptions{Paths: []string{"../library"}}, project.Applications[0].WatchOptions)
+
+	// Inherited application is not altered
+	assert.Equal(t, &WatchOptions{Include: []string{"*.go"}}, graphql.WatchOptions)
+}
+
+func TestGetProjectByNameWhenProjectReplacesInheritedApplication(t *testing.T) {
+	// Given
+	conf := &Config{
+		Projects: []*Project{
+			{
+				Name: "base",
+				Applications: []*Application{
+					{Name: "graphql", Run: &Run{Command: "./graphql"}},
+					{Name: "user-api", Run: &Run{Command: "./user-api"}},
+				},
+			},
+			{
+				Name:         "custom",
+				Extends:      []string{"base"},
+				Applications: []*Application{{Name: "graphql", Run: &Run{Command: "go run main.go"}}},
+			},
+		},
+	}
+
+	// When
+	project, err := conf.GetProjectByName("custom")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, []*Application{
+		{Name: "graphql", Run: &Run{Command: "go run main.go"}},
+		{Name: "user-api", Run: &Run{Command: "./user-api"}},
+	}, project.Applications)
+}
+
+func TestGetProjectByNameWhenInvalidInheritance(t *testing.T) {
+	// Given
+	testCases := []struct {
+		name     string
+		projects []*Project
+		expected string
+	}{
+		{
+			name: "unknown extended project",
+			projects: []*Project{
+				{Name: "a", Extends: []string{"unknown"}},
+			},
+			expected: "project 'a' extends an unknown project 'unknown'",
+		},
+		{
+			name: "inheritance cycle",
+			projects: []*Project{
+				{Name: "a", Extends: []string{"b"}},
+				{Name: "b", Extends: []string{"a"}},
+			},
+			expected: "project 'a' has an inheritance cycle: a -> b -> a",
+		},
+		{
+			name: "unknown overridden application",
+			projects: []*Project{
+				{Name: "a", Overrides: &Overrides{Applications: map[string]*ApplicationOverride{"unknown": {}}}},
+			},
+			expected: "project 'a' overrides an unknown application 'unknown'",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			conf := &Config{Projects: testCase.projects}
+
+			// When
+			project, err := conf.GetProjectByName("a")
+
+			// Then
+			assert.Nil(t, project)
+			assert.EqualError(t, err, testCase.expected)
+		})
+	}
+}
@@ -0,0 +1,303 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// anchorKey is the root key used in configuration files to declare YAML anchors
+	anchorKey = "<"
+)
+
+var (
+	lineErrorRegexp    = regexp.MustCompile(`line (\d+)`)
+	unknownAnchorError = regexp.MustCompile(`unknown anchor '.*' referenced`)
+)
+
+// document represents a single parsed configuration file
+type document struct {
+	filename string
+	root     *yaml.Node
+}
+
+// loader parses configuration files separately and merges them into a single configuration.
+// Anchors declared in a file can be referenced from any other file.
+type loader struct {
+	anchors     map[string]*yaml.Node
+	anchorNames []string
+	origins     map[*yaml.Node]string
+	layers      map[string]int
+	documents   []*document
+}
+
+func newLoader() *loader {
+	return &loader{
+		anchors:     make(map[string]*yaml.Node),
+		anchorNames: make([]string, 0),
+		origins:     make(map[*yaml.Node]string),
+		layers:      make(map[string]int),
+	}
+}
+
+// load parses the files of the given layers and returns the configuration resulting of their merge,
+// with the given profile applied (if any)
+func (l *loader) load(layers []*Layer, profileName string) (*Config, error) {
+	root, err := l.resolve(layers, profileName)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, document := range l.documents {
+		// Decode each file on its own so type errors are reported with the right file. Lines are
+		// numbered again so errors in anchors declared by another file point to that file.
+		single, err := l.merge("", newMappingNode(), document.root)
+		if err != nil {
+			return nil, err
+		}
+
+		numbered, sources := l.numberLines(single, make([]Source, 0))
+		if err := numbered.Decode(&Config{}); err != nil {
+			return nil, decodeError(err, document.filename, sources)
+		}
+	}
+
+	var conf Config
+	if err := root.Decode(&conf); err != nil {
+		return nil, fmt.Errorf("An error has occured while reading configuration files:\n%v", err)
+	}
+
+	conf.nameDefinitions()
+	conf.locate(root, l.origins)
+
+	return &conf, nil
+}
+
+// resolve parses the files of the given layers and returns the root node resulting of their merge,
+// variables being expanded and the given profile applied (if any)
+func (l *loader) resolve(layers []*Layer, profileName string) (*yaml.Node, error) {
+	files := make([]string, 0)
+	for i, layer := range layers {
+		for _, file := range layer.Files {
+			l.layers[file] = i
+			files = append(files, file)
+		}
+	}
+
+	documents, err := l.parseAll(files)
+	if err != nil {
+		return nil, err
+	}
+	l.documents = documents
+
+	root := newMappingNode()
+
+	for _, document := range documents {
+		root, err = l.merge("", root, document.root)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	profile, err := selectProfile(root, profileName)
+	if err != nil {
+		return nil, err
+	}
+
+	visited := make(map[*yaml.Node]bool)
+	for _, document := range documents {
+		expandVariables(document.root, profile, visited)
+	}
+
+	l.applyProfile(root, profileName)
+
+	return root, nil
+}
+
+// parseAll parses all the given files. As a file can reference an anchor declared in another
+// one, files referencing unknown anchors are parsed again once the other files are parsed.
+func (l *loader) parseAll(files []string) ([]*document, error) {
+	documents := make([]*document, len(files))
+	errs := make([]error, len(files))
+
+	for parsed, progress := 0, true; parsed < len(files) && progress; {
+		progress = false
+
+		for i, filename := range files {
+			if documents[i] != nil {
+				continue
+			}
+
+			document, err := l.parse(filename)
+			if err != nil && !unknownAnchorError.MatchString(err.Error()) {
+				return nil, err
+			}
+
+			errs[i] = err
+			if err != nil {
+				continue
+			}
+
+			documents[i] = document
+			parsed++
+			progress = true
+		}
+	}
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return documents, nil
+}
+
+// parse parses a single configuration file. Anchors already known are declared in a prefix
+// of the file content so they can be referenced, then removed from the parsed document.
+func (l *loader) parse(filename string) (*document, error) {
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to read configuration file '%s': %v", filename, err)
+	}
+
+	prefix, err := l.anchorsPrefix()
+	if err != nil {
+		return nil, err
+	}
+
+	offset := bytes.Count(prefix, []byte("\n"))
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(append(prefix, content...), &root); err != nil {
+		return nil, rewriteError(err, filename, offset)
+	}
+
+	document := &document{
+		filename: filename,
+		root:     newMappingNode(),
+	}
+
+	if len(root.Content) == 0 {
+		return document, nil
+	}
+
+	if root.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("An error has occured while reading configuration file '%s':\nconfiguration must be a mapping", filename)
+	}
+
+	// Remove the prefix declarations, keep the file ones
+	document.root = root.Content[0]
+	document.root.Content = document.root.Content[2*len(l.anchorNames):]
+
+	l.register(document.root, filename, offset)
+
+	return document, nil
+}
+
+// anchorsPrefix returns a YAML content declaring all the anchors already known
+func (l *loader) anchorsPrefix() ([]byte, error) {
+	if len(l.anchorNames) == 0 {
+		return []byte{}, nil
+	}
+
+	prefix := newMappingNode()
+	for _, name := range l.anchorNames {
+		prefix.Content = append(prefix.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: anchorKey}, l.anchors[name])
+	}
+
+	content, err := yaml.Marshal(prefix)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to share anchors between configuration files: %v", err)
+	}
+
+	return content, nil
+}
+
+// register walks a newly parsed node tree in order to record the originating file of each node,
+// fix their line (removing the prefix) and record the anchors they declare.
+// Aliases on prefix anchors are pointed to the original anchor nodes.
+func (l *loader) register(node *yaml.Node, filename string, offset int) {
+	if node.Kind == yaml.AliasNode {
+		if original, ok := l.anchors[node.Value]; ok && node.Alias != nil && node.Alias.Line <= offset {
+			node.Alias = original
+		}
+	}
+
+	node.Line -= offset
+	l.origins[node] = filename
+
+	if node.Anchor != "" {
+		if _, ok := l.anchors[node.Anchor]; !ok {
+			l.anchorNames = append(l.anchorNames, node.Anchor)
+		}
+		l.anchors[node.Anchor] = node
+	}
+
+	for _, child := range node.Content {
+		l.register(child, filename, offset)
+	}
+}
+
+// numberLines returns a copy of the given node tree, aliases being resolved, where the line of
+// each node is the index (starting at 1) of its originating file and line in the returned sources
+func (l *loader) numberLines(node *yaml.Node, sources []Source) (*yaml.Node, []Source) {
+	node = resolveAlias(node)
+
+	sources = append(sources, nodeSource(node, l.origins))
+
+	numbered := *node
+	numbered.Anchor = ""
+	numbered.Line = len(sources)
+	numbered.Content = make([]*yaml.Node, 0, len(node.Content))
+
+	for _, child := range node.Content {
+		var copied *yaml.Node
+		copied, sources = l.numberLines(child, sources)
+		numbered.Content = append(numbered.Content, copied)
+	}
+
+	return &numbered, sources
+}
+
+// decodeError replaces the lines mentionned in a decode error of a tree numbered by numberLines by
+// their originating file and line, errors reported several times (on aliases) being kept once
+func decodeError(err error, filename string, sources []Source) error {
+	message := lineErrorRegexp.ReplaceAllStringFunc(err.Error(), func(match string) string {
+		line, _ := strconv.Atoi(lineErrorRegexp.FindStringSubmatch(match)[1])
+		if line < 1 || line > len(sources) || sources[line-1].File == "" {
+			return Source{File: filename}.String()
+		}
+
+		return sources[line-1].String()
+	})
+
+	lines := make([]string, 0)
+	seen := make(map[string]bool)
+
+	for _, line := range strings.Split(message, "\n") {
+		if seen[line] {
+			continue
+		}
+		seen[line] = true
+		lines = append(lines, line)
+	}
+
+	return fmt.Errorf("An error has occured while reading configuration file '%s':\n%s", filename, strings.Join(lines, "\n"))
+}
+
+// rewriteError replaces the lines mentionned in a YAML error by the originating file and line
+func rewriteError(err error, filename string, offset int) error {
+	message := lineErrorRegexp.ReplaceAllStringFunc(err.Error(), func(match string) string {
+		line, _ := strconv.Atoi(lineErrorRegexp.FindStringSubmatch(match)[1])
+		return Source{File: filename, Line: line - offset}.String()
+	})
+
+	return fmt.Errorf("An error has occured while reading configuration file '%s':\n%s", filename, message)
+}
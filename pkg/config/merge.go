@@ -0,0 +1,200 @@
+package config
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// mergeConflicts lists the sequences (by their path) that contain named items that cannot
+// be declared multiple times
+var mergeConflicts = map[string]string{
+	"projects": "project",
+}
+
+// mergeKeyConflicts lists the mappings (by their path) whose keys are names that cannot
+// be declared multiple times
+var mergeKeyConflicts = map[string]string{
+	"applications": "application",
+	"forwards":     "forward",
+}
+
+// MergeConflictError is returned when a named item is declared in multiple configuration files
+type MergeConflictError struct {
+	Kind     string
+	Name     string
+	Source   Source
+	Previous Source
+}
+
+// Error returns the conflict message with both locations of the item
+func (e *MergeConflictError) Error() string {
+	return fmt.Sprintf("%s: %s '%s' is already declared in %s", e.Source, e.Kind, e.Name, e.Previous)
+}
+
+// merge merges a source node into a destination one and returns the resulting node:
+// sequences are appended (named items of a previous layer being merged), mappings are
+// deeply merged and other values are overridden.
+// Given nodes are never modified as they can be referenced by aliases.
+func (l *loader) merge(path string, dst, src *yaml.Node) (*yaml.Node, error) {
+	dst, src = resolveAlias(dst), resolveAlias(src)
+
+	switch {
+	case dst == nil:
+		return src, nil
+
+	case dst.Kind == yaml.MappingNode && src.Kind == yaml.MappingNode:
+		return l.mergeMappings(path, dst, src)
+
+	case dst.Kind == yaml.SequenceNode && src.Kind == yaml.SequenceNode:
+		return l.mergeSequences(path, dst, src)
+	}
+
+	return src, nil
+}
+
+func (l *loader) mergeMappings(path string, dst, src *yaml.Node) (*yaml.Node, error) {
+	merged := l.copyNode(dst)
+	merged.Content = append([]*yaml.Node{}, dst.Content...)
+
+	for i := 0; i+1 < len(src.Content); i += 2 {
+		key, value := src.Content[i], src.Content[i+1]
+
+		// Anchors declarations are not part of the configuration
+		if path == "" && key.Value == anchorKey {
+			continue
+		}
+
+		index := mappingKeyIndex(merged, key.Value)
+		if index < 0 {
+			merged.Content = append(merged.Content, key, value)
+			continue
+		}
+
+		if kind, ok := mergeKeyConflicts[path]; ok && l.layerOf(key) == l.layerOf(merged.Content[index]) {
+			return nil, &MergeConflictError{
+				Kind:     kind,
+				Name:     key.Value,
+				Source:   nodeSource(key, l.origins),
+				Previous: nodeSource(merged.Content[index], l.origins),
+			}
+		}
+
+		value, err := l.merge(joinPath(path, key.Value), merged.Content[index+1], value)
+		if err != nil {
+			return nil, err
+		}
+
+		merged.Content[index+1] = value
+	}
+
+	return merged, nil
+}
+
+func (l *loader) mergeSequences(path string, dst, src *yaml.Node) (*yaml.Node, error) {
+	merged := l.copyNode(dst)
+	merged.Content = append([]*yaml.Node{}, dst.Content...)
+
+	kind, hasConflicts := mergeConflicts[path]
+
+	for _, item := range src.Content {
+		resolved := resolveAlias(item)
+
+		if index := findNamedItem(merged, resolved); index >= 0 {
+			previous := resolveAlias(merged.Content[index])
+
+			// Named items declared in a previous layer are overridden
+			if l.layerOf(previous) != l.layerOf(resolved) {
+				value, err := l.merge(path, previous, resolved)
+				if err != nil {
+					return nil, err
+				}
+
+				merged.Content[index] = value
+				continue
+			}
+
+			if hasConflicts {
+				return nil, &MergeConflictError{
+					Kind:     kind,
+					Name:     mappingValue(resolved, "name").Value,
+					Source:   nodeSource(resolved, l.origins),
+					Previous: nodeSource(previous, l.origins),
+				}
+			}
+		}
+
+		// Same scalar values (excluded directories, ...) are only kept once
+		if resolved.Kind == yaml.ScalarNode && findScalarItem(merged, resolved.Value) != nil {
+			continue
+		}
+
+		merged.Content = append(merged.Content, item)
+	}
+
+	return merged, nil
+}
+
+// copyNode returns a copy of the given node, keeping track of its originating file
+func (l *loader) copyNode(node *yaml.Node) *yaml.Node {
+	copied := *node
+	copied.Anchor = ""
+	l.origins[&copied] = l.origins[node]
+
+	return &copied
+}
+
+func newMappingNode() *yaml.Node {
+	return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
+
+func mappingKeyIndex(node *yaml.Node, key string) int {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// layerOf returns the index of the layer a node comes from
+func (l *loader) layerOf(node *yaml.Node) int {
+	return l.layers[l.origins[node]]
+}
+
+// findNamedItem returns the index of the item of a sequence having the same name as the given item
+func findNamedItem(sequence, item *yaml.Node) int {
+	name := mappingValue(item, "name")
+	if name == nil || name.Kind != yaml.ScalarNode {
+		return -1
+	}
+
+	for i, existing := range sequence.Content {
+		if existingName := mappingValue(existing, "name"); existingName != nil && existingName.Value == name.Value {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// findScalarItem returns the scalar item of a sequence having the given value
+func findScalarItem(sequence *yaml.Node, value string) *yaml.Node {
+	for _, existing := range sequence.Content {
+		existing = resolveAlias(existing)
+		if existing.Kind == yaml.ScalarNode && existing.Value == value {
+			return existing
+		}
+	}
+
+	return nil
+}
@@ -2,8 +2,13 @@ package config
 
 import (
 	"fmt"
+	"net/http"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"syscall"
+	"time"
 )
 
 const (
@@ -12,6 +17,29 @@ const (
 	ForwarderProxy            = "proxy"
 	ForwarderSSH              = "ssh"
 	ForwarderSSHRemote        = "ssh-remote"
+
+	BuilderCommand = "command"
+
+	FileCopy    = "copy"
+	FileContent = "content"
+
+	RestartNever     = "never"
+	RestartOnFailure = "on-failure"
+	RestartAlways    = "always"
+
+	RestartDefaultResetAfter = 30 * time.Second
+
+	StopDefaultSignal  = "SIGTERM"
+	StopDefaultTimeout = 10 * time.Second
+
+	HealthDefaultInterval  = 5 * time.Second
+	HealthDefaultTimeout   = 2 * time.Second
+	HealthDefaultThreshold = 3
+
+	LogsDefaultMaxSize  = 10 * 1024 * 1024
+	LogsDefaultMaxFiles = 5
+
+	WatchDefaultDebounce = 300 * time.Millisecond
 )
 
 var (
@@ -31,6 +59,44 @@ var (
 		ForwarderProxy:            true,
 		ForwarderSSH:              true,
 	}
+
+	// AvailableBuilders lists all ready-to-use application builders
+	AvailableBuilders = map[string]bool{
+		BuilderCommand: true,
+	}
+
+	// AvailableRestartPolicies lists all the policies to restart a local application when it exits
+	AvailableRestartPolicies = map[string]bool{
+		RestartNever:     true,
+		RestartOnFailure: true,
+		RestartAlways:    true,
+	}
+
+	// AvailableStopSignals lists all the signals that can be sent to a local application to stop it
+	AvailableStopSignals = map[string]syscall.Signal{
+		"SIGTERM": syscall.SIGTERM,
+		"SIGINT":  syscall.SIGINT,
+		"SIGQUIT": syscall.SIGQUIT,
+		"SIGHUP":  syscall.SIGHUP,
+		"SIGUSR1": syscall.SIGUSR1,
+		"SIGUSR2": syscall.SIGUSR2,
+		"SIGKILL": syscall.SIGKILL,
+	}
+
+	// sizeUnits lists the units that can be used in sizes, in bytes
+	sizeUnits = map[string]int64{
+		"":   1,
+		"B":  1,
+		"KB": 1024,
+		"MB": 1024 * 1024,
+		"GB": 1024 * 1024 * 1024,
+	}
+
+	// AvailableFileTypes lists all ready-to-use application file writers
+	AvailableFileTypes = map[string]bool{
+		FileCopy:    true,
+		FileContent: true,
+	}
 )
 
 // Config represents the root configuration item
@@ -40,17 +106,36 @@ type Config struct {
 	Run   *GlobalRun   `yaml:"run"`
 	Setup *GlobalSetup `yaml:"setup"`
 	Watch *GlobalWatch `yaml:"watch"`
+	Logs  *GlobalLogs  `yaml:"logs"`
 
 	// Global applications and forward list. If specified, these will always be launched with any project
 	Applications []*Application `yaml:"local"`
 	Forwards     []*Forward     `yaml:"forward"`
 
+	// Applications and forwards definitions, that can be referenced by their name in any list
+	ApplicationDefinitions map[string]*Application `yaml:"applications"`
+	ForwardDefinitions     map[string]*Forward     `yaml:"forwards"`
+
 	// Other global configuration values
 	GoPath     string `yaml:"gopath"`
 	KubeConfig string `yaml:"kubeconfig"`
 
 	// Projects
 	Projects []*Project `yaml:"projects"`
+
+	// Profiles that can be selected at run time
+	Profiles map[string]*Profile `yaml:"profiles"`
+
+	// Originating file and line of each configuration item
+	sources map[interface{}]Source
+}
+
+// Profile represents an environment (staging, preprod, ...) that can be selected at run time:
+// it rewrites the forwards context and namespace and provides variables to the configuration
+type Profile struct {
+	Context   string            `yaml:"context"`
+	Namespace string            `yaml:"namespace"`
+	Vars      map[string]string `yaml:"vars"`
 }
 
 // GlobalBuild represents the global configuration values for the file builder component
@@ -70,14 +155,98 @@ type GlobalSetup struct {
 
 // GlobalWatch represents the global configuration values for the file watcher component
 type GlobalWatch struct {
-	Exclude []string `yaml:"exclude"`
+	Exclude  []string `yaml:"exclude"`
+	Debounce string   `yaml:"debounce"`
+}
+
+// GetExclude returns the patterns of the files and directories never watched, in addition to the default ones
+func (w *GlobalWatch) GetExclude() []string {
+	if w == nil {
+		return nil
+	}
+
+	return w.Exclude
+}
+
+// GetDebounce returns the duration without any file change waited for before rebuilding an application,
+// so a burst of changes only rebuilds it once
+func (w *GlobalWatch) GetDebounce() time.Duration {
+	if w == nil {
+		return WatchDefaultDebounce
+	}
+
+	return parseDuration(w.Debounce, WatchDefaultDebounce)
+}
+
+// GlobalLogs represents the global configuration values for the application log files
+type GlobalLogs struct {
+	Directory string `yaml:"directory"`
+	MaxSize   string `yaml:"max_size"`
+	MaxFiles  int    `yaml:"max_files"`
+}
+
+// GetDirectory returns the directory containing the log files of all projects, ~/.monday/logs by default
+func (l *GlobalLogs) GetDirectory() string {
+	if l == nil || l.Directory == "" {
+		return filepath.Join(defaultConfigPath, ".monday", "logs")
+	}
+
+	return expandValueFromEnvironment(l.Directory)
+}
+
+// GetMaxSize returns the size (in bytes) a log file can reach before being rotated
+func (l *GlobalLogs) GetMaxSize() int64 {
+	if l == nil {
+		return LogsDefaultMaxSize
+	}
+
+	size, err := parseSize(l.MaxSize)
+	if err != nil || size <= 0 {
+		return LogsDefaultMaxSize
+	}
+
+	return size
+}
+
+// GetMaxFiles returns the number of rotated log files kept for each application
+func (l *GlobalLogs) GetMaxFiles() int {
+	if l == nil || l.MaxFiles <= 0 {
+		return LogsDefaultMaxFiles
+	}
+
+	return l.MaxFiles
 }
 
 // Project represents a project name, that could be a group of multiple projects
 type Project struct {
 	Name         string         `yaml:"name"`
+	Extends      []string       `yaml:"extends"`
 	Applications []*Application `yaml:"local"`
 	Forwards     []*Forward     `yaml:"forward"`
+	Overrides    *Overrides     `yaml:"overrides"`
+}
+
+// Overrides represents the patches applied on the applications and forwards of a project
+// (including inherited ones), by their name
+type Overrides struct {
+	Applications map[string]*ApplicationOverride `yaml:"local"`
+	Forwards     map[string]*ForwardOverride     `yaml:"forward"`
+}
+
+// ApplicationOverride represents the values that can be patched on an application
+type ApplicationOverride struct {
+	Watch *bool             `yaml:"watch"`
+	Env   map[string]string `yaml:"env"`
+
+	// Options of the file watcher replacing the application ones, when 'watch' is declared as a mapping
+	WatchOptions *WatchOptions `yaml:"-"`
+}
+
+// ForwardOverride represents the values that can be patched on a forward
+type ForwardOverride struct {
+	Context   string   `yaml:"context"`
+	Namespace string   `yaml:"namespace"`
+	Ports     []string `yaml:"ports"`
 }
 
 // PrependApplications prepends some global local applications to the current project.
@@ -101,6 +270,47 @@ type Application struct {
 	Run        *Run        `yaml:"run"`
 	Files      []*File     `yaml:"files"`
 	Monitoring *Monitoring `yaml:"monitoring"`
+	DependsOn  []string    `yaml:"depends_on"`
+	Health     *Health     `yaml:"health"`
+
+	// Options of the file watcher, when 'watch' is declared as a mapping instead of a boolean
+	WatchOptions *WatchOptions `yaml:"-"`
+
+	// Name of the referenced application definition, when declared by its name only
+	reference string
+}
+
+// WatchOptions represents the files watched for an application, which is rebuilt and restarted when they change
+type WatchOptions struct {
+	Enabled   *bool    `yaml:"enabled"`
+	Include   []string `yaml:"include"`
+	Exclude   []string `yaml:"exclude"`
+	Paths     []string `yaml:"paths"`
+	Gitignore *bool    `yaml:"gitignore"`
+}
+
+// GetInclude returns the patterns of the files watched, all files being watched when empty
+func (w *WatchOptions) GetInclude() []string {
+	if w == nil {
+		return nil
+	}
+
+	return w.Include
+}
+
+// GetExclude returns the patterns of the files and directories not watched
+func (w *WatchOptions) GetExclude() []string {
+	if w == nil {
+		return nil
+	}
+
+	return w.Exclude
+}
+
+// IsGitignoreEnabled indicates if the files ignored by the .gitignore file of a watched directory are not watched,
+// which is the default
+func (w *WatchOptions) IsGitignoreEnabled() bool {
+	return w == nil || w.Gitignore == nil || *w.Gitignore
 }
 
 // Build represents application build information
@@ -131,6 +341,27 @@ func (a *Application) GetPath() string {
 	return getValueByExecutionContext(a.Path)
 }
 
+// GetWatchPaths returns the path of the application followed by the other paths it watches, relative ones
+// being relative to the application path
+func (a *Application) GetWatchPaths() []string {
+	paths := []string{a.GetPath()}
+
+	if a.WatchOptions == nil {
+		return paths
+	}
+
+	for _, path := range a.WatchOptions.Paths {
+		path = expandValueFromEnvironment(path)
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(paths[0], path)
+		}
+
+		paths = append(paths, path)
+	}
+
+	return paths
+}
+
 // File represents a file that have to be written
 type File struct {
 	Type    string `yaml:"type"`
@@ -154,6 +385,9 @@ type Forward struct {
 	Type       string        `yaml:"type"`
 	Values     ForwardValues `yaml:"values"`
 	Monitoring *Monitoring   `yaml:"monitoring"`
+
+	// Name of the referenced forward definition, when declared by its name only
+	reference string
 }
 
 // IsProxified indicates if the current forward rule will use the proxy
@@ -186,6 +420,49 @@ type Run struct {
 	Env          map[string]string `yaml:"env"`
 	EnvFile      string            `yaml:"env_file"`
 	StopCommands []string          `yaml:"stop_commands"`
+	Restart      string            `yaml:"restart"`
+	MaxRestarts  int               `yaml:"max_restarts"`
+	ResetAfter   string            `yaml:"restart_reset_after"`
+	StopSignal   string            `yaml:"stop_signal"`
+	StopTimeout  string            `yaml:"stop_timeout"`
+}
+
+// GetRestart returns the restart policy of the application, never restarted by default
+func (r *Run) GetRestart() string {
+	if r.Restart == "" {
+		return RestartNever
+	}
+
+	return r.Restart
+}
+
+// GetResetAfter returns the duration an application has to stay up for its restart delay to be reset
+func (r *Run) GetResetAfter() time.Duration {
+	return parseDuration(r.ResetAfter, RestartDefaultResetAfter)
+}
+
+// GetStopSignal returns the signal sent to the application to stop it, SIGTERM by default
+func (r *Run) GetStopSignal() syscall.Signal {
+	if signal, ok := AvailableStopSignals[normalizeSignal(r.StopSignal)]; ok {
+		return signal
+	}
+
+	return AvailableStopSignals[StopDefaultSignal]
+}
+
+// GetStopTimeout returns the duration to wait for the application to exit before killing it
+func (r *Run) GetStopTimeout() time.Duration {
+	return parseDuration(r.StopTimeout, StopDefaultTimeout)
+}
+
+// normalizeSignal returns the given signal name in upper case and prefixed by "SIG" so both "term" and "SIGTERM" can be used
+func normalizeSignal(name string) string {
+	name = strings.ToUpper(name)
+	if name != "" && !strings.HasPrefix(name, "SIG") {
+		name = "SIG" + name
+	}
+
+	return name
 }
 
 // GetEnvFile returns the filename guessed with current application environment
@@ -219,6 +496,104 @@ type Monitoring struct {
 	URL  string `yaml:"url"`
 }
 
+// Health represents the health check of a local application: only one of the
+// http, tcp, exec or log checks has to be defined
+type Health struct {
+	HTTP      *HTTPHealth `yaml:"http"`
+	TCP       *TCPHealth  `yaml:"tcp"`
+	Exec      *ExecHealth `yaml:"exec"`
+	Log       *LogHealth  `yaml:"log"`
+	Interval  string      `yaml:"interval"`
+	Timeout   string      `yaml:"timeout"`
+	Threshold int         `yaml:"threshold"`
+}
+
+// HTTPHealth checks that an URL responds with the expected status code
+type HTTPHealth struct {
+	URL    string `yaml:"url"`
+	Status int    `yaml:"status"`
+}
+
+// GetStatus returns the expected status code, 200 by default
+func (h *HTTPHealth) GetStatus() int {
+	if h.Status == 0 {
+		return http.StatusOK
+	}
+
+	return h.Status
+}
+
+// TCPHealth checks that a port accepts connections
+type TCPHealth struct {
+	Host string `yaml:"host"`
+	Port string `yaml:"port"`
+}
+
+// GetHost returns the host to connect to, 127.0.0.1 by default
+func (h *TCPHealth) GetHost() string {
+	if h.Host == "" {
+		return "127.0.0.1"
+	}
+
+	return h.Host
+}
+
+// ExecHealth checks that a command exits successfully
+type ExecHealth struct {
+	Command string `yaml:"command"`
+}
+
+// LogHealth checks that the application has written a line matching a pattern
+type LogHealth struct {
+	Pattern string `yaml:"pattern"`
+}
+
+// GetInterval returns the duration between two checks
+func (h *Health) GetInterval() time.Duration {
+	return parseDuration(h.Interval, HealthDefaultInterval)
+}
+
+// GetTimeout returns the maximum duration of a check
+func (h *Health) GetTimeout() time.Duration {
+	return parseDuration(h.Timeout, HealthDefaultTimeout)
+}
+
+// GetThreshold returns the number of consecutive failed checks before the application is unhealthy
+func (h *Health) GetThreshold() int {
+	if h.Threshold <= 0 {
+		return HealthDefaultThreshold
+	}
+
+	return h.Threshold
+}
+
+// parseSize parses a size such as "512KB" or "10MB" and returns it in bytes
+func parseSize(value string) (int64, error) {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	number := strings.TrimRight(value, "BKMG")
+
+	unit, ok := sizeUnits[value[len(number):]]
+	if !ok {
+		return 0, fmt.Errorf("unknown unit in size '%s'", value)
+	}
+
+	size, err := strconv.ParseInt(strings.TrimSpace(number), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	return size * unit, nil
+}
+
+func parseDuration(value string, defaultValue time.Duration) time.Duration {
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		return defaultValue
+	}
+
+	return duration
+}
+
 func expandValueFromEnvironment(path string) string {
 	if strings.Contains(path, "~") {
 		path = strings.Replace(path, "~", "$HOME", -1)
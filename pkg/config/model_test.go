@@ -2,7 +2,10 @@ package config
 
 import (
 	"os"
+	"path/filepath"
+	"syscall"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -144,3 +147,91 @@ func TestProjectPrependForwards(t *testing.T) {
 		{Name: "My project forward 2"},
 	}, project.Forwards)
 }
+
+func TestRunGetStopSignal(t *testing.T) {
+	// Given
+	testCases := []struct {
+		signal   string
+		expected syscall.Signal
+	}{
+		{signal: "", expected: syscall.SIGTERM},
+		{signal: "SIGINT", expected: syscall.SIGINT},
+		{signal: "quit", expected: syscall.SIGQUIT},
+	}
+
+	for _, testCase := range testCases {
+		run := &Run{StopSignal: testCase.signal}
+
+		// When - Then
+		assert.Equal(t, testCase.expected, run.GetStopSignal())
+	}
+}
+
+func TestGlobalLogsGetMaxSize(t *testing.T) {
+	// Given
+	testCases := []struct {
+		maxSize  string
+		expected int64
+	}{
+		{maxSize: "", expected: LogsDefaultMaxSize},
+		{maxSize: "2048", expected: 2048},
+		{maxSize: "512KB", expected: 512 * 1024},
+		{maxSize: "50mb", expected: 50 * 1024 * 1024},
+		{maxSize: "1 GB", expected: 1024 * 1024 * 1024},
+		{maxSize: "10 parsecs", expected: LogsDefaultMaxSize},
+	}
+
+	for _, testCase := range testCases {
+		logs := &GlobalLogs{MaxSize: testCase.maxSize}
+
+		// When - Then
+		assert.Equal(t, testCase.expected, logs.GetMaxSize())
+	}
+
+	var logs *GlobalLogs
+	assert.Equal(t, int64(LogsDefaultMaxSize), logs.GetMaxSize())
+	assert.Equal(t, LogsDefaultMaxFiles, logs.GetMaxFiles())
+}
+
+func TestApplicationGetWatchPaths(t *testing.T) {
+	// Given
+	os.Setenv("MONDAY_SHARED_PATH", "/home/me/shared")
+	defer os.Unsetenv("MONDAY_SHARED_PATH")
+
+	dir := t.TempDir()
+
+	application := &Application{
+		Path:         dir,
+		WatchOptions: &WatchOptions{Paths: []string{"../library", "$MONDAY_SHARED_PATH/proto"}},
+	}
+
+	// When
+	paths := application.GetWatchPaths()
+
+	// Then
+	assert.Equal(t, []string{dir, filepath.Join(filepath.Dir(dir), "library"), "/home/me/shared/proto"}, paths)
+	assert.Equal(t, []string{dir}, (&Application{Path: dir}).GetWatchPaths())
+}
+
+func TestGlobalWatchGetDebounce(t *testing.T) {
+	// Given
+	testCases := []struct {
+		debounce string
+		expected time.Duration
+	}{
+		{debounce: "", expected: WatchDefaultDebounce},
+		{debounce: "1s", expected: time.Second},
+		{debounce: "soon", expected: WatchDefaultDebounce},
+	}
+
+	for _, testCase := range testCases {
+		watch := &GlobalWatch{Debounce: testCase.debounce}
+
+		// When - Then
+		assert.Equal(t, testCase.expected, watch.GetDebounce())
+	}
+
+	var watch *GlobalWatch
+	assert.Equal(t, WatchDefaultDebounce, watch.GetDebounce())
+	assert.Nil(t, watch.GetExclude())
+}
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Resolved returns the final configuration in YAML, resulting of the merge of all the configuration
+// layers with variables expanded and profile applied. Each value is commented with its source.
+func Resolved() ([]byte, error) {
+	if err := CheckConfigFileExists(); err != nil {
+		return nil, err
+	}
+
+	l := newLoader()
+
+	root, err := l.resolve(FindConfigLayers(), ProfileName)
+	if err != nil {
+		return nil, err
+	}
+
+	var content bytes.Buffer
+
+	encoder := yaml.NewEncoder(&content)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(l.annotate(root, make(map[*yaml.Node]*yaml.Node))); err != nil {
+		return nil, fmt.Errorf("Unable to print the resolved configuration: %v", err)
+	}
+	encoder.Close()
+
+	return content.Bytes(), nil
+}
+
+// annotate returns a copy of the given node tree where aliases are replaced by the nodes they
+// target and scalar values are commented with their source
+func (l *loader) annotate(node *yaml.Node, annotated map[*yaml.Node]*yaml.Node) *yaml.Node {
+	node = resolveAlias(node)
+	if copied, ok := annotated[node]; ok {
+		return copied
+	}
+
+	copied := *node
+	copied.Anchor = ""
+	copied.HeadComment, copied.LineComment, copied.FootComment = "", "", ""
+	annotated[node] = &copied
+
+	if node.Kind == yaml.ScalarNode {
+		copied.LineComment = nodeSource(node, l.origins).String()
+		return &copied
+	}
+
+	copied.Content = make([]*yaml.Node, 0, len(node.Content))
+
+	for i, child := range node.Content {
+		// Mapping keys are not commented
+		if node.Kind == yaml.MappingNode && i%2 == 0 {
+			key := *child
+			key.HeadComment, key.LineComment, key.FootComment = "", "", ""
+			copied.Content = append(copied.Content, &key)
+			continue
+		}
+
+		copied.Content = append(copied.Content, l.annotate(child, annotated))
+	}
+
+	return &copied
+}
@@ -0,0 +1,161 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// profilesKey is the root key used in configuration files to declare profiles
+	profilesKey = "profiles"
+)
+
+// variableRegexp matches the "${name}" and "${name:-default}" variables, as well as their "$${name}" escaped form
+var variableRegexp = regexp.MustCompile(`\$?\$\{([A-Za-z0-9_.-]+)(:-([^}]*))?\}`)
+
+// selectProfile returns the profile having the given name, declared in the configuration root node
+func selectProfile(root *yaml.Node, name string) (*Profile, error) {
+	if name == "" {
+		return nil, nil
+	}
+
+	profiles := make(map[string]*Profile)
+
+	if node := mappingValue(root, profilesKey); node != nil {
+		if err := node.Decode(&profiles); err != nil {
+			return nil, fmt.Errorf("An error has occured while reading configuration profiles:\n%v", err)
+		}
+	}
+
+	profile, ok := profiles[name]
+	if !ok || profile == nil {
+		return nil, fmt.Errorf("Unable to find profile '%s' in the configuration", name)
+	}
+
+	return profile, nil
+}
+
+// expandVariables replaces the variables of every scalar value of the given node tree.
+// Profiles declarations are left untouched.
+func expandVariables(node *yaml.Node, profile *Profile, visited map[*yaml.Node]bool) {
+	node = resolveAlias(node)
+	if node == nil || visited[node] {
+		return
+	}
+	visited[node] = true
+
+	switch node.Kind {
+	case yaml.ScalarNode:
+		value := expandVariable(node.Value, profile)
+		if value == node.Value {
+			return
+		}
+
+		node.Value = value
+
+		// Unquoted values have their type (bool, int, ...) resolved again from the expanded value
+		if node.Style&(yaml.SingleQuotedStyle|yaml.DoubleQuotedStyle|yaml.LiteralStyle|yaml.FoldedStyle) == 0 {
+			node.Tag = ""
+		}
+
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == profilesKey {
+				continue
+			}
+
+			expandVariables(node.Content[i+1], profile, visited)
+		}
+
+	default:
+		for _, child := range node.Content {
+			expandVariables(child, profile, visited)
+		}
+	}
+}
+
+// expandVariable replaces the "${name}" and "${name:-default}" variables of a value using the
+// profile variables first, then the environment variables and finally the default value.
+// Variables that cannot be resolved are left as is and escaped ones ("$${name}") are unescaped,
+// so that they can be expanded later by a shell.
+func expandVariable(value string, profile *Profile) string {
+	return variableRegexp.ReplaceAllStringFunc(value, func(match string) string {
+		if strings.HasPrefix(match, "$$") {
+			return match[1:]
+		}
+
+		parts := variableRegexp.FindStringSubmatch(match)
+		name, hasDefault, defaultValue := parts[1], parts[2] != "", parts[3]
+
+		if profile != nil {
+			if value, ok := profile.Vars[name]; ok {
+				return value
+			}
+		}
+
+		if value, ok := os.LookupEnv(name); ok && value != "" {
+			return value
+		}
+
+		if hasDefault {
+			return defaultValue
+		}
+
+		return match
+	})
+}
+
+// applyProfile rewrites the Kubernetes context and namespace of every forward with the ones of
+// the given profile. Rewritten values keep track of the profile they come from.
+func (l *loader) applyProfile(root *yaml.Node, name string) {
+	profile := mappingValue(mappingValue(root, profilesKey), name)
+	if name == "" || profile == nil {
+		return
+	}
+
+	forwards := sequenceItems(mappingValue(root, "forward"))
+
+	if definitions := mappingValue(root, "forwards"); definitions != nil {
+		for i := 1; i < len(definitions.Content); i += 2 {
+			forwards = append(forwards, resolveAlias(definitions.Content[i]))
+		}
+	}
+
+	for _, project := range sequenceItems(mappingValue(root, "projects")) {
+		forwards = append(forwards, sequenceItems(mappingValue(project, "forward"))...)
+	}
+
+	for _, forward := range forwards {
+		forwardType := mappingValue(forward, "type")
+		if forwardType == nil || (forwardType.Value != ForwarderKubernetes && forwardType.Value != ForwarderKubernetesRemote) {
+			continue
+		}
+
+		values := mappingValue(forward, "values")
+		if values == nil {
+			continue
+		}
+
+		for _, key := range []string{"context", "namespace"} {
+			if value := mappingValue(profile, key); value != nil && value.Kind == yaml.ScalarNode && value.Value != "" {
+				l.setMappingValue(values, key, value)
+			}
+		}
+	}
+}
+
+// setMappingValue sets the value of a key in a mapping node, with a copy of the given value node
+func (l *loader) setMappingValue(node *yaml.Node, key string, value *yaml.Node) {
+	value = l.copyNode(value)
+
+	if index := mappingKeyIndex(node, key); index >= 0 {
+		node.Content[index+1] = value
+		return
+	}
+
+	node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, value)
+}
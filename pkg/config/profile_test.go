@@ -0,0 +1,114 @@
+package config
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadWhenNoProfile(t *testing.T) {
+	// Given
+	dir, _ := os.Getwd()
+	Filepath = dir + "/../../internal/test/config/profiles/monday.yaml"
+	MultipleFilepath = dir + "/../../internal/test/config/profiles/monday.unknown.*.yaml"
+
+	// When
+	conf, err := Load()
+
+	// Then
+	assert.Nil(t, err)
+
+	project, _ := conf.GetProjectByName("graphql")
+
+	assert.True(t, project.Applications[0].Watch)
+	assert.Equal(t, "go run main.go --port ${PORT}", project.Applications[0].Run.Command)
+	assert.Equal(t, map[string]string{
+		"PORT":     "8005",
+		"HOME_DIR": "$HOME",
+		"UNKNOWN":  "${MONDAY_TEST_UNDEFINED}",
+	}, project.Applications[0].Run.Env)
+
+	assert.Equal(t, "preprod", project.Forwards[0].Values.Context)
+	assert.Equal(t, "backend", project.Forwards[0].Values.Namespace)
+	assert.Equal(t, []string{"8080:8080"}, project.Forwards[0].Values.Ports)
+	assert.Equal(t, "root@mailer.preprod.local", project.Forwards[1].Values.Remote)
+}
+
+func TestLoadWhenProfile(t *testing.T) {
+	// Given
+	dir, _ := os.Getwd()
+	Filepath = dir + "/../../internal/test/config/profiles/monday.yaml"
+	MultipleFilepath = dir + "/../../internal/test/config/profiles/monday.unknown.*.yaml"
+
+	ProfileName = "staging"
+	defer func() { ProfileName = "" }()
+
+	// When
+	conf, err := Load()
+
+	// Then
+	assert.Nil(t, err)
+
+	project, _ := conf.GetProjectByName("graphql")
+
+	assert.False(t, project.Applications[0].Watch)
+	assert.Equal(t, "8005", project.Applications[0].Run.Env["PORT"])
+
+	assert.Equal(t, "staging", project.Forwards[0].Values.Context)
+	assert.Equal(t, "backend-stg", project.Forwards[0].Values.Namespace)
+	assert.Equal(t, []string{"8081:8080"}, project.Forwards[0].Values.Ports)
+
+	// Only Kubernetes forwards have their context rewritten
+	assert.Equal(t, "", project.Forwards[1].Values.Context)
+	assert.Equal(t, "root@mailer.staging.local", project.Forwards[1].Values.Remote)
+}
+
+func TestLoadWhenUnknownProfile(t *testing.T) {
+	// Given
+	dir, _ := os.Getwd()
+	Filepath = dir + "/../../internal/test/config/profiles/monday.yaml"
+	MultipleFilepath = dir + "/../../internal/test/config/profiles/monday.unknown.*.yaml"
+
+	ProfileName = "unknown"
+	defer func() { ProfileName = "" }()
+
+	// When
+	conf, err := Load()
+
+	// Then
+	assert.Nil(t, conf)
+	assert.EqualError(t, err, "Unable to find profile 'unknown' in the configuration")
+}
+
+func TestExpandVariable(t *testing.T) {
+	// Given
+	os.Setenv("MONDAY_TEST_VARIABLE", "from-env")
+	defer os.Unsetenv("MONDAY_TEST_VARIABLE")
+
+	profile := &Profile{Vars: map[string]string{"tag": "v1.0", "MONDAY_TEST_VARIABLE": "from-profile"}}
+
+	testCases := []struct {
+		value    string
+		profile  *Profile
+		expected string
+	}{
+		{value: "image:${tag}", profile: profile, expected: "image:v1.0"},
+		{value: "image:${tag:-latest}", profile: nil, expected: "image:latest"},
+		{value: "${MONDAY_TEST_VARIABLE}", profile: nil, expected: "from-env"},
+		{value: "${MONDAY_TEST_VARIABLE}", profile: profile, expected: "from-profile"},
+		{value: "${MONDAY_TEST_UNDEFINED:-}", profile: nil, expected: ""},
+		{value: "${MONDAY_TEST_UNDEFINED}", profile: profile, expected: "${MONDAY_TEST_UNDEFINED}"},
+		{value: "$MONDAY_TEST_VARIABLE", profile: profile, expected: "$MONDAY_TEST_VARIABLE"},
+		{value: "$${MONDAY_TEST_VARIABLE}", profile: profile, expected: "${MONDAY_TEST_VARIABLE}"},
+		{value: "echo $${tag:-latest} ${tag}", profile: profile, expected: "echo ${tag:-latest} v1.0"},
+	}
+
+	for _, testCase := range testCases {
+		// When
+		result := expandVariable(testCase.value, testCase.profile)
+
+		// Then
+		assert.Equal(t, testCase.expected, result)
+	}
+}
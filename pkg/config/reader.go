@@ -3,13 +3,8 @@ package config
 import (
 	"errors"
 	"fmt"
-	"io/ioutil"
-	"log"
 	"os"
 	"path/filepath"
-	"strings"
-
-	"gopkg.in/yaml.v2"
 )
 
 const (
@@ -29,39 +24,35 @@ var (
 	// MultipleFilepath is the path of the YAML configuration files when
 	// you define multiple config files
 	MultipleFilepath string
+
+	// ProfileName is the name of the profile to apply on the configuration, if any
+	ProfileName = os.Getenv("MONDAY_PROFILE")
 )
 
 func init() {
 	setConfigFilePaths()
 }
 
-// Load method loads the configuration from the YAML configuration file
+// Load method loads the configuration from the YAML configuration files.
+// Each file is parsed separately and merged into a single configuration in memory,
+// layer after layer (see FindConfigLayers).
 func Load() (*Config, error) {
-	files := FindMultipleConfigFiles()
-
-	if len(files) > 0 {
-		err := createConfigFromMultiple(files)
-		if err != nil {
-			return nil, err
-		}
-	}
-
 	err := CheckConfigFileExists()
 	if err != nil {
 		return nil, err
 	}
 
-	// Check for multiple config files
-	var conf Config
-
-	file, err := ioutil.ReadFile(Filepath)
+	conf, err := newLoader().load(FindConfigLayers(), ProfileName)
 	if err != nil {
-		log.Printf("Error while reading config file: #%v", err)
+		return nil, err
 	}
 
-	err = yaml.Unmarshal(file, &conf)
-	if err != nil {
-		panic(fmt.Sprintf("An error has occured while reading configuration file:\n%v", err))
+	if err := conf.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := conf.resolveGlobals(); err != nil {
+		return nil, err
 	}
 
 	// Override GOPATH environment variable if defined in configuration
@@ -74,51 +65,39 @@ func Load() (*Config, error) {
 		os.Setenv("MONDAY_KUBE_CONFIG", conf.KubeConfig)
 	}
 
-	return &conf, nil
+	return conf, nil
 }
 
-// FindMultipleConfigFiles finds if multiple configuration files has been created
-func FindMultipleConfigFiles() []string {
-	matches, _ := filepath.Glob(MultipleFilepath)
+// FindConfigFiles returns all the configuration files to load, in the order they are merged
+func FindConfigFiles() []string {
+	files := make([]string, 0)
 
-	for i, match := range matches {
-		if strings.Contains(match, Filepath) {
-			matches = append(matches[:i], matches[i+1:]...)
-		}
+	for _, layer := range FindConfigLayers() {
+		files = append(files, layer.Files...)
 	}
 
-	return matches
+	return files
 }
 
-// Merge multiple configuration files into a single one
-func createConfigFromMultiple(matches []string) error {
-	configFile, err := os.Create(Filepath)
-	if err != nil {
-		return err
-	}
-	defer configFile.Close()
+// FindMultipleConfigFiles finds if multiple configuration files has been created
+func FindMultipleConfigFiles() []string {
+	matches, _ := filepath.Glob(MultipleFilepath)
 
-	added := 0
+	files := make([]string, 0, len(matches))
 	for _, match := range matches {
-		file, err := ioutil.ReadFile(match)
-		if err != nil {
+		if filepath.Clean(match) == filepath.Clean(Filepath) {
 			continue
 		}
 
-		configFile.Write(file)
-		added++
-	}
-
-	if added == 0 {
-		return errors.New("Unable to process any configuration file")
+		files = append(files, match)
 	}
 
-	return nil
+	return files
 }
 
-// CheckConfigFileExists ensures that config file is present before going further
+// CheckConfigFileExists ensures that at least one config file is present before going further
 func CheckConfigFileExists() error {
-	if _, err := os.Stat(Filepath); os.IsNotExist(err) {
+	if len(FindConfigFiles()) == 0 {
 		return errors.New("Configuration file not found. If you run for the first time, please use 'init' command")
 	}
 
@@ -136,15 +115,15 @@ func (c *Config) GetProjectNames() []string {
 	return list
 }
 
-// GetProjectByName returns a project configuration from its name
+// GetProjectByName returns a project configuration from its name, resolved with
+// the projects it extends and its overrides
 func (c *Config) GetProjectByName(name string) (*Project, error) {
-	for _, project := range c.Projects {
-		if project.Name == name {
-			return project, nil
-		}
+	project := c.findProject(name)
+	if project == nil {
+		return nil, fmt.Errorf("Unable to find project name '%s' in the configuration", name)
 	}
 
-	return nil, fmt.Errorf("Unable to find project name '%s' in the configuration", name)
+	return c.resolveProject(project, nil)
 }
 
 func getConfigPath() string {
@@ -155,6 +134,15 @@ func getConfigPath() string {
 	return defaultConfigPath
 }
 
+// GetSessionsPath returns the directory containing the files and control sockets of the running Monday sessions
+func GetSessionsPath() string {
+	if value := os.Getenv("MONDAY_SESSIONS_PATH"); value != "" {
+		return value
+	}
+
+	return filepath.Join(defaultConfigPath, ".monday", "sessions")
+}
+
 func setConfigFilePaths() {
 	Filepath = fmt.Sprintf("%s/%s", getConfigPath(), Filename)
 	MultipleFilepath = fmt.Sprintf("%s/%s", getConfigPath(), MultipleFilenamePattern)
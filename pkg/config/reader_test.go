@@ -2,6 +2,7 @@ package config
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -33,9 +34,6 @@ func TestLoadMultipleFiles(t *testing.T) {
 	Filepath = dir + "/../../internal/test/config/unknown.yaml"
 	MultipleFilepath = dir + "/../../internal/test/config/monday.multiple.*.yaml"
 
-	// Remove single config created file after test
-	defer os.Remove(Filepath)
-
 	// When
 	conf, err := Load()
 
@@ -54,11 +52,12 @@ func TestLoadMultipleFiles(t *testing.T) {
 func TestLoadWhenCustomDirectory(t *testing.T) {
 	// Given
 	dir, _ := os.Getwd()
-	os.Setenv("MONDAY_CONFIG_PATH", dir+"/../../internal/test/config")
+	os.Setenv("MONDAY_CONFIG_PATH", dir+"/../../internal/test/config/merge")
+	defer os.Unsetenv("MONDAY_CONFIG_PATH")
 
 	setConfigFilePaths() // Normally run during package init()
 
-	Filepath = dir + "/../../internal/test/config/unknown.yaml" // don't read the single file
+	content, _ := os.ReadFile(Filepath)
 
 	// When
 	conf, err := Load()
@@ -67,11 +66,44 @@ func TestLoadWhenCustomDirectory(t *testing.T) {
 	assert.IsType(t, new(Config), conf)
 	assert.Nil(t, err)
 
-	assert.Len(t, conf.Projects, 2)
-	assert.Equal(t, conf.Watch.Exclude, []string{
+	assert.Equal(t, []string{"graphql", "forward-only"}, conf.GetProjectNames())
+	assert.Equal(t, []string{
 		".git",
 		"node_modules",
-	})
+		"vendor",
+	}, conf.Watch.Exclude)
+	assert.Equal(t, map[string]string{
+		"CGO_ENABLED":     "0",
+		"DOCKER_BUILDKIT": "1",
+	}, conf.Build.Env)
+
+	// Anchors are shared between files, in any order
+	project, _ := conf.GetProjectByName("graphql")
+	assert.Equal(t, "github.com/eko/graphql", project.Applications[0].Path)
+	assert.Equal(t, "context-test", project.Forwards[0].Values.Context)
+
+	// Single configuration file is left untouched
+	newContent, _ := os.ReadFile(Filepath)
+	assert.Equal(t, content, newContent)
+}
+
+func TestLoadWhenProjectDeclaredInMultipleFiles(t *testing.T) {
+	// Given
+	dir, _ := os.Getwd()
+	Filepath = filepath.Clean(dir + "/../../internal/test/config/monday.yaml")
+	MultipleFilepath = filepath.Clean(dir + "/../../internal/test/config/monday.multiple.*.yaml")
+
+	// When
+	conf, err := Load()
+
+	// Then
+	assert.Nil(t, conf)
+	assert.Equal(t, &MergeConflictError{
+		Kind:     "project",
+		Name:     "graphql",
+		Source:   Source{File: filepath.Clean(dir + "/../../internal/test/config/monday.multiple.project.yaml"), Line: 20},
+		Previous: Source{File: Filepath, Line: 49},
+	}, err)
 }
 
 func TestGetProjectNames(t *testing.T) {
@@ -0,0 +1,177 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ProjectSeparator joins the names of the projects run together, in the name of the combined project
+const ProjectSeparator = "+"
+
+// CombineProjects returns a single project containing the applications and forwards of the given projects
+// (resolved with the projects they extend) preceded by the global ones. An application or forward declared
+// identically by several projects is only kept once, while different items sharing a name or a hostname
+// are reported as an error.
+func (c *Config) CombineProjects(names []string) (*Project, error) {
+	if len(names) == 0 {
+		return nil, fmt.Errorf("No project selected")
+	}
+
+	combined := &Project{Name: strings.Join(names, ProjectSeparator)}
+
+	// The sources ("project 'x'") of the applications and forwards, and the owners of the hostnames are
+	// kept to report the collisions
+	sources := make(map[string]string)
+	hostnames := make(map[string]string)
+
+	addHostname := func(hostname, owner string) error {
+		if existing, ok := hostnames[hostname]; ok && hostname != "" {
+			return fmt.Errorf("hostname '%s' of %s is already used by %s", hostname, owner, existing)
+		}
+		hostnames[hostname] = owner
+
+		return nil
+	}
+
+	addApplication := func(source string, application *Application) error {
+		key := "application '" + application.Name + "'"
+
+		if existing := combined.findApplication(application.Name); existing != nil {
+			if !reflect.DeepEqual(existing, application) {
+				return fmt.Errorf("%s of %s differs from the one of %s", key, source, sources[key])
+			}
+
+			return nil
+		}
+
+		if err := addHostname(application.Hostname, fmt.Sprintf("%s of %s", key, source)); err != nil {
+			return err
+		}
+
+		sources[key] = source
+		combined.Applications = append(combined.Applications, application)
+
+		return nil
+	}
+
+	addForward := func(source string, forward *Forward) error {
+		key := "forward '" + forward.Name + "'"
+
+		if existing := combined.findForward(forward.Name); existing != nil {
+			if !reflect.DeepEqual(existing, forward) {
+				return fmt.Errorf("%s of %s differs from the one of %s", key, source, sources[key])
+			}
+
+			return nil
+		}
+
+		if err := addHostname(forward.Values.Hostname, fmt.Sprintf("%s of %s", key, source)); err != nil {
+			return err
+		}
+
+		sources[key] = source
+		combined.Forwards = append(combined.Forwards, forward)
+
+		return nil
+	}
+
+	for _, application := range c.Applications {
+		if err := addApplication("the global configuration", application); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, forward := range c.Forwards {
+		if err := addForward("the global configuration", forward); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, name := range names {
+		for _, previous := range names[:i] {
+			if previous == name {
+				return nil, fmt.Errorf("Project '%s' is selected multiple times", name)
+			}
+		}
+
+		project, err := c.GetProjectByName(name)
+		if err != nil {
+			return nil, err
+		}
+
+		source := fmt.Sprintf("project '%s'", name)
+
+		for _, application := range project.Applications {
+			if err := addApplication(source, application); err != nil {
+				return nil, err
+			}
+		}
+
+		for _, forward := range project.Forwards {
+			if err := addForward(source, forward); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return combined, nil
+}
+
+// Filter keeps the applications and forwards of the project having one of the given names (all of them when
+// no name is given) and removes the excluded ones. An error is returned when a name is unknown or when a kept
+// application depends on a removed one.
+func (p *Project) Filter(only []string, except []string) error {
+	for _, name := range append(append([]string{}, only...), except...) {
+		if p.findApplication(name) == nil && p.findForward(name) == nil {
+			return fmt.Errorf("'%s' is not an application or forward of project '%s'", name, p.Name)
+		}
+	}
+
+	kept := func(name string) bool {
+		for _, excluded := range except {
+			if excluded == name {
+				return false
+			}
+		}
+
+		if len(only) == 0 {
+			return true
+		}
+
+		for _, included := range only {
+			if included == name {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	applications := make([]*Application, 0, len(p.Applications))
+	for _, application := range p.Applications {
+		if kept(application.Name) {
+			applications = append(applications, application)
+		}
+	}
+
+	forwards := make([]*Forward, 0, len(p.Forwards))
+	for _, forward := range p.Forwards {
+		if kept(forward.Name) {
+			forwards = append(forwards, forward)
+		}
+	}
+
+	for _, application := range applications {
+		for _, name := range application.DependsOn {
+			if !kept(name) {
+				return fmt.Errorf("application '%s' depends on '%s' which is not selected", application.Name, name)
+			}
+		}
+	}
+
+	p.Applications = applications
+	p.Forwards = forwards
+
+	return nil
+}
@@ -0,0 +1,186 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCombineProjects(t *testing.T) {
+	// Given
+	conf := &Config{
+		Applications: []*Application{{Name: "mailcatcher", Run: &Run{Command: "./mailcatcher"}}},
+		Projects: []*Project{
+			{
+				Name:         "graphql",
+				Applications: []*Application{{Name: "graphql", Hostname: "graphql.svc.local", Run: &Run{Command: "./graphql"}}},
+				Forwards:     []*Forward{{Name: "user-api", Type: ForwarderKubernetes, Values: ForwardValues{Hostname: "user-api.svc.local"}}},
+			},
+			{
+				Name: "forward-only",
+				Forwards: []*Forward{
+					{Name: "user-api", Type: ForwarderKubernetes, Values: ForwardValues{Hostname: "user-api.svc.local"}},
+					{Name: "order-api", Type: ForwarderKubernetes, Values: ForwardValues{Hostname: "order-api.svc.local"}},
+				},
+			},
+		},
+	}
+
+	// When
+	project, err := conf.CombineProjects([]string{"graphql", "forward-only"})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "graphql+forward-only", project.Name)
+
+	assert.Len(t, project.Applications, 2)
+	assert.Equal(t, "mailcatcher", project.Applications[0].Name)
+	assert.Equal(t, "graphql", project.Applications[1].Name)
+
+	// The forward declared identically by both projects is only kept once
+	assert.Len(t, project.Forwards, 2)
+	assert.Equal(t, "user-api", project.Forwards[0].Name)
+	assert.Equal(t, "order-api", project.Forwards[1].Name)
+}
+
+func TestCombineProjectsWhenSingleProject(t *testing.T) {
+	// Given
+	conf := &Config{
+		Forwards: []*Forward{{Name: "user-api", Type: ForwarderKubernetes}},
+		Projects: []*Project{
+			{Name: "graphql", Applications: []*Application{{Name: "graphql", Run: &Run{Command: "./graphql"}}}},
+		},
+	}
+
+	// When
+	project, err := conf.CombineProjects([]string{"graphql"})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, &Project{
+		Name:         "graphql",
+		Applications: []*Application{{Name: "graphql", Run: &Run{Command: "./graphql"}}},
+		Forwards:     []*Forward{{Name: "user-api", Type: ForwarderKubernetes}},
+	}, project)
+}
+
+func TestCombineProjectsWhenNameCollides(t *testing.T) {
+	// Given
+	conf := &Config{
+		Projects: []*Project{
+			{Name: "graphql", Applications: []*Application{{Name: "graphql", Run: &Run{Command: "./graphql"}}}},
+			{Name: "graphql-debug", Applications: []*Application{{Name: "graphql", Run: &Run{Command: "./graphql --debug"}}}},
+		},
+	}
+
+	// When
+	project, err := conf.CombineProjects([]string{"graphql", "graphql-debug"})
+
+	// Then
+	assert.Nil(t, project)
+	assert.EqualError(t, err, "application 'graphql' of project 'graphql-debug' differs from the one of project 'graphql'")
+}
+
+func TestCombineProjectsWhenHostnameCollides(t *testing.T) {
+	// Given
+	conf := &Config{
+		Projects: []*Project{
+			{Name: "graphql", Applications: []*Application{{Name: "graphql", Hostname: "graphql.svc.local"}}},
+			{Name: "forward-only", Forwards: []*Forward{{Name: "graphql-remote", Values: ForwardValues{Hostname: "graphql.svc.local"}}}},
+		},
+	}
+
+	// When
+	project, err := conf.CombineProjects([]string{"graphql", "forward-only"})
+
+	// Then
+	assert.Nil(t, project)
+	assert.EqualError(t, err, "hostname 'graphql.svc.local' of forward 'graphql-remote' of project 'forward-only' is already used by application 'graphql' of project 'graphql'")
+}
+
+func TestCombineProjectsWhenProjectNotFound(t *testing.T) {
+	// Given
+	conf := &Config{
+		Projects: []*Project{{Name: "graphql"}},
+	}
+
+	// When
+	project, err := conf.CombineProjects([]string{"graphql", "unknown"})
+
+	// Then
+	assert.Nil(t, project)
+	assert.EqualError(t, err, "Unable to find project name 'unknown' in the configuration")
+}
+
+func TestFilter(t *testing.T) {
+	testCases := []struct {
+		name         string
+		only         []string
+		except       []string
+		applications []string
+		forwards     []string
+	}{
+		{name: "no filter", applications: []string{"graphql", "worker"}, forwards: []string{"user-api", "order-api"}},
+		{name: "only", only: []string{"graphql", "user-api"}, applications: []string{"graphql"}, forwards: []string{"user-api"}},
+		{name: "except", except: []string{"worker"}, applications: []string{"graphql"}, forwards: []string{"user-api", "order-api"}},
+		{name: "only and except", only: []string{"graphql", "user-api"}, except: []string{"user-api"}, applications: []string{"graphql"}, forwards: []string{}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Given
+			project := &Project{
+				Name:         "graphql",
+				Applications: []*Application{{Name: "graphql"}, {Name: "worker", DependsOn: []string{"graphql"}}},
+				Forwards:     []*Forward{{Name: "user-api"}, {Name: "order-api"}},
+			}
+
+			// When
+			err := project.Filter(testCase.only, testCase.except)
+
+			// Then
+			assert.Nil(t, err)
+
+			applications := make([]string, 0)
+			for _, application := range project.Applications {
+				applications = append(applications, application.Name)
+			}
+
+			forwards := make([]string, 0)
+			for _, forward := range project.Forwards {
+				forwards = append(forwards, forward.Name)
+			}
+
+			assert.Equal(t, testCase.applications, applications)
+			assert.Equal(t, testCase.forwards, forwards)
+		})
+	}
+}
+
+func TestFilterWhenNameIsUnknown(t *testing.T) {
+	// Given
+	project := &Project{Name: "graphql", Applications: []*Application{{Name: "graphql"}}}
+
+	// When
+	err := project.Filter(nil, []string{"unknown"})
+
+	// Then
+	assert.EqualError(t, err, "'unknown' is not an application or forward of project 'graphql'")
+	assert.Len(t, project.Applications, 1)
+}
+
+func TestFilterWhenDependencyIsRemoved(t *testing.T) {
+	// Given
+	project := &Project{
+		Name:         "graphql",
+		Applications: []*Application{{Name: "graphql", DependsOn: []string{"user-api"}}},
+		Forwards:     []*Forward{{Name: "user-api"}},
+	}
+
+	// When
+	err := project.Filter(nil, []string{"user-api"})
+
+	// Then
+	assert.EqualError(t, err, "application 'graphql' depends on 'user-api' which is not selected")
+	assert.Len(t, project.Forwards, 1)
+}
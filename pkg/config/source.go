@@ -0,0 +1,151 @@
+package config
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Source represents the location of a configuration item in its originating file
+type Source struct {
+	File string
+	Line int
+}
+
+// String returns the "file:line" representation of the source
+func (s Source) String() string {
+	if s.File == "" {
+		return ""
+	}
+
+	if s.Line == 0 {
+		return s.File
+	}
+
+	return fmt.Sprintf("%s:%d", s.File, s.Line)
+}
+
+// locate registers the originating file and line of every project, application,
+// forward and file of the configuration, using the YAML node they were decoded from
+func (c *Config) locate(root *yaml.Node, origins map[*yaml.Node]string) {
+	c.sources = make(map[interface{}]Source)
+
+	definitions := mappingValue(root, "applications")
+	for name, application := range c.ApplicationDefinitions {
+		if node := mappingValue(definitions, name); node != nil && application != nil {
+			c.locateApplication(application, node, origins)
+		}
+	}
+
+	definitions = mappingValue(root, "forwards")
+	for name, forward := range c.ForwardDefinitions {
+		if node := mappingValue(definitions, name); node != nil && forward != nil {
+			c.sources[forward] = nodeSource(node, origins)
+		}
+	}
+
+	c.locateApplications(c.Applications, mappingValue(root, "local"), origins)
+	c.locateForwards(c.Forwards, mappingValue(root, "forward"), origins)
+
+	nodes := sequenceItems(mappingValue(root, "projects"))
+	for i, project := range c.Projects {
+		if i >= len(nodes) {
+			break
+		}
+
+		c.sources[project] = nodeSource(nodes[i], origins)
+		c.locateApplications(project.Applications, mappingValue(nodes[i], "local"), origins)
+		c.locateForwards(project.Forwards, mappingValue(nodes[i], "forward"), origins)
+	}
+}
+
+func (c *Config) locateApplications(applications []*Application, node *yaml.Node, origins map[*yaml.Node]string) {
+	nodes := sequenceItems(node)
+
+	for i, application := range applications {
+		if i >= len(nodes) || application == nil {
+			break
+		}
+
+		c.locateApplication(application, nodes[i], origins)
+	}
+}
+
+func (c *Config) locateApplication(application *Application, node *yaml.Node, origins map[*yaml.Node]string) {
+	c.sources[application] = nodeSource(node, origins)
+
+	fileNodes := sequenceItems(mappingValue(node, "files"))
+	for i, file := range application.Files {
+		if i >= len(fileNodes) {
+			break
+		}
+
+		c.sources[file] = nodeSource(fileNodes[i], origins)
+	}
+}
+
+func (c *Config) locateForwards(forwards []*Forward, node *yaml.Node, origins map[*yaml.Node]string) {
+	nodes := sequenceItems(node)
+
+	for i, forward := range forwards {
+		if i >= len(nodes) || forward == nil {
+			break
+		}
+
+		c.sources[forward] = nodeSource(nodes[i], origins)
+	}
+}
+
+// nodeSource returns the originating file and line of a node
+func nodeSource(node *yaml.Node, origins map[*yaml.Node]string) Source {
+	return Source{File: origins[node], Line: node.Line}
+}
+
+// sourceOf returns the originating file and line of a configuration item, if known
+func (c *Config) sourceOf(item interface{}) Source {
+	if c.sources == nil {
+		return Source{}
+	}
+
+	return c.sources[item]
+}
+
+// resolveAlias returns the node targeted by an alias or the node itself
+func resolveAlias(node *yaml.Node) *yaml.Node {
+	for node != nil && node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+
+	return node
+}
+
+// mappingValue returns the value node of a given key in a mapping node
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	node = resolveAlias(node)
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return resolveAlias(node.Content[i+1])
+		}
+	}
+
+	return nil
+}
+
+// sequenceItems returns the items of a sequence node, aliases being resolved
+func sequenceItems(node *yaml.Node) []*yaml.Node {
+	node = resolveAlias(node)
+	if node == nil || node.Kind != yaml.SequenceNode {
+		return nil
+	}
+
+	items := make([]*yaml.Node, 0, len(node.Content))
+	for _, item := range node.Content {
+		items = append(items, resolveAlias(item))
+	}
+
+	return items
+}
@@ -0,0 +1,526 @@
+package config
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ValidationError represents a single problem found in the configuration
+type ValidationError struct {
+	Source  Source
+	Message string
+}
+
+// Error returns the problem message prefixed by its location, if known
+func (e *ValidationError) Error() string {
+	if source := e.Source.String(); source != "" {
+		return fmt.Sprintf("%s: %s", source, e.Message)
+	}
+
+	return e.Message
+}
+
+// ValidationErrors represents all the problems found in the configuration
+type ValidationErrors []*ValidationError
+
+// Error returns all the problems, one per line
+func (e ValidationErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+
+	return strings.Join(messages, "\n")
+}
+
+// validator collects the problems found in the configuration, ignoring the
+// duplicates that come from items shared between projects using anchors
+type validator struct {
+	conf   *Config
+	errors ValidationErrors
+	seen   map[string]bool
+}
+
+// Validate checks every project, application, forward and file of the configuration
+// and returns a ValidationErrors containing all the problems found
+func (c *Config) Validate() error {
+	v := &validator{
+		conf:   c,
+		errors: make(ValidationErrors, 0),
+		seen:   make(map[string]bool),
+	}
+
+	for _, name := range sortedKeys(c.ApplicationDefinitions) {
+		v.validateApplication(c.ApplicationDefinitions[name])
+	}
+
+	for _, name := range sortedKeys(c.ForwardDefinitions) {
+		v.validateForward(c.ForwardDefinitions[name])
+	}
+
+	for _, application := range c.Applications {
+		v.validateApplicationItem("global 'local' list", application)
+	}
+
+	for _, forward := range c.Forwards {
+		v.validateForwardItem("global 'forward' list", forward)
+	}
+
+	if c.Logs != nil {
+		v.validateLogs(c.Logs)
+	}
+
+	if c.Watch != nil {
+		v.validateWatch(c.Watch)
+	}
+
+	projectNames := make(map[string]bool)
+
+	for _, project := range c.Projects {
+		if project == nil {
+			continue
+		}
+
+		if project.Name != "" && projectNames[project.Name] {
+			v.addf(project, "project '%s' is declared multiple times", project.Name)
+		}
+		projectNames[project.Name] = true
+
+		v.validateProject(project)
+	}
+
+	if len(v.errors) == 0 {
+		return nil
+	}
+
+	return v.errors
+}
+
+func (v *validator) addf(item interface{}, format string, args ...interface{}) {
+	err := &ValidationError{
+		Source:  v.conf.sourceOf(item),
+		Message: fmt.Sprintf(format, args...),
+	}
+
+	if key := err.Error(); !v.seen[key] {
+		v.seen[key] = true
+		v.errors = append(v.errors, err)
+	}
+}
+
+func (v *validator) validateLogs(logs *GlobalLogs) {
+	if logs.MaxSize != "" {
+		if size, err := parseSize(logs.MaxSize); err != nil || size <= 0 {
+			v.addf(logs, "logs configuration has an invalid 'max_size' '%s' (e.g. 10MB)", logs.MaxSize)
+		}
+	}
+
+	if logs.MaxFiles < 0 {
+		v.addf(logs, "logs configuration has a negative 'max_files' %d", logs.MaxFiles)
+	}
+}
+
+func (v *validator) validateProject(project *Project) {
+	if project.Name == "" {
+		v.addf(project, "project is missing a 'name'")
+	}
+
+	applications := append(append([]*Application{}, v.conf.Applications...), project.Applications...)
+	forwards := append(append([]*Forward{}, v.conf.Forwards...), project.Forwards...)
+
+	if len(applications) == 0 && len(forwards) == 0 && len(project.Extends) == 0 {
+		v.addf(project, "project '%s' does not reference any local application or forward", project.Name)
+	}
+
+	// Unknown references are reported on the items themselves
+	if len(project.Extends) > 0 || project.Overrides != nil {
+		if _, err := v.conf.resolveProject(project, nil); err != nil {
+			v.addf(project, "%v", err)
+		}
+	}
+
+	if project.Overrides != nil {
+		for _, name := range sortedKeys(project.Overrides.Applications) {
+			if override := project.Overrides.Applications[name]; override != nil {
+				v.validateWatchOptions(project, fmt.Sprintf("override of application '%s' in project '%s'", name, project.Name), override.WatchOptions)
+			}
+		}
+	}
+
+	applicationNames := make(map[string]bool)
+	forwardNames := make(map[string]bool)
+	hostnames := make(map[string]bool)
+
+	for _, application := range project.Applications {
+		v.validateApplicationItem(fmt.Sprintf("project '%s'", project.Name), application)
+	}
+
+	for _, application := range applications {
+		if application = v.conf.dereferenceApplication(application); application == nil {
+			continue
+		}
+
+		if application.Name != "" && applicationNames[application.Name] {
+			v.addf(application, "application '%s' is declared multiple times in project '%s'", application.Name, project.Name)
+		}
+		applicationNames[application.Name] = true
+
+		if application.Hostname != "" && hostnames[application.Hostname] {
+			v.addf(application, "hostname '%s' of application '%s' is already used in project '%s'", application.Hostname, application.Name, project.Name)
+		}
+		hostnames[application.Hostname] = true
+	}
+
+	for _, forward := range project.Forwards {
+		v.validateForwardItem(fmt.Sprintf("project '%s'", project.Name), forward)
+	}
+
+	for _, forward := range forwards {
+		if forward = v.conf.dereferenceForward(forward); forward == nil {
+			continue
+		}
+
+		if forward.Name != "" && forwardNames[forward.Name] {
+			v.addf(forward, "forward '%s' is declared multiple times in project '%s'", forward.Name, project.Name)
+		}
+		forwardNames[forward.Name] = true
+
+		if hostname := forward.Values.Hostname; hostname != "" && hostnames[hostname] {
+			v.addf(forward, "hostname '%s' of forward '%s' is already used in project '%s'", hostname, forward.Name, project.Name)
+		}
+		hostnames[forward.Values.Hostname] = true
+	}
+
+	v.validateDependencies(project)
+}
+
+// validateDependencies checks that the applications of a project only depend on
+// applications or forwards of this project, without any cycle
+func (v *validator) validateDependencies(project *Project) {
+	resolved, err := v.conf.resolveProject(project, nil)
+	if err != nil {
+		// Unknown references are reported on the items themselves
+		return
+	}
+
+	globals := make([]*Application, 0)
+	for _, application := range v.conf.Applications {
+		if application = v.conf.dereferenceApplication(application); application != nil {
+			globals = append(globals, application)
+		}
+	}
+
+	applications := append(append([]*Application{}, globals...), resolved.Applications...)
+
+	names := make(map[string]bool)
+	for _, application := range applications {
+		names[application.Name] = true
+	}
+
+	for _, forward := range append(append([]*Forward{}, v.conf.Forwards...), resolved.Forwards...) {
+		if forward = v.conf.dereferenceForward(forward); forward != nil {
+			names[forward.Name] = true
+		}
+	}
+
+	// Dependencies are checked on the declared applications so problems are reported with their source
+	for _, application := range append(globals, project.Applications...) {
+		if application = v.conf.dereferenceApplication(application); application == nil {
+			continue
+		}
+
+		for _, name := range application.DependsOn {
+			if !names[name] {
+				v.addf(application, "application '%s' depends on '%s' which is not an application or forward of project '%s'", application.Name, name, project.Name)
+			}
+		}
+	}
+
+	if _, err := SortByDependencies(applications); err != nil {
+		v.addf(project, "project '%s' has a %v", project.Name, err)
+	}
+}
+
+// validateApplicationItem validates an application of a list, which can be a reference to a definition
+func (v *validator) validateApplicationItem(owner string, application *Application) {
+	if application == nil || application.reference == "" {
+		v.validateApplication(application)
+		return
+	}
+
+	if v.conf.dereferenceApplication(application) == nil {
+		v.addf(application, "%s references an unknown application '%s'", owner, application.reference)
+	}
+}
+
+// validateForwardItem validates a forward of a list, which can be a reference to a definition
+func (v *validator) validateForwardItem(owner string, forward *Forward) {
+	if forward == nil || forward.reference == "" {
+		v.validateForward(forward)
+		return
+	}
+
+	if v.conf.dereferenceForward(forward) == nil {
+		v.addf(forward, "%s references an unknown forward '%s'", owner, forward.reference)
+	}
+}
+
+func (v *validator) validateApplication(application *Application) {
+	if application == nil {
+		return
+	}
+
+	if application.Name == "" {
+		v.addf(application, "application is missing a 'name'")
+	}
+
+	if application.Path == "" {
+		v.addf(application, "application '%s' is missing a 'path'", application.Name)
+	}
+
+	if application.Run == nil {
+		v.addf(application, "application '%s' is missing a 'run' section", application.Name)
+	} else {
+		v.validateRun(application)
+	}
+
+	if build := application.Build; build != nil {
+		if build.Type != "" && !AvailableBuilders[build.Type] {
+			v.addf(application, "application '%s' has an unknown build type '%s'", application.Name, build.Type)
+		}
+
+		if len(build.Commands) == 0 {
+			v.addf(application, "application '%s' has a 'build' section without any command", application.Name)
+		}
+	}
+
+	for _, file := range application.Files {
+		v.validateFile(application, file)
+	}
+
+	v.validateHealth(application)
+	v.validateWatchOptions(application, fmt.Sprintf("application '%s'", application.Name), application.WatchOptions)
+}
+
+func (v *validator) validateWatch(watch *GlobalWatch) {
+	if watch.Debounce != "" {
+		if duration, err := time.ParseDuration(watch.Debounce); err != nil || duration <= 0 {
+			v.addf(watch, "watch configuration has an invalid 'debounce' '%s' (e.g. 500ms)", watch.Debounce)
+		}
+	}
+
+	for _, pattern := range watch.Exclude {
+		if !isValidPattern(pattern) {
+			v.addf(watch, "watch configuration has an invalid 'exclude' pattern '%s'", pattern)
+		}
+	}
+}
+
+func (v *validator) validateWatchOptions(item interface{}, owner string, options *WatchOptions) {
+	if options == nil {
+		return
+	}
+
+	for _, pattern := range options.Include {
+		if !isValidPattern(pattern) {
+			v.addf(item, "%s has an invalid 'watch.include' pattern '%s'", owner, pattern)
+		}
+	}
+
+	for _, pattern := range options.Exclude {
+		if !isValidPattern(pattern) {
+			v.addf(item, "%s has an invalid 'watch.exclude' pattern '%s'", owner, pattern)
+		}
+	}
+
+	for _, extra := range options.Paths {
+		if extra == "" {
+			v.addf(item, "%s has an empty 'watch.paths' item", owner)
+		}
+	}
+}
+
+func (v *validator) validateRun(application *Application) {
+	run := application.Run
+
+	if run.Command == "" {
+		v.addf(application, "application '%s' is missing a 'run.command'", application.Name)
+	}
+
+	if run.Restart != "" && !AvailableRestartPolicies[run.Restart] {
+		v.addf(application, "application '%s' has an unknown restart policy '%s', expected never, on-failure or always", application.Name, run.Restart)
+	}
+
+	if run.MaxRestarts < 0 {
+		v.addf(application, "application '%s' has a negative 'run.max_restarts'", application.Name)
+	}
+
+	if run.ResetAfter != "" {
+		if duration, err := time.ParseDuration(run.ResetAfter); err != nil || duration <= 0 {
+			v.addf(application, "application '%s' has an invalid 'run.restart_reset_after' duration '%s'", application.Name, run.ResetAfter)
+		}
+	}
+
+	if run.StopSignal != "" {
+		if _, ok := AvailableStopSignals[normalizeSignal(run.StopSignal)]; !ok {
+			v.addf(application, "application '%s' has an unknown 'run.stop_signal' '%s'", application.Name, run.StopSignal)
+		}
+	}
+
+	if run.StopTimeout != "" {
+		if duration, err := time.ParseDuration(run.StopTimeout); err != nil || duration <= 0 {
+			v.addf(application, "application '%s' has an invalid 'run.stop_timeout' duration '%s'", application.Name, run.StopTimeout)
+		}
+	}
+}
+
+func (v *validator) validateHealth(application *Application) {
+	health := application.Health
+	if health == nil {
+		return
+	}
+
+	checks := 0
+	for _, defined := range []bool{health.HTTP != nil, health.TCP != nil, health.Exec != nil, health.Log != nil} {
+		if defined {
+			checks++
+		}
+	}
+
+	if checks != 1 {
+		v.addf(application, "application '%s' health must define exactly one of 'http', 'tcp', 'exec' or 'log' checks", application.Name)
+	}
+
+	if health.HTTP != nil && health.HTTP.URL == "" {
+		v.addf(application, "application '%s' has a 'health.http' check without any 'url'", application.Name)
+	}
+
+	if health.TCP != nil {
+		if port, err := strconv.Atoi(health.TCP.Port); err != nil || port < 1 || port > 65535 {
+			v.addf(application, "application '%s' has a 'health.tcp' check with an invalid port '%s'", application.Name, health.TCP.Port)
+		}
+	}
+
+	if health.Exec != nil && health.Exec.Command == "" {
+		v.addf(application, "application '%s' has a 'health.exec' check without any 'command'", application.Name)
+	}
+
+	if health.Log != nil {
+		if _, err := regexp.Compile(health.Log.Pattern); err != nil || health.Log.Pattern == "" {
+			v.addf(application, "application '%s' has a 'health.log' check with an invalid 'pattern' '%s'", application.Name, health.Log.Pattern)
+		}
+	}
+
+	durations := []struct {
+		key   string
+		value string
+	}{
+		{key: "interval", value: health.Interval},
+		{key: "timeout", value: health.Timeout},
+	}
+
+	for _, duration := range durations {
+		if duration.value == "" {
+			continue
+		}
+
+		if value, err := time.ParseDuration(duration.value); err != nil || value <= 0 {
+			v.addf(application, "application '%s' has an invalid 'health.%s' duration '%s'", application.Name, duration.key, duration.value)
+		}
+	}
+
+	if health.Threshold < 0 {
+		v.addf(application, "application '%s' has a negative 'health.threshold'", application.Name)
+	}
+}
+
+func (v *validator) validateFile(application *Application, file *File) {
+	if file == nil {
+		return
+	}
+
+	if !AvailableFileTypes[file.Type] {
+		v.addf(file, "file of application '%s' has an unknown type '%s'", application.Name, file.Type)
+		return
+	}
+
+	if file.To == "" {
+		v.addf(file, "%s file of application '%s' is missing a 'to' path", file.Type, application.Name)
+	}
+
+	if file.Type == FileCopy && file.From == "" {
+		v.addf(file, "copy file of application '%s' is missing a 'from' path", application.Name)
+	}
+}
+
+func (v *validator) validateForward(forward *Forward) {
+	if forward == nil {
+		return
+	}
+
+	if forward.Name == "" {
+		v.addf(forward, "forward is missing a 'name'")
+	}
+
+	if !AvailableForwarders[forward.Type] {
+		v.addf(forward, "forward '%s' has an unknown type '%s'", forward.Name, forward.Type)
+		return
+	}
+
+	values := forward.Values
+
+	if len(values.Ports) == 0 {
+		v.addf(forward, "forward '%s' does not have any port to forward", forward.Name)
+	}
+
+	for _, ports := range values.Ports {
+		if err := validatePorts(ports); err != nil {
+			v.addf(forward, "forward '%s' has an invalid port '%s': %v", forward.Name, ports, err)
+		}
+	}
+
+	switch forward.Type {
+	case ForwarderKubernetes, ForwarderKubernetesRemote:
+		if len(values.Labels) == 0 {
+			v.addf(forward, "forward '%s' of type %s is missing some 'labels' to select pods", forward.Name, forward.Type)
+		}
+
+	case ForwarderSSH, ForwarderSSHRemote:
+		if values.Remote == "" {
+			v.addf(forward, "forward '%s' of type %s is missing a 'remote' host", forward.Name, forward.Type)
+		}
+
+	case ForwarderProxy:
+		if values.ProxyHostname == "" {
+			v.addf(forward, "forward '%s' of type %s is missing a 'proxy_hostname'", forward.Name, forward.Type)
+		}
+	}
+}
+
+// validatePorts checks that the given ports respect the "<local port>:<forwarded port>" syntax
+func validatePorts(ports string) error {
+	parts := strings.Split(ports, ":")
+	if len(parts) != 2 {
+		return fmt.Errorf("expected '<local port>:<forwarded port>'")
+	}
+
+	for _, part := range parts {
+		port, err := strconv.Atoi(part)
+		if err != nil || port < 1 || port > 65535 {
+			return fmt.Errorf("'%s' is not a valid port number", part)
+		}
+	}
+
+	return nil
+}
+
+// isValidPattern indicates if the given file pattern (using the path.Match syntax, '**' matching any directories)
+// is well-formed
+func isValidPattern(pattern string) bool {
+	_, err := path.Match(strings.Trim(pattern, "/"), "")
+	return pattern != "" && err == nil
+}
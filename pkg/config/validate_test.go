@@ -0,0 +1,312 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	// Given
+	testCases := []struct {
+		name     string
+		conf     *Config
+		expected []string
+	}{
+		{
+			name: "valid configuration",
+			conf: &Config{
+				Projects: []*Project{
+					{
+						Name: "graphql",
+						Applications: []*Application{
+							{Name: "graphql", Path: "/", Hostname: "graphql.svc.local", Run: &Run{Command: "go run main.go"}},
+						},
+						Forwards: []*Forward{
+							{Name: "user-api", Type: ForwarderSSH, Values: ForwardValues{Remote: "root@acme.tld", Ports: []string{"8080:80"}}},
+						},
+					},
+				},
+			},
+			expected: nil,
+		},
+		{
+			name: "missing run section",
+			conf: &Config{
+				Projects: []*Project{
+					{Name: "graphql", Applications: []*Application{{Name: "graphql", Path: "/"}}},
+				},
+			},
+			expected: []string{"application 'graphql' is missing a 'run' section"},
+		},
+		{
+			name: "unknown forward type",
+			conf: &Config{
+				Forwards: []*Forward{{Name: "user-api", Type: "unknown"}},
+				Projects: []*Project{{Name: "graphql"}},
+			},
+			expected: []string{"forward 'user-api' has an unknown type 'unknown'"},
+		},
+		{
+			name: "invalid ports and missing remote",
+			conf: &Config{
+				Projects: []*Project{
+					{
+						Name: "graphql",
+						Forwards: []*Forward{
+							{Name: "user-api", Type: ForwarderSSHRemote, Values: ForwardValues{Ports: []string{"8080:http"}}},
+						},
+					},
+				},
+			},
+			expected: []string{
+				"forward 'user-api' has an invalid port '8080:http': 'http' is not a valid port number",
+				"forward 'user-api' of type ssh-remote is missing a 'remote' host",
+			},
+		},
+		{
+			name: "duplicate names and hostnames",
+			conf: &Config{
+				Projects: []*Project{
+					{
+						Name: "graphql",
+						Applications: []*Application{
+							{Name: "graphql", Path: "/", Hostname: "graphql.svc.local", Run: &Run{Command: "./graphql"}},
+							{Name: "graphql", Path: "/", Hostname: "graphql.svc.local", Run: &Run{Command: "./graphql"}},
+						},
+					},
+					{Name: "graphql", Applications: []*Application{{Name: "graphql", Path: "/", Run: &Run{Command: "./graphql"}}}},
+				},
+			},
+			expected: []string{
+				"application 'graphql' is declared multiple times in project 'graphql'",
+				"hostname 'graphql.svc.local' of application 'graphql' is already used in project 'graphql'",
+				"project 'graphql' is declared multiple times",
+			},
+		},
+		{
+			name: "unknown dependency and dependency cycle",
+			conf: &Config{
+				Projects: []*Project{
+					{
+						Name: "graphql",
+						Applications: []*Application{
+							{Name: "graphql", Path: "/", Run: &Run{Command: "./graphql"}, DependsOn: []string{"user-api", "postgres"}},
+							{Name: "user-api", Path: "/", Run: &Run{Command: "./user-api"}, DependsOn: []string{"auth-api"}},
+							{Name: "auth-api", Path: "/", Run: &Run{Command: "./auth-api"}, DependsOn: []string{"user-api", "redis"}},
+						},
+						Forwards: []*Forward{
+							{Name: "redis", Type: ForwarderSSH, Values: ForwardValues{Remote: "root@acme.tld", Ports: []string{"6379:6379"}}},
+						},
+					},
+				},
+			},
+			expected: []string{
+				"application 'graphql' depends on 'postgres' which is not an application or forward of project 'graphql'",
+				"project 'graphql' has a dependency cycle: user-api -> auth-api -> user-api",
+			},
+		},
+		{
+			name: "invalid health checks",
+			conf: &Config{
+				Projects: []*Project{
+					{
+						Name: "graphql",
+						Applications: []*Application{
+							{Name: "graphql", Path: "/", Run: &Run{Command: "./graphql"}, Health: &Health{
+								HTTP:     &HTTPHealth{},
+								TCP:      &TCPHealth{Port: "http"},
+								Interval: "often",
+							}},
+							{Name: "user-api", Path: "/", Run: &Run{Command: "./user-api"}, Health: &Health{
+								Log:       &LogHealth{Pattern: "listening on ("},
+								Threshold: -1,
+							}},
+						},
+					},
+				},
+			},
+			expected: []string{
+				"application 'graphql' health must define exactly one of 'http', 'tcp', 'exec' or 'log' checks",
+				"application 'graphql' has a 'health.http' check without any 'url'",
+				"application 'graphql' has a 'health.tcp' check with an invalid port 'http'",
+				"application 'graphql' has an invalid 'health.interval' duration 'often'",
+				"application 'user-api' has a 'health.log' check with an invalid 'pattern' 'listening on ('",
+				"application 'user-api' has a negative 'health.threshold'",
+			},
+		},
+		{
+			name: "invalid restart policies",
+			conf: &Config{
+				Projects: []*Project{
+					{
+						Name: "graphql",
+						Applications: []*Application{
+							{Name: "graphql", Path: "/", Run: &Run{Command: "./graphql", Restart: "sometimes", MaxRestarts: -1}},
+							{Name: "user-api", Path: "/", Run: &Run{Command: "./user-api", Restart: RestartOnFailure, ResetAfter: "a while"}},
+						},
+					},
+				},
+			},
+			expected: []string{
+				"application 'graphql' has an unknown restart policy 'sometimes', expected never, on-failure or always",
+				"application 'graphql' has a negative 'run.max_restarts'",
+				"application 'user-api' has an invalid 'run.restart_reset_after' duration 'a while'",
+			},
+		},
+		{
+			name: "invalid stop options",
+			conf: &Config{
+				Projects: []*Project{
+					{
+						Name: "graphql",
+						Applications: []*Application{
+							{Name: "graphql", Path: "/", Run: &Run{Command: "./graphql", StopSignal: "SIGSTOP", StopTimeout: "-5s"}},
+							{Name: "user-api", Path: "/", Run: &Run{Command: "./user-api", StopSignal: "int", StopTimeout: "5s"}},
+						},
+					},
+				},
+			},
+			expected: []string{
+				"application 'graphql' has an unknown 'run.stop_signal' 'SIGSTOP'",
+				"application 'graphql' has an invalid 'run.stop_timeout' duration '-5s'",
+			},
+		},
+		{
+			name: "invalid logs options",
+			conf: &Config{
+				Logs: &GlobalLogs{MaxSize: "10 parsecs", MaxFiles: -1},
+				Projects: []*Project{
+					{
+						Name:         "graphql",
+						Applications: []*Application{{Name: "graphql", Path: "/", Run: &Run{Command: "./graphql"}}},
+					},
+				},
+			},
+			expected: []string{
+				"logs configuration has an invalid 'max_size' '10 parsecs' (e.g. 10MB)",
+				"logs configuration has a negative 'max_files' -1",
+			},
+		},
+		{
+			name: "invalid watch configuration",
+			conf: &Config{
+				Watch: &GlobalWatch{Debounce: "soon", Exclude: []string{"[a-"}},
+				Projects: []*Project{
+					{
+						Name: "graphql",
+						Applications: []*Application{
+							{
+								Name:         "graphql",
+								Path:         "/",
+								Run:          &Run{Command: "./graphql"},
+								Watch:        true,
+								WatchOptions: &WatchOptions{Include: []string{"**/*.go", "[*.mod"}, Exclude: []string{""}, Paths: []string{""}},
+							},
+						},
+					},
+				},
+			},
+			expected: []string{
+				"watch configuration has an invalid 'debounce' 'soon' (e.g. 500ms)",
+				"watch configuration has an invalid 'exclude' pattern '[a-'",
+				"application 'graphql' has an invalid 'watch.include' pattern '[*.mod'",
+				"application 'graphql' has an invalid 'watch.exclude' pattern ''",
+				"application 'graphql' has an empty 'watch.paths' item",
+			},
+		},
+		{
+			name: "invalid watch override",
+			conf: &Config{
+				Projects: []*Project{
+					{
+						Name: "graphql",
+						Applications: []*Application{
+							{Name: "graphql", Path: "/", Run: &Run{Command: "./graphql"}},
+						},
+					},
+					{
+						Name:    "graphql-debug",
+						Extends: []string{"graphql"},
+						Overrides: &Overrides{
+							Applications: map[string]*ApplicationOverride{
+								"graphql": {WatchOptions: &WatchOptions{Exclude: []string{"[a-"}}},
+							},
+						},
+					},
+				},
+			},
+			expected: []string{
+				"override of application 'graphql' in project 'graphql-debug' has an invalid 'watch.exclude' pattern '[a-'",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// When
+			err := testCase.conf.Validate()
+
+			// Then
+			if testCase.expected == nil {
+				assert.Nil(t, err)
+				return
+			}
+
+			messages := make([]string, 0)
+			for _, validationError := range err.(ValidationErrors) {
+				messages = append(messages, validationError.Message)
+			}
+
+			assert.Equal(t, testCase.expected, messages)
+		})
+	}
+}
+
+func TestLoadWhenInvalidConfiguration(t *testing.T) {
+	// Given
+	dir, _ := os.Getwd()
+	Filepath = dir + "/../../internal/test/config/invalid/unknown.yaml"
+	MultipleFilepath = dir + "/../../internal/test/config/invalid/monday.*.yaml"
+
+	appsFile := filepath.Clean(dir + "/../../internal/test/config/invalid/monday.apps.yaml")
+	projectsFile := filepath.Clean(dir + "/../../internal/test/config/invalid/monday.projects.yaml")
+
+	// When
+	conf, err := Load()
+
+	// Then
+	assert.Nil(t, conf)
+	assert.IsType(t, ValidationErrors{}, err)
+
+	assert.Equal(t, ValidationErrors{
+		{Source: Source{File: appsFile, Line: 3}, Message: "application 'graphql' is missing a 'run' section"},
+		{Source: Source{File: appsFile, Line: 8}, Message: "file of application 'graphql' has an unknown type 'unknown'"},
+		{Source: Source{File: appsFile, Line: 11}, Message: "forward 'user-api' has an invalid port '8080': expected '<local port>:<forwarded port>'"},
+		{Source: Source{File: appsFile, Line: 11}, Message: "forward 'user-api' of type kubernetes is missing some 'labels' to select pods"},
+		{Source: Source{File: appsFile, Line: 11}, Message: "hostname 'graphql.svc.local' of forward 'user-api' is already used in project 'graphql'"},
+		{Source: Source{File: projectsFile, Line: 10}, Message: "project 'empty' does not reference any local application or forward"},
+	}, err)
+}
+
+func TestLoadWhenDecodeErrorInAnchor(t *testing.T) {
+	// Given
+	dir, _ := os.Getwd()
+	Filepath = dir + "/../../internal/test/config/decode/unknown.yaml"
+	MultipleFilepath = dir + "/../../internal/test/config/decode/monday.*.yaml"
+
+	appsFile := filepath.Clean(dir + "/../../internal/test/config/decode/monday.apps.yaml")
+	projectsFile := filepath.Clean(dir + "/../../internal/test/config/decode/monday.projects.yaml")
+
+	// When
+	conf, err := Load()
+
+	// Then
+	assert.Nil(t, conf)
+	assert.EqualError(t, err, fmt.Sprintf("An error has occured while reading configuration file '%s':\n"+
+		"yaml: unmarshal errors:\n"+
+		"  %s:8: cannot unmarshal !!str `NOTAMAP` into map[string]string", projectsFile, appsFile))
+}
@@ -0,0 +1,178 @@
+package control
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eko/monday/pkg/build"
+	"github.com/eko/monday/pkg/config"
+	"github.com/eko/monday/pkg/forward"
+	"github.com/eko/monday/pkg/helper"
+	"github.com/eko/monday/pkg/run"
+	"github.com/eko/monday/pkg/setup"
+	"github.com/eko/monday/pkg/status"
+	"github.com/eko/monday/pkg/ui"
+	"github.com/eko/monday/pkg/watch"
+)
+
+// Action is an action that can be run on a local application or a forward of the running project
+type Action string
+
+const (
+	// Local applications actions
+	ActionRestart Action = "restart"
+	ActionStop    Action = "stop"
+	ActionStart   Action = "start"
+	ActionRebuild Action = "rebuild"
+	ActionSetup   Action = "setup"
+
+	// Forwards actions
+	ActionReconnect Action = "reconnect"
+	ActionPause     Action = "pause"
+	ActionResume    Action = "resume"
+)
+
+var (
+	// ApplicationActions lists the actions available on local applications
+	ApplicationActions = []Action{ActionRestart, ActionStop, ActionStart, ActionRebuild, ActionSetup}
+
+	// ForwardActions lists the actions available on forwards
+	ForwardActions = []Action{ActionReconnect, ActionPause, ActionResume}
+
+	// runningStates lists the states of the local applications that cannot be started again
+	runningStates = map[status.State]bool{
+		status.StatePending:    true,
+		status.StateBuilding:   true,
+		status.StateRunning:    true,
+		status.StateRestarting: true,
+	}
+)
+
+// IsDestructive indicates if the action interrupts a running application or connection
+func (a Action) IsDestructive() bool {
+	switch a {
+	case ActionRestart, ActionStop, ActionRebuild, ActionReconnect, ActionPause:
+		return true
+	}
+
+	return false
+}
+
+// Controller runs actions on the local applications and forwards of the running project
+type Controller interface {
+	Application(ctx context.Context, action Action, name string) error
+	Forward(ctx context.Context, action Action, name string) error
+}
+
+type controller struct {
+	view      ui.View
+	registry  status.Registry
+	watcher   watch.Watcher
+	setuper   setup.Setuper
+	builder   build.Builder
+	runner    run.Runner
+	forwarder forward.Forwarder
+}
+
+// NewController instanciates a controller running actions with the given components, applications and
+// forwards being looked up in the project currently run by the watcher
+func NewController(
+	view ui.View,
+	registry status.Registry,
+	watcher watch.Watcher,
+	setuper setup.Setuper,
+	builder build.Builder,
+	runner run.Runner,
+	forwarder forward.Forwarder,
+) *controller {
+	return &controller{
+		view:      view,
+		registry:  registry,
+		watcher:   watcher,
+		setuper:   setuper,
+		builder:   builder,
+		runner:    runner,
+		forwarder: forwarder,
+	}
+}
+
+// Application runs the given action on a local application. Actions running the application again
+// return once it has been launched.
+func (c *controller) Application(ctx context.Context, action Action, name string) error {
+	application := c.findApplication(name)
+	if application == nil {
+		return fmt.Errorf("unknown local app '%s'", name)
+	}
+
+	switch action {
+	case ActionRestart:
+		c.view.Writef("🔁  Restarting local app '%s'...\n", name)
+		c.runner.Restart(ctx, application)
+
+	case ActionStop:
+		c.view.Writef("⏹️  Stopping local app '%s'...\n", name)
+		return c.runner.StopApplication(ctx, application)
+
+	case ActionStart:
+		if state, ok := c.registry.GetApplication(name); ok && runningStates[state.State] {
+			return fmt.Errorf("local app '%s' is already %s", name, state.State)
+		}
+
+		go c.runner.Run(ctx, application)
+
+	case ActionRebuild:
+		c.builder.Build(application)
+		c.runner.Restart(ctx, application)
+
+	case ActionSetup:
+		// Setup commands (usually cloning a repository) only run when the application directory does not exist
+		if err := helper.CheckPathExists(application.GetPath()); err == nil {
+			return fmt.Errorf("local app '%s' is already set up in '%s', remove this directory to run its setup again", name, application.GetPath())
+		}
+
+		c.setuper.Setup(application)
+
+	default:
+		return fmt.Errorf("unknown action '%s' for local app '%s'", action, name)
+	}
+
+	return nil
+}
+
+// Forward runs the given action on a forward
+func (c *controller) Forward(ctx context.Context, action Action, name string) error {
+	if c.findForward(name) == nil {
+		return fmt.Errorf("unknown forward '%s'", name)
+	}
+
+	switch action {
+	case ActionReconnect:
+		return c.forwarder.Reconnect(ctx, name)
+	case ActionPause:
+		return c.forwarder.Pause(ctx, name)
+	case ActionResume:
+		return c.forwarder.Resume(ctx, name)
+	}
+
+	return fmt.Errorf("unknown action '%s' for forward '%s'", action, name)
+}
+
+func (c *controller) findApplication(name string) *config.Application {
+	for _, application := range c.watcher.GetProject().Applications {
+		if application.Name == name {
+			return application
+		}
+	}
+
+	return nil
+}
+
+func (c *controller) findForward(name string) *config.Forward {
+	for _, forward := range c.watcher.GetProject().Forwards {
+		if forward.Name == name {
+			return forward
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package control
+
+import (
+	"context"
+	"testing"
+
+	"github.com/eko/monday/pkg/build"
+	"github.com/eko/monday/pkg/config"
+	"github.com/eko/monday/pkg/forward"
+	"github.com/eko/monday/pkg/run"
+	"github.com/eko/monday/pkg/setup"
+	"github.com/eko/monday/pkg/status"
+	"github.com/eko/monday/pkg/ui"
+	"github.com/eko/monday/pkg/watch"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestApplication(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	application := &config.Application{Name: "graphql", Path: "/"}
+
+	watcher := watch.NewMockWatcher(ctrl)
+	watcher.EXPECT().GetProject().Return(&config.Project{
+		Name:         "My project name",
+		Applications: []*config.Application{application},
+	}).AnyTimes()
+
+	view := ui.NewMockView(ctrl)
+	view.EXPECT().Writef("🔁  Restarting local app '%s'...\n", "graphql")
+	view.EXPECT().Writef("⏹️  Stopping local app '%s'...\n", "graphql")
+
+	builder := build.NewMockBuilder(ctrl)
+	builder.EXPECT().Build(application)
+
+	runner := run.NewMockRunner(ctrl)
+	runner.EXPECT().Restart(ctx, application).Times(2)
+	runner.EXPECT().StopApplication(ctx, application).Return(nil)
+
+	registry := status.NewRegistry()
+	registry.UpdateApplication("graphql", func(app *status.Application) {
+		app.State = status.StateRunning
+	})
+
+	controller := NewController(view, registry, watcher, setup.NewMockSetuper(ctrl), builder, runner, forward.NewMockForwarder(ctrl))
+
+	// When - Then
+	assert.Nil(t, controller.Application(ctx, ActionRestart, "graphql"))
+	assert.Nil(t, controller.Application(ctx, ActionStop, "graphql"))
+	assert.Nil(t, controller.Application(ctx, ActionRebuild, "graphql"))
+
+	assert.EqualError(t, controller.Application(ctx, ActionStart, "graphql"), "local app 'graphql' is already running")
+	assert.EqualError(t, controller.Application(ctx, ActionSetup, "graphql"), "local app 'graphql' is already set up in '/', remove this directory to run its setup again")
+	assert.EqualError(t, controller.Application(ctx, ActionPause, "graphql"), "unknown action 'pause' for local app 'graphql'")
+	assert.EqualError(t, controller.Application(ctx, ActionRestart, "unknown-app"), "unknown local app 'unknown-app'")
+}
+
+func TestForward(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	watcher := watch.NewMockWatcher(ctrl)
+	watcher.EXPECT().GetProject().Return(&config.Project{
+		Name:     "My project name",
+		Forwards: []*config.Forward{{Name: "test-ssh-forward", Type: config.ForwarderSSH}},
+	}).AnyTimes()
+
+	forwarder := forward.NewMockForwarder(ctrl)
+	forwarder.EXPECT().Reconnect(ctx, "test-ssh-forward").Return(nil)
+	forwarder.EXPECT().Pause(ctx, "test-ssh-forward").Return(nil)
+	forwarder.EXPECT().Resume(ctx, "test-ssh-forward").Return(nil)
+
+	controller := NewController(ui.NewMockView(ctrl), status.NewRegistry(), watcher, setup.NewMockSetuper(ctrl), build.NewMockBuilder(ctrl), run.NewMockRunner(ctrl), forwarder)
+
+	// When - Then
+	assert.Nil(t, controller.Forward(ctx, ActionReconnect, "test-ssh-forward"))
+	assert.Nil(t, controller.Forward(ctx, ActionPause, "test-ssh-forward"))
+	assert.Nil(t, controller.Forward(ctx, ActionResume, "test-ssh-forward"))
+
+	assert.EqualError(t, controller.Forward(ctx, ActionRestart, "test-ssh-forward"), "unknown action 'restart' for forward 'test-ssh-forward'")
+	assert.EqualError(t, controller.Forward(ctx, ActionReconnect, "unknown-forward"), "unknown forward 'unknown-forward'")
+}
+
+func TestIsDestructive(t *testing.T) {
+	// When - Then
+	assert.True(t, ActionStop.IsDestructive())
+	assert.True(t, ActionPause.IsDestructive())
+	assert.False(t, ActionStart.IsDestructive())
+	assert.False(t, ActionResume.IsDestructive())
+}
@@ -0,0 +1,355 @@
+package dotenv
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Variable represents an environment variable declared in a dotenv file
+type Variable struct {
+	Key   string
+	Value string
+}
+
+// ParseError is returned when a dotenv content cannot be parsed
+type ParseError struct {
+	Line    int
+	Message string
+}
+
+// Error returns the problem message prefixed by its line
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+}
+
+// ParseFile parses the given dotenv file
+func ParseFile(filename string) ([]*Variable, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return Parse(file)
+}
+
+// Parse parses a dotenv content and returns its variables, in their declaration order.
+// It supports "export" prefixes, comments, single-quoted (literal) values, double-quoted values
+// (with escapes and multiple lines) and "$VAR", "${VAR}" or "${VAR:-default}" interpolations
+// against previously declared variables and the process environment.
+func Parse(reader io.Reader) ([]*Variable, error) {
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{
+		input:  []rune(strings.Replace(string(content), "\r\n", "\n", -1)),
+		line:   1,
+		values: make(map[string]string),
+	}
+
+	return p.parse()
+}
+
+type parser struct {
+	input  []rune
+	pos    int
+	line   int
+	values map[string]string
+}
+
+func (p *parser) parse() ([]*Variable, error) {
+	variables := make([]*Variable, 0)
+
+	for {
+		p.skipBlank()
+		if p.eof() {
+			return variables, nil
+		}
+
+		if p.peek() == '#' {
+			p.skipLine()
+			continue
+		}
+
+		variable, err := p.parseVariable()
+		if err != nil {
+			return nil, err
+		}
+
+		p.values[variable.Key] = variable.Value
+		variables = append(variables, variable)
+	}
+}
+
+func (p *parser) parseVariable() (*Variable, error) {
+	key := p.parseKey()
+
+	if key == "export" && (p.peek() == ' ' || p.peek() == '\t') {
+		p.skipSpaces()
+		key = p.parseKey()
+	}
+
+	if key == "" {
+		return nil, p.errorf("invalid variable name starting with '%c'", p.peek())
+	}
+
+	p.skipSpaces()
+	if p.eof() || p.peek() != '=' {
+		return nil, p.errorf("missing '=' after variable '%s'", key)
+	}
+	p.pos++
+	p.skipSpaces()
+
+	var (
+		value string
+		err   error
+	)
+
+	switch p.peek() {
+	case '\'':
+		value, err = p.parseSingleQuoted()
+	case '"':
+		value, err = p.parseDoubleQuoted()
+	default:
+		value, err = p.parseUnquoted()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Variable{Key: key, Value: value}, nil
+}
+
+func (p *parser) parseKey() string {
+	start := p.pos
+
+	for !p.eof() {
+		r := p.peek()
+		if !(r == '_' || r == '.' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (p.pos > start && r >= '0' && r <= '9')) {
+			break
+		}
+		p.pos++
+	}
+
+	return string(p.input[start:p.pos])
+}
+
+// parseSingleQuoted returns the literal value between single quotes
+func (p *parser) parseSingleQuoted() (string, error) {
+	line := p.line
+	p.pos++
+
+	var value strings.Builder
+
+	for {
+		if p.eof() {
+			return "", &ParseError{Line: line, Message: "unterminated single-quoted value"}
+		}
+
+		r := p.next()
+		if r == '\'' {
+			break
+		}
+
+		value.WriteRune(r)
+	}
+
+	return value.String(), p.endOfValue()
+}
+
+// parseDoubleQuoted returns the value between double quotes, escapes and interpolations being resolved
+func (p *parser) parseDoubleQuoted() (string, error) {
+	line := p.line
+	p.pos++
+
+	var value strings.Builder
+
+	for {
+		if p.eof() {
+			return "", &ParseError{Line: line, Message: "unterminated double-quoted value"}
+		}
+
+		r := p.next()
+
+		switch {
+		case r == '"':
+			return value.String(), p.endOfValue()
+
+		case r == '\\' && !p.eof():
+			escaped := p.next()
+
+			switch escaped {
+			case 'n':
+				value.WriteRune('\n')
+			case 'r':
+				value.WriteRune('\r')
+			case 't':
+				value.WriteRune('\t')
+			case '"', '\\', '$':
+				value.WriteRune(escaped)
+			default:
+				value.WriteRune('\\')
+				value.WriteRune(escaped)
+			}
+
+		case r == '$':
+			interpolated, err := p.parseInterpolation()
+			if err != nil {
+				return "", err
+			}
+
+			value.WriteString(interpolated)
+
+		default:
+			value.WriteRune(r)
+		}
+	}
+}
+
+// parseUnquoted returns the value until the end of line or an inline comment, interpolations being resolved
+func (p *parser) parseUnquoted() (string, error) {
+	var value strings.Builder
+
+	for !p.eof() && p.peek() != '\n' {
+		r := p.next()
+
+		switch {
+		case r == '#' && (value.Len() == 0 || strings.HasSuffix(value.String(), " ") || strings.HasSuffix(value.String(), "\t")):
+			p.skipLine()
+			return strings.TrimSpace(value.String()), nil
+
+		case r == '$':
+			interpolated, err := p.parseInterpolation()
+			if err != nil {
+				return "", err
+			}
+
+			value.WriteString(interpolated)
+
+		default:
+			value.WriteRune(r)
+		}
+	}
+
+	return strings.TrimSpace(value.String()), nil
+}
+
+// parseInterpolation returns the value of the "$VAR", "${VAR}" or "${VAR:-default}" following a '$'
+func (p *parser) parseInterpolation() (string, error) {
+	if p.eof() {
+		return "$", nil
+	}
+
+	if p.peek() != '{' {
+		name := p.parseKey()
+		if name == "" {
+			return "$", nil
+		}
+
+		return p.lookup(name), nil
+	}
+
+	line := p.line
+	p.pos++
+
+	name := p.parseKey()
+
+	var defaultValue *string
+	if p.pos+1 < len(p.input) && p.peek() == ':' && p.input[p.pos+1] == '-' {
+		p.pos += 2
+
+		start := p.pos
+		for !p.eof() && p.peek() != '}' && p.peek() != '\n' {
+			p.pos++
+		}
+
+		value := string(p.input[start:p.pos])
+		defaultValue = &value
+	}
+
+	if p.eof() || p.peek() != '}' || name == "" {
+		return "", &ParseError{Line: line, Message: "invalid variable interpolation, expected '${VAR}' or '${VAR:-default}'"}
+	}
+	p.pos++
+
+	if value := p.lookup(name); value != "" || defaultValue == nil {
+		return value, nil
+	}
+
+	return *defaultValue, nil
+}
+
+// lookup returns the value of a previously declared variable or of an environment variable
+func (p *parser) lookup(name string) string {
+	if value, ok := p.values[name]; ok {
+		return value
+	}
+
+	return os.Getenv(name)
+}
+
+// endOfValue ensures that only spaces or a comment follow a quoted value
+func (p *parser) endOfValue() error {
+	p.skipSpaces()
+
+	if p.eof() || p.peek() == '\n' {
+		return nil
+	}
+
+	if p.peek() == '#' {
+		p.skipLine()
+		return nil
+	}
+
+	return p.errorf("unexpected character '%c' after quoted value", p.peek())
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return &ParseError{Line: p.line, Message: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) eof() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *parser) peek() rune {
+	if p.eof() {
+		return 0
+	}
+
+	return p.input[p.pos]
+}
+
+func (p *parser) next() rune {
+	r := p.input[p.pos]
+	p.pos++
+
+	if r == '\n' {
+		p.line++
+	}
+
+	return r
+}
+
+func (p *parser) skipSpaces() {
+	for !p.eof() && (p.peek() == ' ' || p.peek() == '\t') {
+		p.pos++
+	}
+}
+
+func (p *parser) skipBlank() {
+	for !p.eof() && (p.peek() == ' ' || p.peek() == '\t' || p.peek() == '\n') {
+		p.next()
+	}
+}
+
+func (p *parser) skipLine() {
+	for !p.eof() && p.peek() != '\n' {
+		p.pos++
+	}
+}
@@ -0,0 +1,93 @@
+package dotenv
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	// Given
+	os.Setenv("MONDAY_DOTENV_TEST", "from-env")
+	defer os.Unsetenv("MONDAY_DOTENV_TEST")
+
+	content := `# A comment
+SIMPLE=value
+SPACES = value with spaces
+export EXPORTED=exported
+INLINE_COMMENT=value # this is a comment
+HASH=value#not-a-comment
+EMPTY=
+SINGLE='single $SIMPLE \n # not a comment'
+DOUBLE="double \"quoted\"\ttab" # comment
+MULTILINE="first line
+second line\nthird line"
+INTERPOLATED=${SIMPLE}-$EXPORTED-${MONDAY_DOTENV_TEST}
+DEFAULT=${MONDAY_DOTENV_UNDEFINED:-default value}
+ESCAPED="\${SIMPLE} costs \$5"
+UNDEFINED=$MONDAY_DOTENV_UNDEFINED
+`
+
+	// When
+	variables, err := Parse(strings.NewReader(content))
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, []*Variable{
+		{Key: "SIMPLE", Value: "value"},
+		{Key: "SPACES", Value: "value with spaces"},
+		{Key: "EXPORTED", Value: "exported"},
+		{Key: "INLINE_COMMENT", Value: "value"},
+		{Key: "HASH", Value: "value#not-a-comment"},
+		{Key: "EMPTY", Value: ""},
+		{Key: "SINGLE", Value: `single $SIMPLE \n # not a comment`},
+		{Key: "DOUBLE", Value: "double \"quoted\"\ttab"},
+		{Key: "MULTILINE", Value: "first line\nsecond line\nthird line"},
+		{Key: "INTERPOLATED", Value: "value-exported-from-env"},
+		{Key: "DEFAULT", Value: "default value"},
+		{Key: "ESCAPED", Value: "${SIMPLE} costs $5"},
+		{Key: "UNDEFINED", Value: ""},
+	}, variables)
+}
+
+func TestParseWhenErrors(t *testing.T) {
+	// Given
+	testCases := []struct {
+		content  string
+		expected string
+	}{
+		{content: "A=1\nNO_EQUAL\n", expected: "line 2: missing '=' after variable 'NO_EQUAL'"},
+		{content: "A=1\n\n-INVALID=1\n", expected: "line 3: invalid variable name starting with '-'"},
+		{content: "A=1\nB=\"unterminated\nvalue\n", expected: "line 2: unterminated double-quoted value"},
+		{content: "A='unterminated\n", expected: "line 1: unterminated single-quoted value"},
+		{content: "A=\"value\" trailing\n", expected: "line 1: unexpected character 't' after quoted value"},
+		{content: "A=1\nB=${INVALID\n", expected: "line 2: invalid variable interpolation, expected '${VAR}' or '${VAR:-default}'"},
+	}
+
+	for _, testCase := range testCases {
+		// When
+		variables, err := Parse(strings.NewReader(testCase.content))
+
+		// Then
+		assert.Nil(t, variables)
+		assert.EqualError(t, err, testCase.expected)
+	}
+}
+
+func TestParseFile(t *testing.T) {
+	// Given
+	dir, _ := os.Getwd()
+
+	// When
+	variables, err := ParseFile(dir + "/../../internal/test/runner/test.env")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, []*Variable{
+		{Key: "MY_ENVFILE_VAR_1", Value: "this is ok"},
+		{Key: "MY_ENVFILE_VAR_2", Value: "this is really good"},
+		{Key: "MY_ENVFILE_VAR_3", Value: "great"},
+	}, variables)
+}
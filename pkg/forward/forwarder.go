@@ -2,24 +2,32 @@ package forward
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/eko/monday/internal/ready"
 	"github.com/eko/monday/internal/wait"
 	"github.com/eko/monday/pkg/config"
 	"github.com/eko/monday/pkg/forward/kubernetes"
 	"github.com/eko/monday/pkg/forward/ssh"
 	"github.com/eko/monday/pkg/proxy"
+	"github.com/eko/monday/pkg/status"
 	"github.com/eko/monday/pkg/ui"
 )
 
 // Forwarder represents all kinds of forwarders (Kubernetes, others...)
 type Forwarder interface {
 	ForwardAll(ctx context.Context)
-	Stop(ctx context.Context)
+	Add(ctx context.Context, forward *config.Forward)
+	Remove(ctx context.Context, name string)
+	Reconnect(ctx context.Context, name string) error
+	Pause(ctx context.Context, name string) error
+	Resume(ctx context.Context, name string) error
+	Stop(ctx context.Context) error
 }
 
 type ForwarderType interface {
@@ -28,21 +36,26 @@ type ForwarderType interface {
 	Stop(ctx context.Context) error
 	GetReadyChannel() chan struct{}
 	GetStopChannel() chan struct{}
+	GetTarget() string
 }
 
 // forwarder is the struct that manage running local applications
 type forwarder struct {
 	view       ui.View
 	proxy      proxy.Proxy
+	registry   status.Registry
 	forwards   []*config.Forward
+	mutex      sync.Mutex
 	forwarders sync.Map
+	cancels    sync.Map
 }
 
-// NewForwarder instanciates a Forwarder struct from configuration data
-func NewForwarder(view ui.View, proxy proxy.Proxy, project *config.Project) *forwarder {
+// NewForwarder instanciates a Forwarder struct from configuration data, the forwards status being kept in the given registry
+func NewForwarder(view ui.View, proxy proxy.Proxy, registry status.Registry, project *config.Project) *forwarder {
 	return &forwarder{
 		view:     view,
 		proxy:    proxy,
+		registry: registry,
 		forwards: project.Forwards,
 	}
 }
@@ -50,7 +63,7 @@ func NewForwarder(view ui.View, proxy proxy.Proxy, project *config.Project) *for
 // ForwardAll runs all applications forwarders in separated goroutines
 func (f *forwarder) ForwardAll(ctx context.Context) {
 	var wg sync.WaitGroup
-	for _, forward := range f.forwards {
+	for _, forward := range f.getForwards() {
 		wg.Add(1)
 		go f.forward(ctx, forward, &wg)
 	}
@@ -59,7 +72,7 @@ func (f *forwarder) ForwardAll(ctx context.Context) {
 
 	// Run proxy for port-forwarning
 	go func() {
-		err := f.proxy.Listen()
+		err := f.proxy.Listen(ctx)
 		if err != nil {
 			f.view.Writef("❌  %s\n", err.Error())
 			return
@@ -67,15 +80,170 @@ func (f *forwarder) ForwardAll(ctx context.Context) {
 	}()
 }
 
-// Stop stops all currently active forwarders
-func (f *forwarder) Stop(ctx context.Context) {
+// Add registers a new forward (replacing the one with the same name), runs it and proxifies its ports
+func (f *forwarder) Add(ctx context.Context, forward *config.Forward) {
+	f.mutex.Lock()
+	f.forwards = append(removeForward(f.forwards, forward.Name), forward)
+	f.mutex.Unlock()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	f.forward(ctx, forward, &wg)
+
+	if err := f.proxy.Listen(ctx); err != nil {
+		f.view.Writef("❌  %s\n", err.Error())
+	}
+}
+
+// Remove stops the forwarders of the given forward name and unregisters it
+func (f *forwarder) Remove(ctx context.Context, name string) {
+	if cancel, ok := f.cancels.LoadAndDelete(name); ok {
+		cancel.(context.CancelFunc)()
+	}
+
+	if forwarders, ok := f.forwarders.LoadAndDelete(name); ok {
+		for _, forwarder := range forwarders.([]ForwarderType) {
+			forwarder.Stop(ctx)
+		}
+	}
+
+	ready.Unset(name)
+	f.registry.RemoveForward(name)
+
+	f.mutex.Lock()
+	f.forwards = removeForward(f.forwards, name)
+	f.mutex.Unlock()
+
+	f.proxy.RemoveProxyForward(name)
+}
+
+// Reconnect closes the connections of the given forward and opens them again, even when some of them
+// could not be closed properly
+func (f *forwarder) Reconnect(ctx context.Context, name string) error {
+	forwarders, err := f.disconnect(ctx, name)
+	if forwarders == nil {
+		return err
+	}
+
+	f.view.Writef("🔁  Reconnecting forward '%s'...\n", name)
+
+	f.registry.UpdateForward(name, func(fwd *status.Forward) {
+		fwd.State = status.StateReconnecting
+	})
+
+	go f.connect(f.newContext(ctx, name), name, forwarders)
+
+	return err
+}
+
+// Pause closes the connections of the given forward until it is resumed, its proxified hostnames being kept
+func (f *forwarder) Pause(ctx context.Context, name string) error {
+	if _, ok := f.cancels.Load(name); !ok {
+		return fmt.Errorf("forward '%s' is not connected", name)
+	}
+
+	if _, err := f.disconnect(ctx, name); err != nil {
+		return err
+	}
+
+	f.view.Writef("⏸️  Forward '%s' is paused\n", name)
+
+	f.registry.UpdateForward(name, func(fwd *status.Forward) {
+		fwd.State = status.StatePaused
+	})
+
+	return nil
+}
+
+// Resume opens again the connections of the given paused forward
+func (f *forwarder) Resume(ctx context.Context, name string) error {
+	forwarders, ok := f.forwarders.Load(name)
+	if !ok {
+		return fmt.Errorf("unknown forward '%s'", name)
+	}
+
+	if _, ok := f.cancels.Load(name); ok {
+		return fmt.Errorf("forward '%s' is not paused", name)
+	}
+
+	f.view.Writef("▶️  Resuming forward '%s'...\n", name)
+
+	f.registry.UpdateForward(name, func(fwd *status.Forward) {
+		fwd.State = status.StateConnecting
+	})
+
+	go f.connect(f.newContext(ctx, name), name, forwarders.([]ForwarderType))
+
+	return nil
+}
+
+// disconnect stops the forwarders of the given forward without unregistering them, so they can be connected again
+func (f *forwarder) disconnect(ctx context.Context, name string) ([]ForwarderType, error) {
+	forwarders, ok := f.forwarders.Load(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown forward '%s'", name)
+	}
+
+	if cancel, ok := f.cancels.LoadAndDelete(name); ok {
+		cancel.(context.CancelFunc)()
+	}
+
+	ready.Unset(name)
+
+	var errs []error
+	for _, forwarder := range forwarders.([]ForwarderType) {
+		if err := forwarder.Stop(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("forward '%s' could not be stopped: %w", name, err))
+		}
+	}
+
+	return forwarders.([]ForwarderType), errors.Join(errs...)
+}
+
+// newContext returns the context of the connections of the given forward, cancelled when it is disconnected
+func (f *forwarder) newContext(ctx context.Context, name string) context.Context {
+	ctx, cancel := context.WithCancel(ctx)
+
+	if previous, loaded := f.cancels.Swap(name, cancel); loaded {
+		previous.(context.CancelFunc)()
+	}
+
+	return ctx
+}
+
+// Stop stops all currently active forwarders, it returns an error listing the forwarders
+// (and remote resources) that could not be stopped
+func (f *forwarder) Stop(ctx context.Context) error {
+	f.cancels.Range(func(key, value interface{}) bool {
+		value.(context.CancelFunc)()
+		return true
+	})
+
+	var errs []error
+
 	f.forwarders.Range(func(key, value interface{}) bool {
 		for _, forwarder := range value.([]ForwarderType) {
-			forwarder.Stop(ctx)
+			if err := forwarder.Stop(ctx); err != nil {
+				errs = append(errs, fmt.Errorf("forward '%s' could not be stopped: %w", key, err))
+			}
 		}
 
+		f.registry.UpdateForward(key.(string), func(fwd *status.Forward) {
+			fwd.State = status.StateStopped
+		})
+
 		return true
 	})
+
+	return errors.Join(errs...)
+}
+
+// getForwards returns a copy of the registered forwards, which can be added or removed meanwhile
+func (f *forwarder) getForwards() []*config.Forward {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return append([]*config.Forward{}, f.forwards...)
 }
 
 func (f *forwarder) addForwarder(name string, forwarder ForwarderType) {
@@ -100,6 +268,13 @@ func (f *forwarder) forward(ctx context.Context, forward *config.Forward, wg *sy
 
 	f.view.Writef("📡  Forwarding '%s' over %s...\n", forward.Name, forward.Type)
 
+	f.registry.UpdateForward(forward.Name, func(fwd *status.Forward) {
+		fwd.State = status.StateConnecting
+	})
+
+	// Forward connections are retried until the forward is removed (or disconnected)
+	ctx = f.newContext(ctx, forward.Name)
+
 	values := forward.Values
 
 	// Initiates proxy for port-forwarding with hostnames
@@ -207,30 +382,103 @@ func (f *forwarder) forward(ctx context.Context, forward *config.Forward, wg *sy
 	}
 
 	if forwarders, ok := f.forwarders.Load(forward.Name); ok {
-		for _, forwarder := range forwarders.([]ForwarderType) {
-			backoff := wait.Backoff{
-				Min:    100 * time.Millisecond,
-				Max:    10 * time.Second,
-				Factor: 2,
+		f.connect(ctx, forward.Name, forwarders.([]ForwarderType))
+	}
+}
+
+// connect runs the given forwarders of a forward, retrying their connection until the context is done
+func (f *forwarder) connect(ctx context.Context, name string, forwarders []ForwarderType) {
+	// Forwarders notify each of their connections on their ready channel: notifications left by previous ones are dropped
+	for _, forwarder := range forwarders {
+		select {
+		case <-forwarder.GetReadyChannel():
+		default:
+		}
+	}
+
+	awaited := make(map[ForwarderType]bool)
+
+	for _, forwarder := range forwarders {
+		backoff := wait.Backoff{
+			Min:    100 * time.Millisecond,
+			Max:    10 * time.Second,
+			Factor: 2,
+		}
+
+		go func(forwarder ForwarderType) {
+			for ctx.Err() == nil {
+				err := forwarder.Forward(ctx)
+				if err == nil || ctx.Err() != nil {
+					continue
+				}
+
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(backoff.Duration()):
+				}
+
+				f.view.Writef("%v\n👓  Forwarder: lost port-forward connection trying to reconnect...\n", err)
+
+				f.registry.UpdateForward(name, func(fwd *status.Forward) {
+					fwd.State = status.StateReconnecting
+					fwd.Reconnects++
+					fwd.LastError = err.Error()
+				})
+			}
+		}(forwarder)
+
+		switch forwarder.GetForwardType() {
+		case config.ForwarderKubernetesRemote:
+			// Wait for the proxy to be ready before going next with the SSH remote-forwards
+			select {
+			case <-forwarder.GetReadyChannel():
+				awaited[forwarder] = true
+			case <-ctx.Done():
+				return
 			}
+		}
+	}
 
-			go func(forwarder ForwarderType) {
-				for {
-					err := forwarder.Forward(ctx)
-					if err != nil {
-						time.Sleep(backoff.Duration())
-						f.view.Writef("%v\n👓  Forwarder: lost port-forward connection trying to reconnect...\n", err)
-					}
+	// The forward is ready (or connected again) once all its forwarders are, applications depending on it can then be launched.
+	// Forwarders already awaited above are not awaited again for the first connection.
+	go func() {
+		for {
+			for _, forwarder := range forwarders {
+				if awaited[forwarder] {
+					continue
 				}
-			}(forwarder)
 
-			switch forwarder.GetForwardType() {
-			case config.ForwarderKubernetesRemote:
-				// Wait for the proxy to be ready before going next with the SSH remote-forwards
-				<-forwarder.GetReadyChannel()
+				select {
+				case <-forwarder.GetReadyChannel():
+				case <-ctx.Done():
+					return
+				}
 			}
+
+			awaited = nil
+
+			ready.Set(name)
+			f.setConnected(name, forwarders)
+		}
+	}()
+}
+
+// setConnected marks the given forward as connected to the first target of its forwarders
+func (f *forwarder) setConnected(name string, forwarders []ForwarderType) {
+	var target string
+
+	for _, forwarder := range forwarders {
+		if target = forwarder.GetTarget(); target != "" {
+			break
 		}
 	}
+
+	f.registry.UpdateForward(name, func(fwd *status.Forward) {
+		fwd.State = status.StateConnected
+		fwd.Target = target
+		fwd.ConnectedAt = time.Now()
+	})
 }
 
 func (f *forwarder) checkForwardEnvironment(forward *config.Forward) error {
@@ -252,3 +500,16 @@ func splitLocalAndForwardPorts(ports string) (string, string) {
 	parts := strings.Split(ports, ":")
 	return parts[0], parts[1]
 }
+
+// removeForward returns a copy of the given forwards without the one having the given name
+func removeForward(forwards []*config.Forward, name string) []*config.Forward {
+	result := make([]*config.Forward, 0, len(forwards))
+
+	for _, forward := range forwards {
+		if forward.Name != name {
+			result = append(result, forward)
+		}
+	}
+
+	return result
+}
@@ -13,6 +13,7 @@ import (
 	context "context"
 	reflect "reflect"
 
+	config "github.com/eko/monday/pkg/config"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -39,6 +40,18 @@ func (m *MockForwarder) EXPECT() *MockForwarderMockRecorder {
 	return m.recorder
 }
 
+// Add mocks base method.
+func (m *MockForwarder) Add(ctx context.Context, forward *config.Forward) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Add", ctx, forward)
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockForwarderMockRecorder) Add(ctx, forward any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockForwarder)(nil).Add), ctx, forward)
+}
+
 // ForwardAll mocks base method.
 func (m *MockForwarder) ForwardAll(ctx context.Context) {
 	m.ctrl.T.Helper()
@@ -51,10 +64,66 @@ func (mr *MockForwarderMockRecorder) ForwardAll(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForwardAll", reflect.TypeOf((*MockForwarder)(nil).ForwardAll), ctx)
 }
 
+// Pause mocks base method.
+func (m *MockForwarder) Pause(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pause", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Pause indicates an expected call of Pause.
+func (mr *MockForwarderMockRecorder) Pause(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pause", reflect.TypeOf((*MockForwarder)(nil).Pause), ctx, name)
+}
+
+// Reconnect mocks base method.
+func (m *MockForwarder) Reconnect(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reconnect", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reconnect indicates an expected call of Reconnect.
+func (mr *MockForwarderMockRecorder) Reconnect(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconnect", reflect.TypeOf((*MockForwarder)(nil).Reconnect), ctx, name)
+}
+
+// Remove mocks base method.
+func (m *MockForwarder) Remove(ctx context.Context, name string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Remove", ctx, name)
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockForwarderMockRecorder) Remove(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockForwarder)(nil).Remove), ctx, name)
+}
+
+// Resume mocks base method.
+func (m *MockForwarder) Resume(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resume", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Resume indicates an expected call of Resume.
+func (mr *MockForwarderMockRecorder) Resume(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resume", reflect.TypeOf((*MockForwarder)(nil).Resume), ctx, name)
+}
+
 // Stop mocks base method.
-func (m *MockForwarder) Stop(ctx context.Context) {
+func (m *MockForwarder) Stop(ctx context.Context) error {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "Stop", ctx)
+	ret := m.ctrl.Call(m, "Stop", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // Stop indicates an expected call of Stop.
@@ -142,6 +211,20 @@ func (mr *MockForwarderTypeMockRecorder) GetStopChannel() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStopChannel", reflect.TypeOf((*MockForwarderType)(nil).GetStopChannel))
 }
 
+// GetTarget mocks base method.
+func (m *MockForwarderType) GetTarget() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTarget")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetTarget indicates an expected call of GetTarget.
+func (mr *MockForwarderTypeMockRecorder) GetTarget() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTarget", reflect.TypeOf((*MockForwarderType)(nil).GetTarget))
+}
+
 // Stop mocks base method.
 func (m *MockForwarderType) Stop(ctx context.Context) error {
 	m.ctrl.T.Helper()
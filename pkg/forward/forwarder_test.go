@@ -2,11 +2,14 @@ package forward
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/eko/monday/pkg/config"
 	"github.com/eko/monday/pkg/proxy"
+	"github.com/eko/monday/pkg/status"
 	"github.com/eko/monday/pkg/ui"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
@@ -38,7 +41,7 @@ func TestNewForwarder(t *testing.T) {
 	view := ui.NewMockView(ctrl)
 
 	// When
-	f := NewForwarder(view, proxyfier, project)
+	f := NewForwarder(view, proxyfier, status.NewRegistry(), project)
 
 	// Then
 	assert.IsType(t, new(forwarder), f)
@@ -50,7 +53,9 @@ func TestNewForwarder(t *testing.T) {
 
 func TestForwardAll(t *testing.T) {
 	// Given
-	ctx := context.Background()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
@@ -58,7 +63,7 @@ func TestForwardAll(t *testing.T) {
 
 	proxyfier := proxy.NewMockProxy(ctrl)
 	proxyfier.EXPECT().AddProxyForward("test-ssh-forward", proxyForward)
-	proxyfier.EXPECT().Listen().Return(nil).AnyTimes()
+	proxyfier.EXPECT().Listen(gomock.Any()).Return(nil).AnyTimes()
 
 	project := &config.Project{
 		Name: "My project name",
@@ -76,8 +81,9 @@ func TestForwardAll(t *testing.T) {
 
 	view := ui.NewMockView(ctrl)
 	view.EXPECT().Writef("📡  Forwarding '%s' over %s...\n", "test-ssh-forward", "ssh")
+	view.EXPECT().Writef("%v\n👓  Forwarder: lost port-forward connection trying to reconnect...\n", gomock.Any()).AnyTimes()
 
-	forwarder := NewForwarder(view, proxyfier, project)
+	forwarder := NewForwarder(view, proxyfier, status.NewRegistry(), project)
 
 	// When
 	forwarder.ForwardAll(ctx)
@@ -94,14 +100,60 @@ func TestForwardAll(t *testing.T) {
 	}
 }
 
-func TestForwardRemoteSSH(t *testing.T) {
+func TestForwardAllWhenForwardsAreAdded(t *testing.T) {
 	// Given
 	ctx := context.Background()
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
+	proxyfier := proxy.NewMockProxy(ctrl)
+	proxyfier.EXPECT().Listen(ctx).Return(nil).AnyTimes()
+	proxyfier.EXPECT().RemoveProxyForward(gomock.Any()).AnyTimes()
+
+	view := ui.NewMockView(ctrl)
+	view.EXPECT().Writef(gomock.Any(), gomock.Any()).AnyTimes()
+
+	project := &config.Project{
+		Name: "My project name",
+		Forwards: []*config.Forward{
+			{Name: "test-unknown-forward", Type: "unknown"},
+		},
+	}
+
+	forwarder := NewForwarder(view, proxyfier, status.NewRegistry(), project)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		for i := 0; i < 50; i++ {
+			name := fmt.Sprintf("test-added-forward-%d", i)
+
+			forwarder.Add(ctx, &config.Forward{Name: name, Type: "unknown"})
+			forwarder.Remove(ctx, name)
+		}
+	}()
+
+	// When
+	for i := 0; i < 50; i++ {
+		forwarder.ForwardAll(ctx)
+	}
+
+	// Then
+	<-done
+	assert.Len(t, forwarder.getForwards(), 1)
+}
+
+func TestForwardRemoteSSH(t *testing.T) {
+	// Given
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
 	proxy := proxy.NewMockProxy(ctrl)
-	proxy.EXPECT().Listen().Return(nil).AnyTimes()
+	proxy.EXPECT().Listen(gomock.Any()).Return(nil).AnyTimes()
 
 	project := &config.Project{
 		Name: "My project name",
@@ -119,8 +171,9 @@ func TestForwardRemoteSSH(t *testing.T) {
 
 	view := ui.NewMockView(ctrl)
 	view.EXPECT().Writef("📡  Forwarding '%s' over %s...\n", "test-ssh-forward", "ssh-remote")
+	view.EXPECT().Writef("%v\n👓  Forwarder: lost port-forward connection trying to reconnect...\n", gomock.Any()).AnyTimes()
 
-	forwarder := NewForwarder(view, proxy, project)
+	forwarder := NewForwarder(view, proxy, status.NewRegistry(), project)
 
 	// When
 	forwarder.ForwardAll(ctx)
@@ -136,3 +189,246 @@ func TestForwardRemoteSSH(t *testing.T) {
 		}
 	}
 }
+
+func TestRemove(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	proxyfier := proxy.NewMockProxy(ctrl)
+	proxyfier.EXPECT().RemoveProxyForward("test-ssh-forward")
+
+	forwarderType := NewMockForwarderType(ctrl)
+	forwarderType.EXPECT().Stop(ctx).Return(nil)
+
+	project := &config.Project{
+		Name: "My project name",
+		Forwards: []*config.Forward{
+			{Name: "test-ssh-forward", Type: config.ForwarderSSH},
+			{Name: "test-other-forward", Type: config.ForwarderSSH},
+		},
+	}
+
+	view := ui.NewMockView(ctrl)
+
+	registry := status.NewRegistry()
+	registry.UpdateForward("test-ssh-forward", func(forward *status.Forward) {})
+
+	forwarder := NewForwarder(view, proxyfier, registry, project)
+	forwarder.addForwarder("test-ssh-forward", forwarderType)
+
+	forwardCtx, cancel := context.WithCancel(ctx)
+	forwarder.cancels.Store("test-ssh-forward", cancel)
+
+	// When
+	forwarder.Remove(ctx, "test-ssh-forward")
+
+	// Then
+	assert.NotNil(t, forwardCtx.Err())
+	assert.Len(t, forwarder.forwards, 1)
+	assert.Equal(t, "test-other-forward", forwarder.forwards[0].Name)
+	assert.Len(t, project.Forwards, 2)
+
+	_, ok := forwarder.forwarders.Load("test-ssh-forward")
+	assert.False(t, ok)
+
+	_, ok = registry.GetForward("test-ssh-forward")
+	assert.False(t, ok)
+}
+
+func TestStop(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	forwarderType := NewMockForwarderType(ctrl)
+	forwarderType.EXPECT().Stop(ctx).Return(nil)
+
+	failingForwarderType := NewMockForwarderType(ctrl)
+	failingForwarderType.EXPECT().Stop(ctx).Return(errors.New("deployment 'user-api' could not be reset"))
+
+	project := &config.Project{
+		Name: "My project name",
+		Forwards: []*config.Forward{
+			{Name: "test-ssh-forward", Type: config.ForwarderSSH},
+			{Name: "test-kubernetes-forward", Type: config.ForwarderKubernetesRemote},
+		},
+	}
+
+	registry := status.NewRegistry()
+
+	forwarder := NewForwarder(ui.NewMockView(ctrl), proxy.NewMockProxy(ctrl), registry, project)
+	forwarder.addForwarder("test-ssh-forward", forwarderType)
+	forwarder.addForwarder("test-kubernetes-forward", failingForwarderType)
+
+	forwardCtx, cancel := context.WithCancel(ctx)
+	forwarder.cancels.Store("test-ssh-forward", cancel)
+
+	// When
+	err := forwarder.Stop(ctx)
+
+	// Then
+	assert.NotNil(t, forwardCtx.Err())
+	assert.EqualError(t, err, "forward 'test-kubernetes-forward' could not be stopped: deployment 'user-api' could not be reset")
+
+	assert.Len(t, registry.GetForwards(), 2)
+	for _, forward := range registry.GetForwards() {
+		assert.Equal(t, status.StateStopped, forward.State)
+	}
+}
+
+func TestPauseAndResume(t *testing.T) {
+	// Given
+	ctx, cancelAll := context.WithCancel(context.Background())
+	defer cancelAll()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	resumed := make(chan struct{})
+
+	forwarderType := NewMockForwarderType(ctrl)
+	forwarderType.EXPECT().Stop(ctx).Return(nil)
+	forwarderType.EXPECT().GetForwardType().Return(config.ForwarderSSH).AnyTimes()
+	forwarderType.EXPECT().GetReadyChannel().Return(make(chan struct{})).AnyTimes()
+	forwarderType.EXPECT().Forward(gomock.Any()).DoAndReturn(func(ctx context.Context) error {
+		close(resumed)
+		<-ctx.Done()
+		return nil
+	})
+
+	project := &config.Project{
+		Name:     "My project name",
+		Forwards: []*config.Forward{{Name: "test-ssh-forward", Type: config.ForwarderSSH}},
+	}
+
+	view := ui.NewMockView(ctrl)
+	view.EXPECT().Writef("⏸️  Forward '%s' is paused\n", "test-ssh-forward")
+	view.EXPECT().Writef("▶️  Resuming forward '%s'...\n", "test-ssh-forward")
+
+	registry := status.NewRegistry()
+
+	forwarder := NewForwarder(view, proxy.NewMockProxy(ctrl), registry, project)
+	forwarder.addForwarder("test-ssh-forward", forwarderType)
+
+	forwardCtx := forwarder.newContext(ctx, "test-ssh-forward")
+
+	// When - Then
+	err := forwarder.Pause(ctx, "test-ssh-forward")
+	assert.Nil(t, err)
+	assert.NotNil(t, forwardCtx.Err())
+
+	forward, _ := registry.GetForward("test-ssh-forward")
+	assert.Equal(t, status.StatePaused, forward.State)
+
+	err = forwarder.Pause(ctx, "test-ssh-forward")
+	assert.EqualError(t, err, "forward 'test-ssh-forward' is not connected")
+
+	err = forwarder.Resume(ctx, "test-ssh-forward")
+	assert.Nil(t, err)
+
+	<-resumed
+
+	err = forwarder.Resume(ctx, "test-ssh-forward")
+	assert.EqualError(t, err, "forward 'test-ssh-forward' is not paused")
+
+	err = forwarder.Resume(ctx, "unknown-forward")
+	assert.EqualError(t, err, "unknown forward 'unknown-forward'")
+}
+
+func TestReconnect(t *testing.T) {
+	// Given
+	ctx, cancelAll := context.WithCancel(context.Background())
+	defer cancelAll()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reconnected := make(chan struct{})
+
+	forwarderType := NewMockForwarderType(ctrl)
+	forwarderType.EXPECT().Stop(ctx).Return(errors.New("process not found"))
+	forwarderType.EXPECT().GetForwardType().Return(config.ForwarderSSH).AnyTimes()
+	forwarderType.EXPECT().GetReadyChannel().Return(make(chan struct{})).AnyTimes()
+	forwarderType.EXPECT().Forward(gomock.Any()).DoAndReturn(func(ctx context.Context) error {
+		close(reconnected)
+		<-ctx.Done()
+		return nil
+	})
+
+	project := &config.Project{
+		Name:     "My project name",
+		Forwards: []*config.Forward{{Name: "test-ssh-forward", Type: config.ForwarderSSH}},
+	}
+
+	view := ui.NewMockView(ctrl)
+	view.EXPECT().Writef("🔁  Reconnecting forward '%s'...\n", "test-ssh-forward")
+
+	registry := status.NewRegistry()
+
+	forwarder := NewForwarder(view, proxy.NewMockProxy(ctrl), registry, project)
+	forwarder.addForwarder("test-ssh-forward", forwarderType)
+
+	forwardCtx := forwarder.newContext(ctx, "test-ssh-forward")
+
+	// When
+	err := forwarder.Reconnect(ctx, "test-ssh-forward")
+
+	// Then
+	assert.EqualError(t, err, "forward 'test-ssh-forward' could not be stopped: process not found")
+	assert.NotNil(t, forwardCtx.Err())
+
+	<-reconnected
+
+	forward, _ := registry.GetForward("test-ssh-forward")
+	assert.Equal(t, status.StateReconnecting, forward.State)
+}
+
+func TestConnectWhenConnectedAgain(t *testing.T) {
+	// Given
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	readyChannel := make(chan struct{}, 1)
+
+	forwarderType := NewMockForwarderType(ctrl)
+	forwarderType.EXPECT().GetForwardType().Return(config.ForwarderKubernetes).AnyTimes()
+	forwarderType.EXPECT().GetReadyChannel().Return(readyChannel).AnyTimes()
+	forwarderType.EXPECT().GetTarget().Return("user-api-5d8f7").AnyTimes()
+
+	gomock.InOrder(
+		forwarderType.EXPECT().Forward(gomock.Any()).DoAndReturn(func(ctx context.Context) error {
+			readyChannel <- struct{}{}
+			return errors.New("lost connection to pod")
+		}),
+		forwarderType.EXPECT().Forward(gomock.Any()).DoAndReturn(func(ctx context.Context) error {
+			readyChannel <- struct{}{}
+			<-ctx.Done()
+			return nil
+		}),
+	)
+
+	view := ui.NewMockView(ctrl)
+	view.EXPECT().Writef("%v\n👓  Forwarder: lost port-forward connection trying to reconnect...\n", errors.New("lost connection to pod"))
+
+	registry := status.NewRegistry()
+
+	forwarder := NewForwarder(view, proxy.NewMockProxy(ctrl), registry, &config.Project{Name: "My project name"})
+
+	// When
+	forwarder.connect(ctx, "test-kubernetes-forward", []ForwarderType{forwarderType})
+
+	// Then
+	assert.Eventually(t, func() bool {
+		forward, _ := registry.GetForward("test-kubernetes-forward")
+		return forward.State == status.StateConnected && forward.Reconnects == 1
+	}, 2*time.Second, 10*time.Millisecond)
+
+	forward, _ := registry.GetForward("test-kubernetes-forward")
+	assert.Equal(t, "user-api-5d8f7", forward.Target)
+}
@@ -8,6 +8,7 @@ import (
 	"net/url"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/eko/monday/pkg/config"
@@ -64,8 +65,10 @@ type Forwarder struct {
 	labels         map[string]string
 	portForwarders map[string]*portforward.PortForwarder
 	deployments    map[string]*DeploymentBackup
+	pod            string
 	stopChannel    chan struct{}
 	readyChannel   chan struct{}
+	mutex          sync.Mutex
 }
 
 func NewForwarder(view ui.View, forwardType, name, context, namespace string, ports []string, labels map[string]string) (*Forwarder, error) {
@@ -95,7 +98,7 @@ func NewForwarder(view ui.View, forwardType, name, context, namespace string, po
 		portForwarders: make(map[string]*portforward.PortForwarder, 0),
 		deployments:    make(map[string]*DeploymentBackup, 0),
 		stopChannel:    make(chan struct{}, 1),
-		readyChannel:   make(chan struct{}),
+		readyChannel:   make(chan struct{}, 1),
 	}, nil
 }
 
@@ -104,7 +107,7 @@ func (f *Forwarder) GetForwardType() string {
 	return f.forwardType
 }
 
-// GetReadyChannel returns the Kubernetes go client channel for ready event
+// GetReadyChannel returns the channel notified each time the ports are forwarded to a pod
 func (f *Forwarder) GetReadyChannel() chan struct{} {
 	return f.readyChannel
 }
@@ -114,6 +117,14 @@ func (f *Forwarder) GetStopChannel() chan struct{} {
 	return f.readyChannel
 }
 
+// GetTarget returns the name of the pod ports are currently forwarded to
+func (f *Forwarder) GetTarget() string {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return f.pod
+}
+
 // Forward method executes the local or remote port-forward depending on the given type
 func (f *Forwarder) Forward(ctx context.Context) error {
 	defer func() {
@@ -148,19 +159,34 @@ func (f *Forwarder) Forward(ctx context.Context) error {
 
 // Stop stops the current forwarder
 func (f *Forwarder) Stop(ctx context.Context) error {
-	// Close port-forwards currently active connections
+	f.mutex.Lock()
+	portForwarders := make([]*portforward.PortForwarder, 0, len(f.portForwarders))
 	for _, portForwarder := range f.portForwarders {
+		portForwarders = append(portForwarders, portForwarder)
+	}
+
+	backups := make([]*DeploymentBackup, 0, len(f.deployments))
+	for _, backup := range f.deployments {
+		backups = append(backups, backup)
+	}
+	f.mutex.Unlock()
+
+	// Close port-forwards currently active connections
+	for _, portForwarder := range portForwarders {
 		portForwarder.Close()
 	}
 
 	deploymentsClient := f.clientSet.AppsV1().Deployments(f.namespace)
 
+	var errs []error
+
 	// Reset currently active remote-forward deployment proxies
-	for _, backup := range f.deployments {
+	for _, backup := range backups {
 		selector := f.getSelector()
 
 		deployments, err := deploymentsClient.List(ctx, metav1.ListOptions{LabelSelector: selector})
 		if err != nil {
+			errs = append(errs, fmt.Errorf("deployment '%s' in namespace '%s' could not be reset: %v", backup.Deployment.Name, f.namespace, err))
 			continue
 		}
 
@@ -177,10 +203,11 @@ func (f *Forwarder) Stop(ctx context.Context) error {
 		_, err = deploymentsClient.Update(ctx, &deployment, metav1.UpdateOptions{})
 		if err != nil {
 			f.view.Writef("❌  An error has occured while stopping/resetting a deployment: %v\n", err)
+			errs = append(errs, fmt.Errorf("deployment '%s' in namespace '%s' could not be reset: %v", deployment.Name, f.namespace, err))
 		}
 	}
 
-	return nil
+	return errors.Join(errs...)
 }
 
 func isPodRunning(pod *apiv1.Pod) bool {
@@ -234,16 +261,46 @@ func (f *Forwarder) forwardLocal(ctx context.Context, selector string) error {
 	stdoutStream := log.NewStreamer(log.StdOut, runningPod.Name, f.view)
 	stderrStream := log.NewStreamer(log.StdErr, runningPod.Name, f.view)
 
-	fw, err := portforward.New(dialer, f.ports, f.stopChannel, f.readyChannel, stdoutStream, stderrStream)
+	f.mutex.Lock()
+	stopChannel := f.stopChannel
+	f.mutex.Unlock()
+
+	// The ready channel of the Kubernetes go client is closed once, so each connection has its own
+	readyChannel := make(chan struct{})
+
+	fw, err := portforward.New(dialer, f.ports, stopChannel, readyChannel, stdoutStream, stderrStream)
 	if err != nil {
 		return err
 	}
 
+	f.mutex.Lock()
 	f.portForwarders[f.name] = fw
+	f.pod = runningPod.Name
+	f.mutex.Unlock()
+
+	done := make(chan struct{})
+	defer close(done)
+
+	go f.notifyReady(readyChannel, done)
 
 	return fw.ForwardPorts()
 }
 
+// notifyReady notifies the ready channel of the forwarder once the given connection ready channel is closed,
+// unless the connection is done before or a notification is already pending
+func (f *Forwarder) notifyReady(readyChannel, done chan struct{}) {
+	select {
+	case <-readyChannel:
+	case <-done:
+		return
+	}
+
+	select {
+	case f.readyChannel <- struct{}{}:
+	default:
+	}
+}
+
 func (f *Forwarder) forwardRemote(ctx context.Context, selector string) error {
 	deploymentsClient := f.clientSet.AppsV1().Deployments(f.namespace)
 
@@ -263,6 +320,7 @@ func (f *Forwarder) forwardRemote(ctx context.Context, selector string) error {
 	deployment := deployments.Items[0]
 	container := deployment.Spec.Template.Spec.Containers[0]
 
+	f.mutex.Lock()
 	if _, ok := f.deployments[f.name]; !ok {
 		f.view.Writef("📡  Setting up proxy on application '%s', please wait some seconds for pod to be ready...\n", deployment.Name)
 
@@ -272,6 +330,7 @@ func (f *Forwarder) forwardRemote(ctx context.Context, selector string) error {
 			Deployment: &deployment,
 		}
 	}
+	f.mutex.Unlock()
 
 	container.Image = ProxyDockerImage
 
@@ -322,10 +381,12 @@ func (f *Forwarder) getSelector() string {
 }
 
 func (f *Forwarder) reset() {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
 	f.portForwarders = make(map[string]*portforward.PortForwarder, 0)
 	f.deployments = make(map[string]*DeploymentBackup, 0)
 	f.stopChannel = make(chan struct{}, 1)
-	f.readyChannel = make(chan struct{})
 }
 
 func initializeClientConfig(context string, kubeConfigPath string) (*restclient.Config, error) {
@@ -155,6 +155,48 @@ func TestGetReadyChannel(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestNotifyReady(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	initKubeConfig(t)
+	defer os.Remove(defaultKubeConfigPath)
+
+	forwarder, err := NewForwarder(ui.NewMockView(ctrl), config.ForwarderKubernetes, "test-forward", "context-test", "platform", []string{"8080:8080"}, map[string]string{
+		"app": "my-test-app",
+	})
+	assert.Nil(t, err)
+
+	for connection := 0; connection < 2; connection++ {
+		readyChannel := make(chan struct{})
+		close(readyChannel)
+
+		// When
+		forwarder.notifyReady(readyChannel, make(chan struct{}))
+
+		// Then
+		select {
+		case <-forwarder.GetReadyChannel():
+		default:
+			t.Fatalf("connection %d has not been notified", connection)
+		}
+	}
+
+	// When
+	done := make(chan struct{})
+	close(done)
+
+	forwarder.notifyReady(make(chan struct{}), done)
+
+	// Then
+	select {
+	case <-forwarder.GetReadyChannel():
+		t.Fatal("a connection done before being ready has been notified")
+	default:
+	}
+}
+
 func TestGetStopChannel(t *testing.T) {
 	// Given
 	ctrl := gomock.NewController(t)
@@ -2,7 +2,9 @@ package ssh
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
 	"os/exec"
 
 	"github.com/eko/monday/pkg/ui"
@@ -56,7 +58,12 @@ func (f *Forwarder) GetStopChannel() chan struct{} {
 	return f.readyChannel
 }
 
-func (f *Forwarder) Forward(_ context.Context) error {
+// GetTarget returns the host the SSH connection is opened to
+func (f *Forwarder) GetTarget() string {
+	return f.remote
+}
+
+func (f *Forwarder) Forward(ctx context.Context) error {
 	if f.remote == "" {
 		return fmt.Errorf("Please provide a 'remote' attribute specifing the host you want to SSH on")
 	}
@@ -93,6 +100,19 @@ func (f *Forwarder) Forward(_ context.Context) error {
 		return fmt.Errorf("Cannot run the SSH command for port-forwarding '%s' on host '%s': %v", mapping, host, err)
 	}
 
+	// Close the SSH connection once the forward is not needed anymore
+	cmd := f.cmd
+	stop := context.AfterFunc(ctx, func() {
+		cmd.Process.Kill()
+	})
+	defer stop()
+
+	// Notify the SSH forward is established, unless it was already notified
+	select {
+	case f.readyChannel <- struct{}{}:
+	default:
+	}
+
 	if err := f.cmd.Wait(); err != nil {
 		return fmt.Errorf("SSH forwarding of '%s' on host '%s' returned an error: %v", mapping, host, err)
 	}
@@ -107,7 +127,7 @@ func (f *Forwarder) Stop(_ context.Context) error {
 	}
 
 	err := f.cmd.Process.Kill()
-	if err != nil {
+	if err != nil && !errors.Is(err, os.ErrProcessDone) {
 		return err
 	}
 
@@ -0,0 +1,275 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"regexp"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/eko/monday/internal/ready"
+	"github.com/eko/monday/pkg/config"
+	"github.com/eko/monday/pkg/helper"
+	"github.com/eko/monday/pkg/status"
+	"github.com/eko/monday/pkg/ui"
+)
+
+// Status represents the health status of a local application
+type Status string
+
+const (
+	StatusUnknown   Status = "unknown"
+	StatusHealthy   Status = "healthy"
+	StatusUnhealthy Status = "unhealthy"
+)
+
+// Checker periodically checks the health of the local applications declaring a health section
+type Checker interface {
+	Start(application *config.Application)
+	Stop(name string)
+	Observe(name, line string)
+	GetStatus(name string) Status
+}
+
+// checker is the struct that manage the health checks of local applications
+type checker struct {
+	view     ui.View
+	registry status.Registry
+	mutex    sync.Mutex
+	checks   map[string]*check
+}
+
+// check is the health state of a single application
+type check struct {
+	application *config.Application
+	status      Status
+	failures    int
+	pattern     *regexp.Regexp
+	matched     bool
+	cancel      context.CancelFunc
+}
+
+// NewChecker instanciates a health checker writing health changes to the given view and registry
+func NewChecker(view ui.View, registry status.Registry) *checker {
+	return &checker{
+		view:     view,
+		registry: registry,
+		checks:   make(map[string]*check),
+	}
+}
+
+// Start periodically checks the health of the given application until it is stopped.
+// The application is marked as ready once healthy.
+func (c *checker) Start(application *config.Application) {
+	if application.Health == nil {
+		return
+	}
+
+	c.Stop(application.Name)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	check := &check{
+		application: application,
+		status:      StatusUnknown,
+		cancel:      cancel,
+	}
+
+	if log := application.Health.Log; log != nil {
+		check.pattern, _ = regexp.Compile(log.Pattern)
+	}
+
+	c.mutex.Lock()
+	c.checks[application.Name] = check
+	c.mutex.Unlock()
+
+	c.setHealth(application.Name, StatusUnknown)
+
+	go c.run(ctx, check)
+}
+
+// Stop stops the health checks of the given application
+func (c *checker) Stop(name string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if check, ok := c.checks[name]; ok {
+		check.cancel()
+		delete(c.checks, name)
+
+		c.setHealth(name, "")
+	}
+}
+
+// Observe gives an output line of the given application to its log health check
+func (c *checker) Observe(name, line string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if check, ok := c.checks[name]; ok && check.pattern != nil && check.pattern.MatchString(line) {
+		check.matched = true
+	}
+}
+
+// GetStatus returns the current health status of the given application
+func (c *checker) GetStatus(name string) Status {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if check, ok := c.checks[name]; ok {
+		return check.status
+	}
+
+	return StatusUnknown
+}
+
+// setHealth records the health status of the given application in the registry
+func (c *checker) setHealth(name string, health Status) {
+	c.registry.UpdateApplication(name, func(application *status.Application) {
+		application.Health = string(health)
+	})
+}
+
+func (c *checker) run(ctx context.Context, check *check) {
+	ticker := time.NewTicker(check.application.Health.GetInterval())
+	defer ticker.Stop()
+
+	for {
+		err := c.check(ctx, check)
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		c.update(ctx, check, err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// update changes the status of an application given its latest check result, unless its checks have been
+// stopped in the meantime
+func (c *checker) update(ctx context.Context, check *check, err error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Checks are cancelled while holding the mutex, so a stopped check can't be updated anymore
+	if ctx.Err() != nil {
+		return
+	}
+
+	name := check.application.Name
+
+	if err == nil {
+		check.failures = 0
+
+		if check.status != StatusHealthy {
+			check.status = StatusHealthy
+			c.setHealth(name, StatusHealthy)
+			c.view.Writef("💚  Local app '%s' is healthy\n", name)
+			ready.Set(name)
+		}
+
+		return
+	}
+
+	check.failures++
+
+	if check.failures >= check.application.Health.GetThreshold() && check.status != StatusUnhealthy {
+		check.status = StatusUnhealthy
+		c.setHealth(name, StatusUnhealthy)
+		c.view.Writef("💔  Local app '%s' is unhealthy: %v\n", name, err)
+		ready.Unset(name)
+	}
+}
+
+func (c *checker) check(ctx context.Context, check *check) error {
+	health := check.application.Health
+
+	ctx, cancel := context.WithTimeout(ctx, health.GetTimeout())
+	defer cancel()
+
+	switch {
+	case health.HTTP != nil:
+		return checkHTTP(ctx, health.HTTP)
+	case health.TCP != nil:
+		return checkTCP(ctx, health.TCP)
+	case health.Exec != nil:
+		return checkExec(ctx, health.Exec, check.application.GetPath())
+	case health.Log != nil:
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+
+		if !check.matched {
+			return fmt.Errorf("no output line matching '%s'", health.Log.Pattern)
+		}
+	}
+
+	return nil
+}
+
+func checkHTTP(ctx context.Context, health *config.HTTPHealth) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, health.URL, nil)
+	if err != nil {
+		return err
+	}
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != health.GetStatus() {
+		return fmt.Errorf("'%s' returned status code %d instead of %d", health.URL, response.StatusCode, health.GetStatus())
+	}
+
+	return nil
+}
+
+func checkTCP(ctx context.Context, health *config.TCPHealth) error {
+	var dialer net.Dialer
+
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(health.GetHost(), health.Port))
+	if err != nil {
+		return err
+	}
+
+	return conn.Close()
+}
+
+func checkExec(ctx context.Context, health *config.ExecHealth, path string) error {
+	cmd := helper.BuildCmd([]string{health.Command}, path, nil, nil)
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("command failed: %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- cmd.Wait()
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			return fmt.Errorf("command failed: %v", err)
+		}
+
+		return nil
+
+	case <-ctx.Done():
+		// Kill the whole process group of the command
+		syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+		<-done
+
+		return fmt.Errorf("command did not complete: %v", ctx.Err())
+	}
+}
@@ -0,0 +1,206 @@
+package health
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/eko/monday/internal/ready"
+	"github.com/eko/monday/pkg/config"
+	"github.com/eko/monday/pkg/status"
+	"github.com/eko/monday/pkg/ui"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestNewChecker(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	view := ui.NewMockView(ctrl)
+
+	registry := status.NewRegistry()
+
+	// When
+	c := NewChecker(view, registry)
+
+	// Then
+	assert.IsType(t, new(checker), c)
+	assert.Implements(t, new(Checker), c)
+
+	assert.Equal(t, view, c.view)
+	assert.Equal(t, registry, c.registry)
+	assert.Len(t, c.checks, 0)
+}
+
+func TestStartWhenHealthy(t *testing.T) {
+	// Given
+	defer ready.Reset()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	_, port, _ := net.SplitHostPort(listener.Addr().String())
+
+	testCases := []struct {
+		name   string
+		health *config.Health
+	}{
+		{name: "http-app", health: &config.Health{HTTP: &config.HTTPHealth{URL: server.URL, Status: http.StatusNoContent}}},
+		{name: "tcp-app", health: &config.Health{TCP: &config.TCPHealth{Port: port}}},
+		{name: "exec-app", health: &config.Health{Exec: &config.ExecHealth{Command: "exit 0"}}},
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	view := ui.NewMockView(ctrl)
+	registry := status.NewRegistry()
+	checker := NewChecker(view, registry)
+
+	for _, testCase := range testCases {
+		view.EXPECT().Writef("💚  Local app '%s' is healthy\n", testCase.name)
+
+		// When
+		checker.Start(&config.Application{Name: testCase.name, Path: "/", Health: testCase.health})
+
+		// Then
+		assert.Nil(t, ready.Wait(contextWithTimeout(t), testCase.name))
+		assert.Equal(t, StatusHealthy, checker.GetStatus(testCase.name))
+
+		application, _ := registry.GetApplication(testCase.name)
+		assert.Equal(t, string(StatusHealthy), application.Health)
+
+		checker.Stop(testCase.name)
+		assert.Equal(t, StatusUnknown, checker.GetStatus(testCase.name))
+
+		application, _ = registry.GetApplication(testCase.name)
+		assert.Equal(t, "", application.Health)
+	}
+}
+
+func TestStartWhenLogPattern(t *testing.T) {
+	// Given
+	defer ready.Reset()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	view := ui.NewMockView(ctrl)
+	view.EXPECT().Writef("💚  Local app '%s' is healthy\n", "log-app")
+
+	checker := NewChecker(view, status.NewRegistry())
+
+	// When
+	checker.Start(&config.Application{
+		Name: "log-app",
+		Path: "/",
+		Health: &config.Health{
+			Log:       &config.LogHealth{Pattern: "listening on :[0-9]+"},
+			Interval:  "10ms",
+			Threshold: 100,
+		},
+	})
+	defer checker.Stop("log-app")
+
+	checker.Observe("log-app", "starting server\n")
+	time.Sleep(50 * time.Millisecond)
+
+	// Then
+	assert.Equal(t, StatusUnknown, checker.GetStatus("log-app"))
+
+	checker.Observe("log-app", "listening on :8005\n")
+
+	assert.Nil(t, ready.Wait(contextWithTimeout(t), "log-app"))
+	assert.Equal(t, StatusHealthy, checker.GetStatus("log-app"))
+}
+
+func TestStartWhenUnhealthy(t *testing.T) {
+	// Given
+	defer ready.Reset()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	unhealthy := make(chan bool)
+
+	view := ui.NewMockView(ctrl)
+	view.EXPECT().Writef("💔  Local app '%s' is unhealthy: %v\n", "exec-app", gomock.Any()).Do(func(format string, args ...interface{}) {
+		assert.EqualError(t, args[1].(error), "command failed: exit status 1")
+		close(unhealthy)
+	})
+
+	checker := NewChecker(view, status.NewRegistry())
+
+	// When
+	checker.Start(&config.Application{
+		Name: "exec-app",
+		Path: "/",
+		Health: &config.Health{
+			Exec:      &config.ExecHealth{Command: "exit 1"},
+			Interval:  "10ms",
+			Threshold: 2,
+		},
+	})
+	defer checker.Stop("exec-app")
+
+	// Then
+	select {
+	case <-unhealthy:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Application has not been reported as unhealthy")
+	}
+
+	assert.Equal(t, StatusUnhealthy, checker.GetStatus("exec-app"))
+	assert.False(t, ready.IsReady("exec-app"))
+}
+
+func TestStopWhenCheckInFlight(t *testing.T) {
+	// Given
+	defer ready.Reset()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	view := ui.NewMockView(ctrl)
+	registry := status.NewRegistry()
+	checker := NewChecker(view, registry)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	check := &check{
+		application: &config.Application{Name: "http-app", Path: "/", Health: &config.Health{}},
+		status:      StatusUnknown,
+		cancel:      cancel,
+	}
+
+	// When
+	cancel()
+	checker.update(ctx, check, nil)
+
+	// Then
+	assert.Equal(t, StatusUnknown, check.status)
+	assert.False(t, ready.IsReady("http-app"))
+
+	_, ok := registry.GetApplication("http-app")
+	assert.False(t, ok)
+}
+
+func contextWithTimeout(t *testing.T) context.Context {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	return ctx
+}
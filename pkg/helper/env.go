@@ -1,21 +1,31 @@
 package helper
 
 import (
-	"bufio"
 	"fmt"
 	"os"
 	"os/exec"
-	"regexp"
+
+	"github.com/eko/monday/pkg/dotenv"
+	"github.com/eko/monday/pkg/secret"
 )
 
-// AddEnvVariables adds environment variables given as key/value pair
-func AddEnvVariables(cmd *exec.Cmd, envs map[string]string) {
+// AddEnvVariables adds environment variables given as key/value pair,
+// secret references being resolved
+func AddEnvVariables(cmd *exec.Cmd, envs map[string]string) error {
 	for key, value := range envs {
+		value, err := secret.Resolve(value)
+		if err != nil {
+			return fmt.Errorf("unable to set environment variable '%s': %v", key, err)
+		}
+
 		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", key, value))
 	}
+
+	return nil
 }
 
-// AddEnvVariablesFromFile adds environment variables given as a filename
+// AddEnvVariablesFromFile adds environment variables given as a dotenv filename,
+// secret references being resolved
 func AddEnvVariablesFromFile(cmd *exec.Cmd, filename string) error {
 	if filename == "" {
 		return nil
@@ -23,28 +33,18 @@ func AddEnvVariablesFromFile(cmd *exec.Cmd, filename string) error {
 
 	filename = os.ExpandEnv(filename)
 
-	file, err := os.OpenFile(filename, os.O_RDONLY, os.ModePerm)
+	variables, err := dotenv.ParseFile(filename)
 	if err != nil {
-		return fmt.Errorf("unable to open environment file '%s': %v", filename, err)
+		return fmt.Errorf("an error has occured while reading environment file '%s': %v", filename, err)
 	}
-	defer file.Close()
 
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := scanner.Text()
-
-		r, _ := regexp.Compile("([a-zA-Z0-9_]+)=(.*)")
-		matches := r.FindStringSubmatch(line)
-
-		if len(matches) < 3 {
-			continue
+	for _, variable := range variables {
+		value, err := secret.Resolve(variable.Value)
+		if err != nil {
+			return fmt.Errorf("unable to set environment variable '%s' from file '%s': %v", variable.Key, filename, err)
 		}
 
-		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", matches[1], matches[2]))
-	}
-
-	if err := scanner.Err(); err != nil {
-		return fmt.Errorf("an error has occured while reading environment file '%s': %v", filename, err)
+		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", variable.Key, value))
 	}
 
 	return nil
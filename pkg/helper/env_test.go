@@ -5,6 +5,7 @@ import (
 	"os/exec"
 	"testing"
 
+	"github.com/eko/monday/internal/redact"
 	"github.com/eko/monday/pkg/config"
 	"github.com/stretchr/testify/assert"
 )
@@ -54,3 +55,50 @@ func getMockedApplication() *config.Application {
 		},
 	}
 }
+
+func TestAddEnvVariablesWhenSecret(t *testing.T) {
+	// Given
+	defer redact.Reset()
+
+	cmd := &exec.Cmd{}
+
+	// When
+	err := AddEnvVariables(cmd, map[string]string{
+		"MY_SECRET": "secret://exec/echo p4ssw0rd",
+	})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Contains(t, cmd.Env, "MY_SECRET=p4ssw0rd")
+	assert.Equal(t, "password is ******", redact.String("password is p4ssw0rd"))
+}
+
+func TestAddEnvVariablesWhenSecretCannotBeResolved(t *testing.T) {
+	// Given
+	cmd := &exec.Cmd{}
+
+	// When
+	err := AddEnvVariables(cmd, map[string]string{
+		"MY_SECRET": "secret://unknown/p4ssw0rd",
+	})
+
+	// Then
+	assert.EqualError(t, err, "unable to set environment variable 'MY_SECRET': unknown provider 'unknown' for secret reference 'secret://unknown/p4ssw0rd'")
+	assert.Len(t, cmd.Env, 0)
+}
+
+func TestAddEnvVariablesFromFileWhenSecret(t *testing.T) {
+	// Given
+	defer redact.Reset()
+
+	dir, _ := os.Getwd()
+	cmd := &exec.Cmd{}
+
+	// When
+	err := AddEnvVariablesFromFile(cmd, dir+"/../../internal/test/runner/secret.env")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Contains(t, cmd.Env, "MY_ENVFILE_SECRET=from-exec")
+	assert.Contains(t, cmd.Env, "MY_ENVFILE_VAR=not a secret")
+}
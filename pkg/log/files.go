@@ -0,0 +1,193 @@
+package log
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eko/monday/internal/filename"
+	"github.com/eko/monday/internal/redact"
+	"github.com/eko/monday/pkg/ui"
+)
+
+const (
+	// TimeFormat is the format of the timestamp prefixing each line of the log files
+	TimeFormat = "2006-01-02T15:04:05.000Z07:00"
+
+	fileExtension = ".log"
+)
+
+// Files writes the output of the local applications into their own log file, rotated by size
+type Files interface {
+	Write(name, stdType, line string)
+	Close() error
+}
+
+// files is the struct that manage the log files of the applications of a project
+type files struct {
+	view      ui.View
+	directory string
+	maxSize   int64
+	maxFiles  int
+	mutex     sync.Mutex
+	opened    map[string]*file
+}
+
+// file is an opened log file of a single application
+type file struct {
+	file   *os.File
+	size   int64
+	failed bool
+}
+
+// NewFiles instanciates the log files of the given project, stored in the given directory and rotated
+// once they reach maxSize bytes, keeping maxFiles rotated files
+func NewFiles(view ui.View, directory, project string, maxSize int64, maxFiles int) *files {
+	return &files{
+		view:      view,
+		directory: GetProjectDirectory(directory, project),
+		maxSize:   maxSize,
+		maxFiles:  maxFiles,
+		opened:    make(map[string]*file),
+	}
+}
+
+// GetProjectDirectory returns the directory containing the log files of the given project
+func GetProjectDirectory(directory, project string) string {
+	return filepath.Join(directory, filename.Sanitize(project))
+}
+
+// GetFilepath returns the path of the log file of the given application
+func GetFilepath(directory, project, name string) string {
+	return filepath.Join(GetProjectDirectory(directory, project), filename.Sanitize(name)+fileExtension)
+}
+
+// Write appends the given output line of the application to its log file, prefixed by the current time
+// and the output type. Secret values are masked.
+func (f *files) Write(name, stdType, line string) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if !strings.HasSuffix(line, "\n") {
+		line += "\n"
+	}
+
+	line = fmt.Sprintf("%s %s %s", time.Now().Format(TimeFormat), stdType, redact.String(line))
+
+	if err := f.write(name, line); err != nil {
+		f.view.Writef("❌  Unable to write the log file of '%s', its output will not be saved anymore: %v\n", name, err)
+	}
+}
+
+// Close closes all the opened log files
+func (f *files) Close() error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	var errs []string
+
+	for name, opened := range f.opened {
+		if opened.file != nil {
+			if err := opened.file.Close(); err != nil {
+				errs = append(errs, fmt.Sprintf("'%s': %v", name, err))
+			}
+		}
+
+		delete(f.opened, name)
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("log files could not be closed: %s", strings.Join(errs, ", "))
+	}
+
+	return nil
+}
+
+// write writes the line in the log file of the application, opening or rotating it when needed.
+// An error is only returned the first time writing the file fails.
+func (f *files) write(name, line string) error {
+	opened, ok := f.opened[name]
+	if !ok {
+		opened = &file{}
+		f.opened[name] = opened
+	}
+
+	if opened.failed {
+		return nil
+	}
+
+	err := f.prepare(name, opened, int64(len(line)))
+	if err == nil {
+		var n int
+		n, err = opened.file.WriteString(line)
+		opened.size += int64(n)
+	}
+
+	if err != nil {
+		opened.failed = true
+	}
+
+	return err
+}
+
+// prepare opens the log file of the application, after having rotated it if the line does not fit in
+func (f *files) prepare(name string, opened *file, length int64) error {
+	if opened.file != nil && (opened.size == 0 || opened.size+length <= f.maxSize) {
+		return nil
+	}
+
+	path := filepath.Join(f.directory, filename.Sanitize(name)+fileExtension)
+
+	if opened.file != nil {
+		opened.file.Close()
+		opened.file = nil
+
+		if err := f.rotate(path); err != nil {
+			return err
+		}
+	}
+
+	if err := os.MkdirAll(f.directory, 0755); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	opened.file = file
+	opened.size = info.Size()
+
+	// A file left by a previous session may already be full
+	if opened.size > 0 && opened.size+length > f.maxSize {
+		return f.prepare(name, opened, length)
+	}
+
+	return nil
+}
+
+// rotate shifts the rotated files of the given path (<app>.log.1 becoming <app>.log.2, ...), the oldest one
+// being removed, and renames the current file to <app>.log.1
+func (f *files) rotate(path string) error {
+	if f.maxFiles <= 0 {
+		return os.Remove(path)
+	}
+
+	for i := f.maxFiles - 1; i > 0; i-- {
+		if err := os.Rename(fmt.Sprintf("%s.%d", path, i), fmt.Sprintf("%s.%d", path, i+1)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	return os.Rename(path, path+".1")
+}
@@ -0,0 +1,95 @@
+package log
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/eko/monday/internal/redact"
+	"github.com/eko/monday/pkg/ui"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestFilesWrite(t *testing.T) {
+	// Given
+	defer redact.Reset()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	directory := t.TempDir()
+	redact.Add("p4ssw0rd")
+
+	files := NewFiles(ui.NewMockView(ctrl), directory, "my/project", 1024, 2)
+
+	// When
+	files.Write("graphql", StdOut, "listening on :8080\n")
+	files.Write("graphql", StdErr, "connecting with p4ssw0rd")
+	files.Close()
+
+	// Then
+	content, err := os.ReadFile(GetFilepath(directory, "my/project", "graphql"))
+	assert.Nil(t, err)
+	assert.Equal(t, filepath.Join(directory, "my-project", "graphql.log"), GetFilepath(directory, "my/project", "graphql"))
+
+	lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+	assert.Len(t, lines, 2)
+	assert.Regexp(t, `^\d{4}-\d{2}-\d{2}T\S+ stdout listening on :8080$`, lines[0])
+	assert.Regexp(t, `^\d{4}-\d{2}-\d{2}T\S+ stderr connecting with \*\*\*\*\*\*$`, lines[1])
+}
+
+func TestFilesWriteWhenRotated(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	directory := t.TempDir()
+	path := GetFilepath(directory, "my-project", "graphql")
+
+	// Each line is about 40 bytes long (timestamp, type and message), so only 2 lines fit in a file
+	files := NewFiles(ui.NewMockView(ctrl), directory, "my-project", 100, 2)
+
+	// When
+	for _, line := range []string{"line 1", "line 2", "line 3", "line 4", "line 5", "line 6", "line 7"} {
+		files.Write("graphql", StdOut, line)
+	}
+	files.Close()
+
+	// Then
+	for path, expected := range map[string][]string{
+		path:        {"line 7"},
+		path + ".1": {"line 5", "line 6"},
+		path + ".2": {"line 3", "line 4"},
+	} {
+		content, err := os.ReadFile(path)
+		assert.Nil(t, err)
+		assert.Equal(t, len(expected), strings.Count(string(content), "\n"))
+
+		for _, line := range expected {
+			assert.Contains(t, string(content), " stdout "+line+"\n")
+		}
+	}
+
+	_, err := os.Stat(path + ".3")
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestFilesWriteWhenError(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	directory := filepath.Join(t.TempDir(), "file")
+	os.WriteFile(directory, []byte{}, 0644)
+
+	view := ui.NewMockView(ctrl)
+	view.EXPECT().Writef("❌  Unable to write the log file of '%s', its output will not be saved anymore: %v\n", "graphql", gomock.Any())
+
+	files := NewFiles(view, directory, "my-project", 100, 2)
+
+	// When - Then
+	files.Write("graphql", StdOut, "line 1")
+	files.Write("graphql", StdOut, "line 2")
+}
@@ -0,0 +1,175 @@
+package log
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// followInterval is the duration between two checks of new lines when following a log file
+	followInterval = 250 * time.Millisecond
+)
+
+// Read writes the lines of the given log file (and of its rotated files, the oldest first) written since the given
+// time to the writer. When follow is set, the lines appended to the file are written until the context is done.
+func Read(ctx context.Context, w io.Writer, path string, since time.Time, follow bool) error {
+	rotated := getRotatedFilepaths(path)
+
+	if _, err := os.Stat(path); err != nil && len(rotated) == 0 {
+		return fmt.Errorf("no log file found at '%s'", path)
+	}
+
+	filter := &sinceFilter{since: since, keep: since.IsZero()}
+
+	for _, rotatedPath := range rotated {
+		file, err := os.Open(rotatedPath)
+		if err != nil {
+			continue
+		}
+
+		err = filter.copy(w, file)
+		file.Close()
+
+		if err != nil {
+			return err
+		}
+	}
+
+	var file *os.File
+	defer func() {
+		if file != nil {
+			file.Close()
+		}
+	}()
+
+	for {
+		// Remaining lines of a rotated file are read before switching to the new one
+		wasRotated := file != nil && isRotated(file, path)
+
+		if file == nil {
+			file, _ = os.Open(path)
+		}
+
+		if file != nil {
+			if err := filter.copy(w, file); err != nil {
+				return err
+			}
+		}
+
+		if wasRotated {
+			file.Close()
+			file = nil
+			continue
+		}
+
+		if !follow {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(followInterval):
+		}
+	}
+}
+
+// ParseSince returns the time from which lines are read, given as a duration or a RFC3339 date
+func ParseSince(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if duration, err := time.ParseDuration(value); err == nil {
+		return time.Now().Add(-duration), nil
+	}
+
+	if date, err := time.Parse(time.RFC3339, value); err == nil {
+		return date, nil
+	}
+
+	return time.Time{}, fmt.Errorf("Invalid since value '%s', please use a duration (10m, 2h) or a RFC3339 date", value)
+}
+
+// getRotatedFilepaths returns the existing rotated files of the given log file, the oldest first
+func getRotatedFilepaths(path string) []string {
+	matches, _ := filepath.Glob(path + ".*")
+
+	indexes := make(map[string]int)
+	result := make([]string, 0, len(matches))
+
+	for _, match := range matches {
+		index, err := strconv.Atoi(strings.TrimPrefix(match, path+"."))
+		if err != nil {
+			continue
+		}
+
+		indexes[match] = index
+		result = append(result, match)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return indexes[result[i]] > indexes[result[j]]
+	})
+
+	return result
+}
+
+// isRotated indicates if the given opened file is not the one at the given path anymore
+func isRotated(file *os.File, path string) bool {
+	opened, err := file.Stat()
+	if err != nil {
+		return true
+	}
+
+	current, err := os.Stat(path)
+
+	return err == nil && !os.SameFile(opened, current)
+}
+
+// sinceFilter writes the lines written since a given time, lines without any timestamp following the
+// decision made for the previous line
+type sinceFilter struct {
+	since time.Time
+	keep  bool
+}
+
+// copy writes the complete lines read from the file, a trailing incomplete line being left in the file
+func (f *sinceFilter) copy(w io.Writer, file *os.File) error {
+	reader := bufio.NewReader(file)
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			if err != io.EOF {
+				return err
+			}
+
+			// Do not consume the incomplete line so it is read again once completed
+			_, err = file.Seek(-int64(len(line)), io.SeekCurrent)
+			return err
+		}
+
+		if date, _, ok := strings.Cut(line, " "); ok {
+			if t, err := time.Parse(TimeFormat, date); err == nil {
+				f.keep = !t.Before(f.since)
+			}
+		}
+
+		if !f.keep {
+			continue
+		}
+
+		if _, err := io.WriteString(w, line); err != nil {
+			return err
+		}
+	}
+}
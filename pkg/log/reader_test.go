@@ -0,0 +1,137 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRead(t *testing.T) {
+	// Given
+	path := filepath.Join(t.TempDir(), "graphql.log")
+
+	os.WriteFile(path+".2", []byte("2024-01-01T10:00:00.000Z stdout first\n"), 0644)
+	os.WriteFile(path+".1", []byte("2024-01-01T11:00:00.000Z stdout second\n2024-01-01T12:00:00.000Z stderr third\n"), 0644)
+	os.WriteFile(path, []byte("2024-01-01T13:00:00.000Z stdout fourth\nincomplete"), 0644)
+
+	testCases := []struct {
+		since    time.Time
+		expected string
+	}{
+		{
+			expected: "2024-01-01T10:00:00.000Z stdout first\n" +
+				"2024-01-01T11:00:00.000Z stdout second\n" +
+				"2024-01-01T12:00:00.000Z stderr third\n" +
+				"2024-01-01T13:00:00.000Z stdout fourth\n",
+		},
+		{
+			since: time.Date(2024, 1, 1, 11, 30, 0, 0, time.UTC),
+			expected: "2024-01-01T12:00:00.000Z stderr third\n" +
+				"2024-01-01T13:00:00.000Z stdout fourth\n",
+		},
+	}
+
+	for _, testCase := range testCases {
+		buffer := bytes.NewBuffer(nil)
+
+		// When
+		err := Read(context.Background(), buffer, path, testCase.since, false)
+
+		// Then
+		assert.Nil(t, err)
+		assert.Equal(t, testCase.expected, buffer.String())
+	}
+}
+
+func TestReadWhenNoFile(t *testing.T) {
+	// Given
+	path := filepath.Join(t.TempDir(), "graphql.log")
+
+	// When
+	err := Read(context.Background(), bytes.NewBuffer(nil), path, time.Time{}, false)
+
+	// Then
+	assert.EqualError(t, err, "no log file found at '"+path+"'")
+}
+
+func TestReadWhenFollow(t *testing.T) {
+	// Given
+	path := filepath.Join(t.TempDir(), "graphql.log")
+	os.WriteFile(path, []byte("2024-01-01T10:00:00.000Z stdout first\n"), 0644)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	writer := &lockedBuffer{}
+
+	done := make(chan error)
+	go func() {
+		done <- Read(ctx, writer, path, time.Time{}, true)
+	}()
+
+	// When
+	file, _ := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0644)
+	file.WriteString("2024-01-01T11:00:00.000Z stdout second\n")
+	file.Close()
+
+	// The file is rotated
+	os.Rename(path, path+".1")
+	os.WriteFile(path, []byte("2024-01-01T12:00:00.000Z stdout third\n"), 0644)
+
+	// Then
+	assert.Eventually(t, func() bool {
+		return strings.HasSuffix(writer.String(), "third\n")
+	}, 5*time.Second, 50*time.Millisecond)
+
+	cancel()
+	assert.Nil(t, <-done)
+
+	assert.Equal(t, "2024-01-01T10:00:00.000Z stdout first\n"+
+		"2024-01-01T11:00:00.000Z stdout second\n"+
+		"2024-01-01T12:00:00.000Z stdout third\n", writer.String())
+}
+
+// lockedBuffer is a buffer that can be written and read concurrently
+type lockedBuffer struct {
+	mutex  sync.Mutex
+	buffer bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return b.buffer.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return b.buffer.String()
+}
+
+func TestParseSince(t *testing.T) {
+	// When
+	empty, emptyErr := ParseSince("")
+	duration, durationErr := ParseSince("10m")
+	date, dateErr := ParseSince("2024-01-01T10:00:00Z")
+	_, invalidErr := ParseSince("yesterday")
+
+	// Then
+	assert.Nil(t, emptyErr)
+	assert.True(t, empty.IsZero())
+
+	assert.Nil(t, durationErr)
+	assert.WithinDuration(t, time.Now().Add(-10*time.Minute), duration, time.Second)
+
+	assert.Nil(t, dateErr)
+	assert.Equal(t, time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC), date)
+
+	assert.EqualError(t, invalidErr, "Invalid since value 'yesterday', please use a duration (10m, 2h) or a RFC3339 date")
+}
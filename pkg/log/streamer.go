@@ -5,6 +5,7 @@ import (
 	"io"
 	"os"
 	"regexp"
+	"strings"
 
 	"github.com/eko/monday/pkg/ui"
 )
@@ -25,9 +26,10 @@ var (
 )
 
 type Streamer struct {
-	buf     *bytes.Buffer
-	stdType string
-	name    string
+	buf       *bytes.Buffer
+	stdType   string
+	name      string
+	observers []func(line string)
 
 	view ui.View
 }
@@ -49,6 +51,18 @@ func NewStreamer(stdType string, name string, view ui.View) *Streamer {
 	return streamer
 }
 
+// Observe registers a function called with each line written to the streamer
+func (l *Streamer) Observe(observer func(line string)) {
+	l.observers = append(l.observers, observer)
+}
+
+// Record writes each line written to the streamer into the log file of its application
+func (l *Streamer) Record(files Files) {
+	l.Observe(func(line string) {
+		files.Write(l.name, l.stdType, line)
+	})
+}
+
 func (l *Streamer) Write(p []byte) (n int, err error) {
 	if n, err = l.buf.Write(p); err != nil {
 		return
@@ -101,6 +115,15 @@ func (l *Streamer) output() (err error) {
 }
 
 func (l *Streamer) out(str string) (err error) {
+	for _, observer := range l.observers {
+		observer(str)
+	}
+
+	if view, ok := l.view.(ui.StructuredView); ok && view.IsStructured() {
+		view.WriteEntry(ui.Entry{Name: l.name, Stream: l.stdType, Message: strings.TrimSuffix(str, "\n")})
+		return nil
+	}
+
 	switch l.stdType {
 	case StdOut:
 		str = ColorOkay + l.name + ColorReset + " " + str
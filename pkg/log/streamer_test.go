@@ -1,6 +1,8 @@
 package log
 
 import (
+	"bytes"
+	"encoding/json"
 	"testing"
 
 	"github.com/eko/monday/pkg/ui"
@@ -34,3 +36,45 @@ func TestNewStreamer(t *testing.T) {
 		assert.Equal(t, testCase.name, streamer.name)
 	}
 }
+
+func TestStreamerObserve(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	view := ui.NewMockView(ctrl)
+	view.EXPECT().Write(gomock.Any()).Times(2)
+
+	streamer := NewStreamer(StdOut, "test-stdout", view)
+
+	lines := make([]string, 0)
+	streamer.Observe(func(line string) {
+		lines = append(lines, line)
+	})
+
+	// When
+	streamer.Write([]byte("first line\nsecond line\nincomplete"))
+
+	// Then
+	assert.Equal(t, []string{"first line\n", "second line\n"}, lines)
+}
+
+func TestStreamerWriteWhenStructuredView(t *testing.T) {
+	// Given
+	buffer := bytes.NewBuffer(nil)
+	view := ui.NewJSONView("logs", ui.NewJSONOutput(buffer)).WithComponent("runner")
+
+	streamer := NewStreamer(StdErr, "test-stderr", view)
+
+	// When
+	streamer.Write([]byte("first line\n"))
+
+	// Then
+	var entry ui.Entry
+	assert.Nil(t, json.Unmarshal(buffer.Bytes(), &entry))
+
+	assert.Equal(t, "runner", entry.Component)
+	assert.Equal(t, "test-stderr", entry.Name)
+	assert.Equal(t, StdErr, entry.Stream)
+	assert.Equal(t, "first line", entry.Message)
+}
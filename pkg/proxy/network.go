@@ -37,36 +37,55 @@ func getNetworkInterface() (string, error) {
 	return "", errors.New("unable to find 'loopback' network interface")
 }
 
-// getAddIPCommandWithArgs returns the command (ifconfig, ip, ...) that will be used for the current OS
-// and its associated arguments
-func getAddIPCommandWithArgs(ip string) (string, []string) {
-	var command = "ifconfig"
-	var args []string
-
+// getIPCommand returns the command (ifconfig or ip) used for the current OS to add and remove
+// IP addresses on the loopback interface, so an address is always removed with the tool that added it
+func getIPCommand() string {
 	switch runtime.GOOS {
 	case "darwin":
-		args = []string{networkInterface, "alias", ip, "up"}
+		return "ifconfig"
 
 	case "linux":
 		// Check if "ifconfig" is available
-		_, err := exec.LookPath(command)
-		if err == nil {
-			// "ifconfig" case
-			args = []string{networkInterface, ip, "up"}
-		} else {
-			// "ip" case
-			command = "ip"
-			args = []string{"addr", "add", ip + "/32", "dev", networkInterface}
+		if _, err := exec.LookPath("ifconfig"); err == nil {
+			return "ifconfig"
 		}
 
+		return "ip"
+
 	default:
 		panic(fmt.Sprintf("Sorry, it seems your OS (%s) is not available yet.", runtime.GOOS))
 	}
+}
+
+// getAddIPCommandWithArgs returns the command (ifconfig, ip, ...) that will be used for the current OS
+// and its associated arguments
+func getAddIPCommandWithArgs(ip string) (string, []string) {
+	command := getIPCommand()
+
+	switch {
+	case runtime.GOOS == "darwin":
+		return command, []string{networkInterface, "alias", ip, "up"}
+
+	case command == "ifconfig":
+		// Addresses set on the interface itself replace its own one (127.0.0.1), so an alias interface is used
+		return command, []string{getAliasInterface(ip), ip, "netmask", "255.255.255.255", "up"}
+
+	default:
+		return command, []string{"addr", "add", ip + "/32", "dev", networkInterface}
+	}
+}
+
+// getAliasInterface returns the name of the Linux alias interface holding the given IP address
+// on the loopback interface, when added using ifconfig
+func getAliasInterface(ip string) string {
+	bytes := net.ParseIP(ip).To4()
 
-	return command, args
+	return fmt.Sprintf("%s:%d", networkInterface, int(bytes[1])<<16|int(bytes[2])<<8|int(bytes[3]))
 }
 
-func assignIpToPort(a, b, c, d byte, port string) (byte, byte, byte, byte, error) {
+// assignIpToPort finds an IP address on the loopback interface that can be used with the given port,
+// adding it as an alias (removed when the proxy is stopped) when it does not exist yet
+func (p *proxy) assignIpToPort(a, b, c, d byte, port string) (byte, byte, byte, byte, error) {
 	// Retrieve network interface
 	iface, err := net.InterfaceByName(networkInterface)
 	if err != nil {
@@ -93,6 +112,8 @@ func assignIpToPort(a, b, c, d byte, port string) (byte, byte, byte, byte, error
 			if err := exec.Command(command, args...).Run(); err != nil {
 				return a, b, c, d, fmt.Errorf("error while trying to run ifconfig/ip command to add new IP address (%s) on network interface '%s': %v", ip.String(), networkInterface, err)
 			}
+
+			p.aliases = append(p.aliases, ip.String())
 		}
 
 		// Can't be contacted on ip/port? it means this couple is free to be used
@@ -106,9 +127,37 @@ func assignIpToPort(a, b, c, d byte, port string) (byte, byte, byte, byte, error
 	return a, b, c, d, fmt.Errorf("unable to find an available IP/Port (ip: %d.%d.%d.%d:%s)", a, b, c, d, port)
 }
 
+// getRemoveIPCommandWithArgs returns the command (ifconfig, ip, ...) that will be used for the current OS
+// to remove an IP address added on the loopback interface, and its associated arguments
+func getRemoveIPCommandWithArgs(ip string) (string, []string) {
+	command := getIPCommand()
+
+	switch {
+	case runtime.GOOS == "darwin":
+		return command, []string{networkInterface, "-alias", ip}
+
+	case command == "ifconfig":
+		return command, []string{getAliasInterface(ip), "down"}
+
+	default:
+		return command, []string{"addr", "del", ip + "/32", "dev", networkInterface}
+	}
+}
+
+// removeIP removes the given IP address from the loopback interface
+func removeIP(ip string) error {
+	command, args := getRemoveIPCommandWithArgs(ip)
+
+	if output, err := exec.Command(command, args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("%v: %s", err, strings.TrimSpace(string(output)))
+	}
+
+	return nil
+}
+
 func isAlreadyAssigned(ip net.IP, addrs []net.Addr) bool {
 	for _, addr := range addrs {
-		if addr.String() == ip.String()+"/8" {
+		if ipNet, ok := addr.(*net.IPNet); ok && ipNet.IP.Equal(ip) {
 			return true
 		}
 	}
@@ -0,0 +1,46 @@
+package proxy
+
+import (
+	"net"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetIPCommandWithArgs(t *testing.T) {
+	// When
+	addCommand, addArgs := getAddIPCommandWithArgs("127.0.1.2")
+	removeCommand, removeArgs := getRemoveIPCommandWithArgs("127.0.1.2")
+
+	// Then
+	assert.Equal(t, getIPCommand(), addCommand)
+	assert.Equal(t, addCommand, removeCommand)
+
+	switch {
+	case runtime.GOOS == "darwin":
+		assert.Equal(t, []string{networkInterface, "alias", "127.0.1.2", "up"}, addArgs)
+		assert.Equal(t, []string{networkInterface, "-alias", "127.0.1.2"}, removeArgs)
+
+	case addCommand == "ifconfig":
+		assert.Equal(t, []string{networkInterface + ":258", "127.0.1.2", "netmask", "255.255.255.255", "up"}, addArgs)
+		assert.Equal(t, []string{networkInterface + ":258", "down"}, removeArgs)
+
+	default:
+		assert.Equal(t, []string{"addr", "add", "127.0.1.2/32", "dev", networkInterface}, addArgs)
+		assert.Equal(t, []string{"addr", "del", "127.0.1.2/32", "dev", networkInterface}, removeArgs)
+	}
+}
+
+func TestIsAlreadyAssigned(t *testing.T) {
+	// Given
+	addrs := []net.Addr{
+		&net.IPNet{IP: net.IPv4(127, 0, 0, 1), Mask: net.CIDRMask(8, 32)},
+		&net.IPNet{IP: net.IPv4(127, 0, 1, 2), Mask: net.CIDRMask(32, 32)},
+	}
+
+	// When - Then
+	assert.True(t, isAlreadyAssigned(net.IPv4(127, 0, 0, 1), addrs))
+	assert.True(t, isAlreadyAssigned(net.IPv4(127, 0, 1, 2), addrs))
+	assert.False(t, isAlreadyAssigned(net.IPv4(127, 0, 1, 3), addrs))
+}
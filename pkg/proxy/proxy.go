@@ -1,6 +1,8 @@
 package proxy
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net"
@@ -19,9 +21,11 @@ const (
 )
 
 type Proxy interface {
-	Listen() error
+	Listen(ctx context.Context) error
 	Stop() error
 	AddProxyForward(name string, proxyForward *ProxyForward)
+	RemoveProxyForward(name string)
+	GetProxyForwards() map[string][]ProxyForward
 }
 
 // proxy represents the proxy component instance
@@ -38,6 +42,7 @@ type proxy struct {
 	lastIpByteC        byte
 	lastIpByteD        byte
 	attributedIPs      map[string]string
+	aliases            []string
 	view               ui.View
 }
 
@@ -58,9 +63,17 @@ func NewProxy(view ui.View, hostfile hostfile.Hostfile) *proxy {
 	}
 }
 
-// Listen opens a TCP proxy for each ProxyForward instance
-func (p *proxy) Listen() error {
+// Listen opens a TCP proxy for each ProxyForward instance, closed once the given context is done
+func (p *proxy) Listen(ctx context.Context) error {
+	// Forwards can be added while listening (on configuration reload), so a copy is iterated
+	p.addProxyForwardMux.Lock()
+	proxyForwards := make(map[string][]*ProxyForward, len(p.ProxyForwards))
 	for name, pfs := range p.ProxyForwards {
+		proxyForwards[name] = append([]*ProxyForward{}, pfs...)
+	}
+	p.addProxyForwardMux.Unlock()
+
+	for name, pfs := range proxyForwards {
 		for _, pf := range pfs {
 			if pf.LocalPort == "" {
 				// In case no local port is specified: don't handle connections
@@ -69,58 +82,115 @@ func (p *proxy) Listen() error {
 
 			key := fmt.Sprintf("%s_%s", name, pf.LocalPort)
 
+			p.listenerMux.Lock()
+			_, ok := p.listeners[key]
+			p.listenerMux.Unlock()
+
 			// We already have a listening port
-			if _, ok := p.listeners[key]; ok {
-				return nil
+			if ok {
+				continue
 			}
 
 			p.view.Writef("🔌  Proxifying %s locally (%s:%s) <-> forwarding to %s:%s\n", pf.GetHostname(), pf.LocalIP, pf.LocalPort, pf.GetProxyHostname(), pf.ProxyPort)
 
-			go p.handleConnections(pf, key)
+			listener, err := net.Listen("tcp", net.JoinHostPort(pf.LocalIP, pf.LocalPort))
+			if err != nil {
+				p.view.Writef("❌  Could not create proxy listener for '%s:%s' (%s): %v\n", pf.LocalIP, pf.LocalPort, pf.GetHostname(), err)
+				continue
+			}
+
+			p.listenerMux.Lock()
+			p.listeners[key] = listener
+			p.listenerMux.Unlock()
+
+			context.AfterFunc(ctx, func() {
+				listener.Close()
+			})
+
+			go p.handleConnections(ctx, pf, key, listener)
 		}
 	}
 
 	return nil
 }
 
-// Stop stops all currently active proxy listeners
+// RemoveProxyForward closes the listeners of the given ProxyForward name and removes its hostnames from hosts file
+func (p *proxy) RemoveProxyForward(name string) {
+	p.addProxyForwardMux.Lock()
+	defer p.addProxyForwardMux.Unlock()
+
+	pfs, ok := p.ProxyForwards[name]
+	if !ok {
+		return
+	}
+
+	for _, pf := range pfs {
+		key := fmt.Sprintf("%s_%s", name, pf.LocalPort)
+
+		p.listenerMux.Lock()
+		if listener, ok := p.listeners[key]; ok {
+			delete(p.listeners, key)
+			listener.Close()
+		}
+		p.listenerMux.Unlock()
+
+		if _, ok := p.attributedIPs[pf.GetHostname()]; !ok {
+			continue
+		}
+
+		err := p.hostfile.RemoveHost(pf.GetHostname())
+		if err != nil {
+			p.view.Writef("❌  An error has occured while trying to remove host from file for application '%s' (ip: %s): %v\n", pf.Name, pf.LocalIP, err)
+		}
+
+		delete(p.attributedIPs, pf.GetHostname())
+	}
+
+	delete(p.ProxyForwards, name)
+}
+
+// Stop stops all currently active proxy listeners, then removes the hosts entries and the loopback aliases
+// created by the proxy. The returned error lists all the ones that could not be cleaned up.
 func (p *proxy) Stop() error {
 	p.listening = false
 
+	var errs []error
+
+	p.listenerMux.Lock()
 	for name, listener := range p.listeners {
-		err := listener.Close()
-		if err != nil {
-			p.view.Writef("❌  An error has occured while stopping proxy listener '%s': %v\n", name, err)
+		if err := listener.Close(); err != nil && !errors.Is(err, net.ErrClosed) {
+			errs = append(errs, fmt.Errorf("proxy listener '%s' could not be closed: %v", name, err))
 		}
 	}
+	p.listenerMux.Unlock()
+
+	p.addProxyForwardMux.Lock()
+	defer p.addProxyForwardMux.Unlock()
 
 	for _, proxyForwards := range p.ProxyForwards {
 		for _, pf := range proxyForwards {
-			err := p.hostfile.RemoveHost(pf.GetHostname())
-			if err != nil {
-				p.view.Writef("❌  An error has occured while trying to remove host from file for application '%s' (ip: %s): %v\n", pf.Name, pf.LocalIP, err)
+			if err := p.hostfile.RemoveHost(pf.GetHostname()); err != nil {
+				errs = append(errs, fmt.Errorf("hosts entry '%s' (ip: %s) could not be removed: %v", pf.GetHostname(), pf.LocalIP, err))
 			}
 		}
 	}
 
-	return nil
-}
-
-func (p *proxy) handleConnections(pf *ProxyForward, key string) {
-	listener, err := net.Listen("tcp", fmt.Sprintf("%s:%s", pf.LocalIP, pf.LocalPort))
-	if err != nil {
-		p.view.Writef("❌  Could not create proxy listener for '%s:%s' (%s): %v\n", pf.LocalIP, pf.LocalPort, pf.GetHostname(), err)
-		return
+	for _, ip := range p.aliases {
+		if err := removeIP(ip); err != nil {
+			errs = append(errs, fmt.Errorf("loopback alias '%s' could not be removed from '%s': %v", ip, networkInterface, err))
+		}
 	}
 
-	p.listenerMux.Lock()
-	p.listeners[key] = listener
-	p.listenerMux.Unlock()
+	p.aliases = nil
+
+	return errors.Join(errs...)
+}
 
+func (p *proxy) handleConnections(ctx context.Context, pf *ProxyForward, key string, listener net.Listener) {
 	// Accept clients and proxify calls
 	for {
 		client, err := listener.Accept()
-		if !p.listening {
+		if !p.listening || ctx.Err() != nil || p.isRemoved(key, listener) {
 			break
 		}
 		if err != nil {
@@ -130,7 +200,7 @@ func (p *proxy) handleConnections(pf *ProxyForward, key string) {
 
 		defer client.Close()
 
-		target, err := net.Dial("tcp", fmt.Sprintf("%s:%s", pf.GetProxyHostname(), pf.ProxyPort))
+		target, err := net.Dial("tcp", net.JoinHostPort(pf.GetProxyHostname(), pf.ProxyPort))
 		if err != nil {
 			p.view.Writef("❌  Error when dialing with target for '%s:%s' (%s): %v\n", pf.GetProxyHostname(), pf.LocalPort, pf.ProxyPort, err)
 			return
@@ -143,6 +213,32 @@ func (p *proxy) handleConnections(pf *ProxyForward, key string) {
 	}
 }
 
+// isRemoved indicates if the given listener has been removed in the meantime
+func (p *proxy) isRemoved(key string, listener net.Listener) bool {
+	p.listenerMux.Lock()
+	defer p.listenerMux.Unlock()
+
+	current, ok := p.listeners[key]
+
+	return !ok || current != listener
+}
+
+// GetProxyForwards returns a copy of the proxy forwards (hostnames mapped on a local IP and port) of each
+// application and forward name
+func (p *proxy) GetProxyForwards() map[string][]ProxyForward {
+	p.addProxyForwardMux.Lock()
+	defer p.addProxyForwardMux.Unlock()
+
+	result := make(map[string][]ProxyForward, len(p.ProxyForwards))
+	for name, pfs := range p.ProxyForwards {
+		for _, pf := range pfs {
+			result[name] = append(result[name], *pf)
+		}
+	}
+
+	return result
+}
+
 // AddProxyForward creates a new ProxyForward instance and attributes an IP address and a proxy port to it
 func (p *proxy) AddProxyForward(name string, proxyForward *ProxyForward) {
 	p.addProxyForwardMux.Lock()
@@ -185,7 +281,7 @@ func (p *proxy) generateIP(pf *ProxyForward) error {
 	p.lastIpByteC = c
 	p.lastIpByteD = d
 
-	a, b, c, d, err = assignIpToPort(a, b, c, d, pf.LocalPort)
+	a, b, c, d, err = p.assignIpToPort(a, b, c, d, pf.LocalPort)
 	if err != nil {
 		return err
 	}
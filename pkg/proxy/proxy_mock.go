@@ -10,6 +10,7 @@
 package proxy
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "go.uber.org/mock/gomock"
@@ -50,18 +51,44 @@ func (mr *MockProxyMockRecorder) AddProxyForward(name, proxyForward any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProxyForward", reflect.TypeOf((*MockProxy)(nil).AddProxyForward), name, proxyForward)
 }
 
+// GetProxyForwards mocks base method.
+func (m *MockProxy) GetProxyForwards() map[string][]ProxyForward {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProxyForwards")
+	ret0, _ := ret[0].(map[string][]ProxyForward)
+	return ret0
+}
+
+// GetProxyForwards indicates an expected call of GetProxyForwards.
+func (mr *MockProxyMockRecorder) GetProxyForwards() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProxyForwards", reflect.TypeOf((*MockProxy)(nil).GetProxyForwards))
+}
+
 // Listen mocks base method.
-func (m *MockProxy) Listen() error {
+func (m *MockProxy) Listen(ctx context.Context) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Listen")
+	ret := m.ctrl.Call(m, "Listen", ctx)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Listen indicates an expected call of Listen.
-func (mr *MockProxyMockRecorder) Listen() *gomock.Call {
+func (mr *MockProxyMockRecorder) Listen(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Listen", reflect.TypeOf((*MockProxy)(nil).Listen), ctx)
+}
+
+// RemoveProxyForward mocks base method.
+func (m *MockProxy) RemoveProxyForward(name string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RemoveProxyForward", name)
+}
+
+// RemoveProxyForward indicates an expected call of RemoveProxyForward.
+func (mr *MockProxyMockRecorder) RemoveProxyForward(name any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Listen", reflect.TypeOf((*MockProxy)(nil).Listen))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveProxyForward", reflect.TypeOf((*MockProxy)(nil).RemoveProxyForward), name)
 }
 
 // Stop mocks base method.
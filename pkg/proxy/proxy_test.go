@@ -4,7 +4,10 @@
 package proxy
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net"
 	"testing"
 
 	"github.com/eko/monday/pkg/hostfile"
@@ -130,7 +133,7 @@ func TestListen(t *testing.T) {
 	proxy.AddProxyForward("test", pf)
 
 	// When
-	err := proxy.Listen()
+	err := proxy.Listen(context.Background())
 
 	// Then
 	assert.Nil(t, err)
@@ -144,6 +147,39 @@ func TestListen(t *testing.T) {
 	assert.Equal(t, proxy.lastIpByteD, byte(1))
 }
 
+func TestListenWhenProxyForwardsAreAdded(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	hostfileMock := hostfile.NewMockHostfile(ctrl)
+	hostfileMock.EXPECT().AddHost(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	view := ui.NewMockView(ctrl)
+	view.EXPECT().Writef(gomock.Any(), gomock.Any()).AnyTimes()
+
+	proxy := NewProxy(view, hostfileMock)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		for i := 0; i < 50; i++ {
+			name := fmt.Sprintf("test-%d", i)
+			proxy.AddProxyForward(name, NewProxyForward(name, name+".svc.local", "", "", "8080"))
+		}
+	}()
+
+	// When
+	for i := 0; i < 50; i++ {
+		assert.Nil(t, proxy.Listen(context.Background()))
+	}
+
+	// Then
+	<-done
+	assert.Len(t, proxy.GetProxyForwards(), 50)
+}
+
 func TestGetNextIPAddress(t *testing.T) {
 	testCases := []struct {
 		a byte
@@ -213,3 +249,97 @@ func TestGetNextIPAddress(t *testing.T) {
 		})
 	}
 }
+
+func TestGetProxyForwards(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pf := NewProxyForward("test", "hostname.svc.local", "", "8080", "8080")
+	pf.SetLocalIP("127.0.1.1")
+
+	proxy := NewProxy(ui.NewMockView(ctrl), hostfile.NewMockHostfile(ctrl))
+	proxy.ProxyForwards["test"] = []*ProxyForward{pf}
+
+	// When
+	result := proxy.GetProxyForwards()
+
+	// Then
+	assert.Equal(t, map[string][]ProxyForward{"test": {*pf}}, result)
+
+	// Returned proxy forwards are copies
+	result["test"][0].LocalIP = "127.0.1.2"
+	assert.Equal(t, "127.0.1.1", pf.LocalIP)
+}
+
+func TestRemoveProxyForward(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	hostfileMock := hostfile.NewMockHostfile(ctrl)
+	hostfileMock.EXPECT().RemoveHost("hostname.svc.local").Return(nil)
+
+	view := ui.NewMockView(ctrl)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pf := NewProxyForward("test", "hostname.svc.local", "", "8080", "8080")
+	pf.SetLocalIP("127.0.1.1")
+
+	proxy := NewProxy(view, hostfileMock)
+	proxy.ProxyForwards["test"] = []*ProxyForward{pf}
+	proxy.listeners["test_8080"] = listener
+	proxy.attributedIPs["hostname.svc.local"] = "127.0.1.1"
+
+	// When
+	proxy.RemoveProxyForward("test")
+
+	// Then
+	assert.Len(t, proxy.ProxyForwards, 0)
+	assert.Len(t, proxy.listeners, 0)
+	assert.Len(t, proxy.attributedIPs, 0)
+
+	_, err = listener.Accept()
+	assert.NotNil(t, err)
+}
+
+func TestStop(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	hostfileMock := hostfile.NewMockHostfile(ctrl)
+	hostfileMock.EXPECT().RemoveHost("hostname.svc.local").Return(nil)
+	hostfileMock.EXPECT().RemoveHost("other.svc.local").Return(errors.New("permission denied"))
+
+	view := ui.NewMockView(ctrl)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pf := NewProxyForward("test", "hostname.svc.local", "", "8080", "8080")
+	pf.SetLocalIP("127.0.1.1")
+
+	otherPf := NewProxyForward("other", "other.svc.local", "", "8080", "8080")
+	otherPf.SetLocalIP("127.0.1.2")
+
+	proxy := NewProxy(view, hostfileMock)
+	proxy.ProxyForwards["test"] = []*ProxyForward{pf}
+	proxy.ProxyForwards["other"] = []*ProxyForward{otherPf}
+	proxy.listeners["test_8080"] = listener
+
+	// When
+	err = proxy.Stop()
+
+	// Then
+	assert.EqualError(t, err, "hosts entry 'other.svc.local' (ip: 127.0.1.2) could not be removed: permission denied")
+
+	_, err = listener.Accept()
+	assert.NotNil(t, err)
+}
@@ -1,49 +1,86 @@
 package run
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"os/exec"
+	"strings"
+	"sync"
 	"syscall"
+	"time"
 
+	"github.com/eko/monday/internal/ready"
+	"github.com/eko/monday/internal/wait"
 	"github.com/eko/monday/pkg/config"
+	"github.com/eko/monday/pkg/health"
 	"github.com/eko/monday/pkg/helper"
 	"github.com/eko/monday/pkg/log"
 	"github.com/eko/monday/pkg/proxy"
+	"github.com/eko/monday/pkg/status"
 	"github.com/eko/monday/pkg/ui"
 )
 
+var (
+	// Restart delays of crashed applications, increased exponentially
+	restartMinDelay = 1 * time.Second
+	restartMaxDelay = 1 * time.Minute
+)
+
 type Runner interface {
-	RunAll()
-	Run(application *config.Application)
-	Restart(application *config.Application)
-	Stop() error
+	RunAll(ctx context.Context)
+	Run(ctx context.Context, application *config.Application)
+	Restart(ctx context.Context, application *config.Application)
+	Add(ctx context.Context, application *config.Application)
+	Remove(application *config.Application)
+	StopApplication(ctx context.Context, application *config.Application) error
+	Stop(ctx context.Context) error
 }
 
 // runner is the struct that manage running local applications
 type runner struct {
 	proxy        proxy.Proxy
+	checker      health.Checker
+	registry     status.Registry
+	files        log.Files
 	projectName  string
 	applications []*config.Application
 	cmds         map[string]*exec.Cmd
+	exits        map[string]chan struct{}
+	mutex        sync.Mutex
+	cancels      sync.Map
 	view         ui.View
 	conf         *config.GlobalRun
 }
 
-// NewRunner instanciates a Runner struct from configuration data
-func NewRunner(view ui.View, proxy proxy.Proxy, project *config.Project, conf *config.GlobalRun) *runner {
+// NewRunner instanciates a Runner struct from configuration data, the applications status being kept in the given registry
+// and their output recorded in the given log files
+func NewRunner(view ui.View, proxy proxy.Proxy, checker health.Checker, registry status.Registry, files log.Files, project *config.Project, conf *config.GlobalRun) *runner {
 	return &runner{
 		proxy:        proxy,
+		checker:      checker,
+		registry:     registry,
+		files:        files,
 		projectName:  project.Name,
 		applications: project.Applications,
 		cmds:         make(map[string]*exec.Cmd, 0),
+		exits:        make(map[string]chan struct{}, 0),
 		view:         view,
 		conf:         conf,
 	}
 }
 
-// RunAll runs all local applications in separated goroutines
-func (r *runner) RunAll() {
-	for _, application := range r.applications {
-		go r.Run(application)
+// RunAll runs all local applications in separated goroutines, each one waiting for its dependencies to be ready.
+// Applications are not restarted anymore once the given context is done.
+func (r *runner) RunAll(ctx context.Context) {
+	applications, err := config.SortByDependencies(r.getApplications())
+	if err != nil {
+		r.view.Writef("❌  %v\n", err)
+		applications = r.getApplications()
+	}
+
+	for _, application := range applications {
+		go r.runWhenReady(ctx, application)
 
 		if application.Hostname != "" {
 			proxyForward := proxy.NewProxyForward(application.Name, application.Hostname, "", "", "")
@@ -52,31 +89,137 @@ func (r *runner) RunAll() {
 	}
 }
 
-// Run launches the application
-func (r *runner) Run(application *config.Application) {
+// Run launches the application and restarts it depending on its restart policy
+func (r *runner) Run(ctx context.Context, application *config.Application) {
+	r.start(r.newContext(ctx, application.Name), application)
+}
+
+// runWhenReady waits for the applications and forwards the application depends on to be ready, then launches it
+func (r *runner) runWhenReady(ctx context.Context, application *config.Application) {
+	ctx = r.newContext(ctx, application.Name)
+
+	r.registry.UpdateApplication(application.Name, func(app *status.Application) {
+		app.State = status.StatePending
+	})
+
+	if len(application.DependsOn) > 0 {
+		r.view.Writef("⏳  Waiting for %s to be ready before running local app '%s'...\n", strings.Join(application.DependsOn, ", "), application.Name)
+
+		if err := ready.Wait(ctx, application.DependsOn...); err != nil {
+			return
+		}
+	}
+
+	r.start(ctx, application)
+}
+
+// newContext returns the context of a new launch of the application, cancelled when the application is stopped
+func (r *runner) newContext(ctx context.Context, name string) context.Context {
+	ctx, cancel := context.WithCancel(ctx)
+
+	if previous, loaded := r.cancels.Swap(name, cancel); loaded {
+		previous.(context.CancelFunc)()
+	}
+
+	return ctx
+}
+
+// start launches the application until it is stopped, restarting it when it exits depending on its
+// restart policy. Restarts are delayed exponentially, the delay being reset once the application stayed up long enough.
+func (r *runner) start(ctx context.Context, application *config.Application) {
 	if err := helper.CheckPathExists(application.GetPath()); err != nil {
-		r.view.Writef("❌  %s\n", err.Error())
+		ui.ViewWithName(r.view, application.Name).Writef("❌  %s\n", err.Error())
 		return
 	}
 
-	r.run(application)
+	backoff := &wait.Backoff{
+		Min:    restartMinDelay,
+		Max:    restartMaxDelay,
+		Factor: 2,
+	}
+
+	restarts := 0
+
+	for {
+		startedAt := time.Now()
+
+		started, err := r.run(ctx, application)
+		if !started || ctx.Err() != nil || !shouldRestart(application.Run, err) {
+			return
+		}
+
+		if time.Since(startedAt) >= application.Run.GetResetAfter() {
+			backoff.Reset()
+			restarts = 0
+		}
+
+		if max := application.Run.MaxRestarts; max > 0 && restarts >= max {
+			r.view.Writef("❌  Local app '%s' has been restarted %d times, giving up\n", application.Name, restarts)
+			return
+		}
+
+		delay := backoff.Duration()
+		r.view.Writef("🔁  Restarting local app '%s' in %s...\n", application.Name, delay)
+
+		r.registry.UpdateApplication(application.Name, func(app *status.Application) {
+			app.State = status.StateRestarting
+		})
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+
+		restarts++
+
+		r.registry.UpdateApplication(application.Name, func(app *status.Application) {
+			app.Restarts++
+		})
+	}
+}
+
+// shouldRestart indicates if an application that exited with the given error has to be restarted
+func shouldRestart(run *config.Run, err error) bool {
+	switch run.GetRestart() {
+	case config.RestartAlways:
+		return true
+	case config.RestartOnFailure:
+		return err != nil
+	}
+
+	return false
 }
 
-// Run launches the application
-func (r *runner) run(application *config.Application) {
+// run launches the application and waits for it to exit, it returns whether the application has been started
+func (r *runner) run(ctx context.Context, application *config.Application) (bool, error) {
 	var run = application.Run
 
+	// Messages are attributed to the application, even when they do not quote its name
+	view := ui.ViewWithName(r.view, application.Name)
+
 	if run == nil {
-		r.view.Writef("❌  Please declare a 'run' section for application %s\n", application.Name)
-		return
+		view.Writef("❌  Please declare a 'run' section for application %s\n", application.Name)
+		return false, nil
 	}
 
-	r.view.Writef("🏁  Running local app '%s' (%s)...\n", application.Name, application.Path)
+	view.Writef("🏁  Running local app '%s' (%s)...\n", application.Name, application.Path)
 
 	applicationPath := application.GetPath()
 
 	stdoutStream := log.NewStreamer(log.StdOut, application.Name, r.view)
 	stderrStream := log.NewStreamer(log.StdErr, application.Name, r.view)
+	stdoutStream.Record(r.files)
+	stderrStream.Record(r.files)
+
+	if health := application.Health; health != nil && health.Log != nil {
+		observer := func(line string) {
+			r.checker.Observe(application.Name, line)
+		}
+
+		stdoutStream.Observe(observer)
+		stderrStream.Observe(observer)
+	}
 
 	cmd := helper.BuildCmd([]string{run.Command}, applicationPath, stdoutStream, stderrStream)
 
@@ -86,51 +229,278 @@ func (r *runner) run(application *config.Application) {
 		envs = helper.MergeMapString(run.Env, r.conf.Env)
 	}
 
-	helper.AddEnvVariables(cmd, envs)
+	if err := helper.AddEnvVariables(cmd, envs); err != nil {
+		view.Writef("❌  %v\n", err)
+		return false, nil
+	}
 	if err := helper.AddEnvVariablesFromFile(cmd, run.GetEnvFile()); err != nil {
-		r.view.Writef("❌  %v\n", err)
-		return
+		view.Writef("❌  %v\n", err)
+		return false, nil
+	}
+
+	if err := cmd.Start(); err != nil {
+		view.Writef("❌  Cannot run the application %s on path %s: %v\n", application.Name, applicationPath, err)
+
+		r.registry.UpdateApplication(application.Name, func(app *status.Application) {
+			app.State = status.StateCrashed
+		})
+
+		return false, err
 	}
 
+	r.registry.UpdateApplication(application.Name, func(app *status.Application) {
+		app.State = status.StateRunning
+		app.PID = cmd.Process.Pid
+		app.StartedAt = time.Now()
+	})
+
+	// Closed once the application has exited so it can be awaited when stopped
+	exit := make(chan struct{})
+	defer close(exit)
+
+	r.mutex.Lock()
 	r.cmds[application.Name] = cmd
+	r.exits[application.Name] = exit
+	r.mutex.Unlock()
 
-	if err := cmd.Run(); err != nil {
-		r.view.Writef("❌  Cannot run the application %s on path %s: %v\n", application.Name, applicationPath, err)
-		return
+	// Applications depending on this one can now be launched, once healthy if a health check is defined
+	if application.Health != nil {
+		r.checker.Start(application)
+	} else {
+		ready.Set(application.Name)
 	}
+
+	err := cmd.Wait()
+
+	// The process is not stopped anymore once exited, as its PID can be reused
+	r.mutex.Lock()
+	current := r.cmds[application.Name] == cmd
+	if current {
+		delete(r.cmds, application.Name)
+		delete(r.exits, application.Name)
+	}
+	r.mutex.Unlock()
+
+	// Health checks are started again when the application is restarted
+	if current && application.Health != nil {
+		r.checker.Stop(application.Name)
+	}
+
+	// Applications depending on this one wait for it to be launched again
+	ready.Unset(application.Name)
+
+	r.registry.UpdateApplication(application.Name, func(app *status.Application) {
+		app.PID = 0
+		app.ExitCode = cmd.ProcessState.ExitCode()
+
+		switch {
+		case ctx.Err() != nil:
+			app.State = status.StateStopped
+		case err != nil:
+			app.State = status.StateCrashed
+		default:
+			app.State = status.StateExited
+		}
+	})
+
+	if err != nil {
+		view.Writef("❌  Cannot run the application %s on path %s: %v\n", application.Name, applicationPath, err)
+	}
+
+	return true, err
 }
 
 // Restart kills the current application launch (if it exists) and launch a new one
-func (r *runner) Restart(application *config.Application) {
-	r.stopApplication(application)
-	go r.Run(application)
+func (r *runner) Restart(ctx context.Context, application *config.Application) {
+	r.StopApplication(ctx, application)
+	go r.Run(ctx, application)
 }
 
-// Stop stops all the currently active local applications
-func (r *runner) Stop() error {
-	for _, application := range r.applications {
-		r.stopApplication(application)
+// Add registers a new local application (replacing the one with the same name) and runs it
+func (r *runner) Add(ctx context.Context, application *config.Application) {
+	r.mutex.Lock()
+	r.applications = append(removeApplication(r.applications, application.Name), application)
+	r.mutex.Unlock()
+
+	if application.Hostname != "" {
+		proxyForward := proxy.NewProxyForward(application.Name, application.Hostname, "", "", "")
+		r.proxy.AddProxyForward(application.Name, proxyForward)
 	}
 
-	return nil
+	go r.runWhenReady(ctx, application)
+}
+
+// Remove stops a local application and unregisters it
+func (r *runner) Remove(application *config.Application) {
+	r.StopApplication(context.Background(), application)
+
+	r.mutex.Lock()
+	r.applications = removeApplication(r.applications, application.Name)
+	delete(r.cmds, application.Name)
+	delete(r.exits, application.Name)
+	r.mutex.Unlock()
+
+	r.registry.RemoveApplication(application.Name)
+
+	if application.Hostname != "" {
+		r.proxy.RemoveProxyForward(application.Name)
+	}
 }
 
-func (r *runner) stopApplication(application *config.Application) {
-	if cmd, ok := r.cmds[application.Name]; ok {
+// Stop stops all the currently active local applications in parallel. When applications depend on
+// each other, an application is only stopped once the applications depending on it are stopped.
+// Applications still running when the given context is done are killed.
+func (r *runner) Stop(ctx context.Context) error {
+	applications := r.getApplications()
+
+	// In case of a dependency cycle, no order can be respected so all applications are stopped at once
+	_, err := config.SortByDependencies(applications)
+	ordered := err == nil
+
+	stopped := make(map[string]chan struct{}, len(applications))
+	for _, application := range applications {
+		stopped[application.Name] = make(chan struct{})
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, len(applications))
+
+	for _, application := range applications {
+		wg.Add(1)
+
+		go func(application *config.Application) {
+			defer wg.Done()
+			defer close(stopped[application.Name])
+
+			if ordered {
+				for _, dependent := range dependentsOf(applications, application.Name) {
+					<-stopped[dependent.Name]
+				}
+			}
+
+			if err := r.StopApplication(ctx, application); err != nil {
+				errs <- err
+			}
+		}(application)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	var result []error
+	for err := range errs {
+		result = append(result, err)
+	}
+
+	return errors.Join(result...)
+}
+
+// StopApplication stops the given application (without restarting it) and runs its stop commands, it returns
+// an error when the application could not be stopped or its stop commands failed
+func (r *runner) StopApplication(ctx context.Context, application *config.Application) error {
+	var result error
+
+	// Prevent the application from being restarted
+	if cancel, ok := r.cancels.LoadAndDelete(application.Name); ok {
+		cancel.(context.CancelFunc)()
+	}
+
+	ready.Unset(application.Name)
+
+	if application.Health != nil {
+		r.checker.Stop(application.Name)
+	}
+
+	r.registry.UpdateApplication(application.Name, func(app *status.Application) {
+		app.State = status.StateStopped
+	})
+
+	r.mutex.Lock()
+	cmd, ok := r.cmds[application.Name]
+	exit := r.exits[application.Name]
+	r.mutex.Unlock()
+
+	if ok {
 		pgid, err := syscall.Getpgid(cmd.Process.Pid)
 		if err == nil {
-			syscall.Kill(-pgid, syscall.SIGKILL)
-			cmd.Wait()
+			if err := r.terminate(ctx, application, pgid, exit); err != nil {
+				result = err
+			}
 		}
 	}
 
 	// In case we have stop command, run it
-	if len(application.Run.StopCommands) > 0 {
+	if application.Run != nil && len(application.Run.StopCommands) > 0 {
 		cmd := helper.BuildCmd(application.Run.StopCommands, application.GetPath(), nil, nil)
 		if err := cmd.Run(); err != nil {
 			r.view.Writef("❌  Cannot run stop command for application '%s': %v\n", application.Name, err)
+			result = errors.Join(result, fmt.Errorf("stop commands of local app '%s' failed: %v", application.Name, err))
 		}
 
 		cmd.Wait()
 	}
+
+	return result
+}
+
+// terminate sends the stop signal to the process group of the application and waits for it to exit,
+// killing it when it is still running after the stop timeout or once the context is done
+func (r *runner) terminate(ctx context.Context, application *config.Application, pgid int, exit chan struct{}) error {
+	timeout := application.Run.GetStopTimeout()
+
+	syscall.Kill(-pgid, application.Run.GetStopSignal())
+
+	select {
+	case <-exit:
+		return nil
+	case <-time.After(timeout):
+		r.view.Writef("❌  Local app '%s' did not stop within %s, killing it\n", application.Name, timeout)
+	case <-ctx.Done():
+		r.view.Writef("❌  Local app '%s' did not stop before shutdown deadline, killing it\n", application.Name)
+	}
+
+	if err := syscall.Kill(-pgid, syscall.SIGKILL); err != nil {
+		return fmt.Errorf("local app '%s' (process group %d) could not be killed: %v", application.Name, pgid, err)
+	}
+
+	<-exit
+
+	return nil
+}
+
+// getApplications returns a copy of the registered applications, which can be added or removed meanwhile
+func (r *runner) getApplications() []*config.Application {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return append([]*config.Application{}, r.applications...)
+}
+
+// dependentsOf returns the applications depending on the one having the given name
+func dependentsOf(applications []*config.Application, name string) []*config.Application {
+	result := make([]*config.Application, 0)
+
+	for _, application := range applications {
+		for _, dependency := range application.DependsOn {
+			if dependency == name {
+				result = append(result, application)
+				break
+			}
+		}
+	}
+
+	return result
+}
+
+// removeApplication returns a copy of the given applications without the one having the given name
+func removeApplication(applications []*config.Application, name string) []*config.Application {
+	result := make([]*config.Application, 0, len(applications))
+
+	for _, application := range applications {
+		if application.Name != name {
+			result = append(result, application)
+		}
+	}
+
+	return result
 }
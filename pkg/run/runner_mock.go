@@ -10,6 +10,7 @@
 package run
 
 import (
+	context "context"
 	reflect "reflect"
 
 	config "github.com/eko/monday/pkg/config"
@@ -39,52 +40,90 @@ func (m *MockRunner) EXPECT() *MockRunnerMockRecorder {
 	return m.recorder
 }
 
+// Add mocks base method.
+func (m *MockRunner) Add(ctx context.Context, application *config.Application) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Add", ctx, application)
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockRunnerMockRecorder) Add(ctx, application any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockRunner)(nil).Add), ctx, application)
+}
+
+// Remove mocks base method.
+func (m *MockRunner) Remove(application *config.Application) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Remove", application)
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockRunnerMockRecorder) Remove(application any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockRunner)(nil).Remove), application)
+}
+
 // Restart mocks base method.
-func (m *MockRunner) Restart(application *config.Application) {
+func (m *MockRunner) Restart(ctx context.Context, application *config.Application) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "Restart", application)
+	m.ctrl.Call(m, "Restart", ctx, application)
 }
 
 // Restart indicates an expected call of Restart.
-func (mr *MockRunnerMockRecorder) Restart(application any) *gomock.Call {
+func (mr *MockRunnerMockRecorder) Restart(ctx, application any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restart", reflect.TypeOf((*MockRunner)(nil).Restart), application)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restart", reflect.TypeOf((*MockRunner)(nil).Restart), ctx, application)
 }
 
 // Run mocks base method.
-func (m *MockRunner) Run(application *config.Application) {
+func (m *MockRunner) Run(ctx context.Context, application *config.Application) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "Run", application)
+	m.ctrl.Call(m, "Run", ctx, application)
 }
 
 // Run indicates an expected call of Run.
-func (mr *MockRunnerMockRecorder) Run(application any) *gomock.Call {
+func (mr *MockRunnerMockRecorder) Run(ctx, application any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockRunner)(nil).Run), application)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockRunner)(nil).Run), ctx, application)
 }
 
 // RunAll mocks base method.
-func (m *MockRunner) RunAll() {
+func (m *MockRunner) RunAll(ctx context.Context) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "RunAll")
+	m.ctrl.Call(m, "RunAll", ctx)
 }
 
 // RunAll indicates an expected call of RunAll.
-func (mr *MockRunnerMockRecorder) RunAll() *gomock.Call {
+func (mr *MockRunnerMockRecorder) RunAll(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunAll", reflect.TypeOf((*MockRunner)(nil).RunAll))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunAll", reflect.TypeOf((*MockRunner)(nil).RunAll), ctx)
 }
 
 // Stop mocks base method.
-func (m *MockRunner) Stop() error {
+func (m *MockRunner) Stop(ctx context.Context) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Stop")
+	ret := m.ctrl.Call(m, "Stop", ctx)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Stop indicates an expected call of Stop.
-func (mr *MockRunnerMockRecorder) Stop() *gomock.Call {
+func (mr *MockRunnerMockRecorder) Stop(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockRunner)(nil).Stop), ctx)
+}
+
+// StopApplication mocks base method.
+func (m *MockRunner) StopApplication(ctx context.Context, application *config.Application) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopApplication", ctx, application)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StopApplication indicates an expected call of StopApplication.
+func (mr *MockRunnerMockRecorder) StopApplication(ctx, application any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockRunner)(nil).Stop))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopApplication", reflect.TypeOf((*MockRunner)(nil).StopApplication), ctx, application)
 }
@@ -1,13 +1,20 @@
 package run
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/eko/monday/internal/ready"
 	"github.com/eko/monday/pkg/config"
+	"github.com/eko/monday/pkg/health"
 	"github.com/eko/monday/pkg/log"
 	"github.com/eko/monday/pkg/proxy"
+	"github.com/eko/monday/pkg/status"
 	"github.com/eko/monday/pkg/ui"
 	"go.uber.org/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -20,17 +27,23 @@ func TestNewRunner(t *testing.T) {
 
 	view := ui.NewMockView(ctrl)
 	proxyfier := proxy.NewMockProxy(ctrl)
+	checker := health.NewMockChecker(ctrl)
+	registry := status.NewRegistry()
+	files := log.NewMockFiles(ctrl)
 
 	project := getMockedProjectWithApplication()
 
 	// When
-	r := NewRunner(view, proxyfier, project, &config.GlobalRun{})
+	r := NewRunner(view, proxyfier, checker, registry, files, project, &config.GlobalRun{})
 
 	// Then
 	assert.IsType(t, new(runner), r)
 	assert.Implements(t, new(Runner), r)
 
 	assert.Equal(t, proxyfier, r.proxy)
+	assert.Equal(t, checker, r.checker)
+	assert.Equal(t, registry, r.registry)
+	assert.Equal(t, files, r.files)
 	assert.Equal(t, project.Name, r.projectName)
 	assert.Equal(t, project.Applications, r.applications)
 }
@@ -43,54 +56,340 @@ func TestRunAll(t *testing.T) {
 	view := ui.NewMockView(ctrl)
 	view.EXPECT().Writef("🏁  Running local app '%s' (%s)...\n", "test-app", "/")
 	view.EXPECT().Write(log.ColorGreen + "test-app" + log.ColorWhite + " OK Arguments Seems -to=work\n")
+	view.EXPECT().Writef("❌  Cannot run the application %s on path %s: %v\n", "test-app", "/", gomock.Any())
+
+	files := log.NewMockFiles(ctrl)
+	files.EXPECT().Write("test-app", log.StdOut, "OK Arguments Seems -to=work\n")
 
 	proxyfier := proxy.NewMockProxy(ctrl)
 
+	// The application keeps running so its command can be retrieved
 	project := getMockedProjectWithApplication()
+	project.Applications[0].Run.Command = "echo OK Arguments Seems -to=work && sleep 10"
 
-	runner := NewRunner(view, proxyfier, project, &config.GlobalRun{})
+	runner := NewRunner(view, proxyfier, health.NewMockChecker(ctrl), status.NewRegistry(), files, project, &config.GlobalRun{})
+	defer runner.Stop(context.Background())
 
 	// When
-	runner.RunAll()
+	runner.RunAll(context.Background())
 
 	// Then
 	// Wait for goroutine to launch application and be available
-	for i := 0; i < 50; i++ {
-		if _, ok := runner.cmds["test-app"]; ok {
-			break
-		}
-
+	var cmd *exec.Cmd
+	for i := 0; i < 50 && cmd == nil; i++ {
 		time.Sleep(time.Duration(100 * time.Millisecond))
+
+		runner.mutex.Lock()
+		cmd = runner.cmds["test-app"]
+		runner.mutex.Unlock()
 	}
 
 	// Check for application to be runned properly
-	if cmd, ok := runner.cmds["test-app"]; ok {
+	if cmd != nil {
 		runCommand := strings.Replace(strings.Join(cmd.Args, " "), "echo <runner>", "runner", -1)
-		assert.Equal(t, "/bin/sh -c echo OK Arguments Seems -to=work", runCommand)
+		assert.Equal(t, "/bin/sh -c echo OK Arguments Seems -to=work && sleep 10", runCommand)
 	} else {
 		t.Fatal("Cannot retrieve just launched application command execution")
 	}
 }
 
+func TestRunAllWhenDependencies(t *testing.T) {
+	// Given
+	defer ready.Reset()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	view := ui.NewMockView(ctrl)
+	view.EXPECT().Writef("⏳  Waiting for %s to be ready before running local app '%s'...\n", "user-api", "graphql")
+	view.EXPECT().Writef("🏁  Running local app '%s' (%s)...\n", "graphql", "/")
+	view.EXPECT().Write(log.ColorGreen + "graphql" + log.ColorWhite + " OK\n")
+	view.EXPECT().Writef("❌  Cannot run the application %s on path %s: %v\n", "graphql", "/", gomock.Any())
+
+	proxyfier := proxy.NewMockProxy(ctrl)
+
+	project := &config.Project{
+		Name: "My project name",
+		Applications: []*config.Application{
+			{
+				Name:      "graphql",
+				Path:      "/",
+				DependsOn: []string{"user-api"},
+				Run: &config.Run{
+					Command: "echo OK && sleep 10",
+				},
+			},
+		},
+	}
+
+	runner := NewRunner(view, proxyfier, health.NewMockChecker(ctrl), status.NewRegistry(), getMockedFiles(ctrl), project, &config.GlobalRun{})
+	defer runner.Stop(context.Background())
+
+	// When
+	runner.RunAll(context.Background())
+
+	// Then
+	time.Sleep(200 * time.Millisecond)
+	assert.False(t, ready.IsReady("graphql"))
+
+	ready.Set("user-api")
+
+	for i := 0; i < 50 && !ready.IsReady("graphql"); i++ {
+		time.Sleep(time.Duration(100 * time.Millisecond))
+	}
+
+	assert.True(t, ready.IsReady("graphql"))
+
+	// Wait for the application output to be streamed
+	time.Sleep(200 * time.Millisecond)
+}
+
+func TestRunAllWhenHealthCheck(t *testing.T) {
+	// Given
+	defer ready.Reset()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	application := &config.Application{
+		Name: "graphql",
+		Path: "/",
+		Health: &config.Health{
+			Log: &config.LogHealth{Pattern: "^OK"},
+		},
+		Run: &config.Run{
+			Command: "echo OK",
+		},
+	}
+
+	started := make(chan bool)
+	stopped := make(chan bool)
+
+	view := ui.NewMockView(ctrl)
+	view.EXPECT().Writef("🏁  Running local app '%s' (%s)...\n", "graphql", "/")
+	view.EXPECT().Write(log.ColorGreen + "graphql" + log.ColorWhite + " OK\n")
+
+	checker := health.NewMockChecker(ctrl)
+	checker.EXPECT().Start(application).Do(func(application *config.Application) {
+		close(started)
+	})
+	checker.EXPECT().Observe("graphql", "OK\n").AnyTimes()
+	checker.EXPECT().Stop("graphql").Do(func(name string) {
+		close(stopped)
+	})
+
+	runner := NewRunner(view, proxy.NewMockProxy(ctrl), checker, status.NewRegistry(), getMockedFiles(ctrl), &config.Project{
+		Name:         "My project name",
+		Applications: []*config.Application{application},
+	}, &config.GlobalRun{})
+
+	// When
+	runner.RunAll(context.Background())
+
+	// Then
+	<-started
+	assert.False(t, ready.IsReady("graphql"))
+
+	// Health checks are stopped once the application has exited, its output being streamed
+	<-stopped
+}
+
+func TestRunWhenExited(t *testing.T) {
+	// Given
+	defer ready.Reset()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	view := ui.NewMockView(ctrl)
+	view.EXPECT().Writef("🏁  Running local app '%s' (%s)...\n", "graphql", "/")
+
+	application := &config.Application{
+		Name: "graphql",
+		Path: "/",
+		Run: &config.Run{
+			Command: "sleep 0.2",
+		},
+	}
+
+	runner := NewRunner(view, proxy.NewMockProxy(ctrl), health.NewMockChecker(ctrl), status.NewRegistry(), getMockedFiles(ctrl), &config.Project{
+		Name:         "My project name",
+		Applications: []*config.Application{application},
+	}, &config.GlobalRun{})
+
+	// When
+	started, err := runner.run(context.Background(), application)
+
+	// Then
+	assert.True(t, started)
+	assert.Nil(t, err)
+
+	// Applications depending on an exited one wait for it to be launched again
+	assert.False(t, ready.IsReady("graphql"))
+
+	state, _ := runner.registry.GetApplication("graphql")
+	assert.Equal(t, status.StateExited, state.State)
+}
+
+func TestRunWhenRestartOnFailure(t *testing.T) {
+	// Given
+	defer func(min, max time.Duration) {
+		restartMinDelay, restartMaxDelay = min, max
+	}(restartMinDelay, restartMaxDelay)
+
+	restartMinDelay, restartMaxDelay = 10*time.Millisecond, 20*time.Millisecond
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	application := &config.Application{
+		Name: "crashing-app",
+		Path: "/",
+		Run: &config.Run{
+			Command:     "exit 3",
+			Restart:     config.RestartOnFailure,
+			MaxRestarts: 2,
+		},
+	}
+
+	view := ui.NewMockView(ctrl)
+	view.EXPECT().Writef("🏁  Running local app '%s' (%s)...\n", "crashing-app", "/").Times(3)
+	view.EXPECT().Writef("❌  Cannot run the application %s on path %s: %v\n", "crashing-app", "/", gomock.Any()).Times(3)
+	view.EXPECT().Writef("🔁  Restarting local app '%s' in %s...\n", "crashing-app", gomock.Any()).Times(2)
+	view.EXPECT().Writef("❌  Local app '%s' has been restarted %d times, giving up\n", "crashing-app", 2)
+
+	runner := NewRunner(view, proxy.NewMockProxy(ctrl), health.NewMockChecker(ctrl), status.NewRegistry(), getMockedFiles(ctrl), &config.Project{
+		Name:         "My project name",
+		Applications: []*config.Application{application},
+	}, &config.GlobalRun{})
+
+	// When
+	runner.Run(context.Background(), application)
+
+	// Then
+	state, _ := runner.registry.GetApplication("crashing-app")
+	assert.Equal(t, status.StateCrashed, state.State)
+	assert.Equal(t, 2, state.Restarts)
+	assert.Equal(t, 3, state.ExitCode)
+}
+
+func TestRunWhenRestartOnFailureAndHealthCheck(t *testing.T) {
+	// Given
+	defer ready.Reset()
+
+	defer func(min, max time.Duration) {
+		restartMinDelay, restartMaxDelay = min, max
+	}(restartMinDelay, restartMaxDelay)
+
+	restartMinDelay, restartMaxDelay = 10*time.Millisecond, 20*time.Millisecond
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	application := &config.Application{
+		Name: "crashing-app",
+		Path: "/",
+		Health: &config.Health{
+			TCP: &config.TCPHealth{Port: "8080"},
+		},
+		Run: &config.Run{
+			Command:     "exit 3",
+			Restart:     config.RestartOnFailure,
+			MaxRestarts: 1,
+		},
+	}
+
+	view := ui.NewMockView(ctrl)
+	view.EXPECT().Writef(gomock.Any(), gomock.Any()).AnyTimes()
+
+	// Health checks are stopped each time the application exits, and started again when it is restarted
+	checker := health.NewMockChecker(ctrl)
+	gomock.InOrder(
+		checker.EXPECT().Start(application),
+		checker.EXPECT().Stop("crashing-app"),
+		checker.EXPECT().Start(application),
+		checker.EXPECT().Stop("crashing-app"),
+	)
+
+	runner := NewRunner(view, proxy.NewMockProxy(ctrl), checker, status.NewRegistry(), getMockedFiles(ctrl), &config.Project{
+		Name:         "My project name",
+		Applications: []*config.Application{application},
+	}, &config.GlobalRun{})
+
+	// When
+	runner.Run(context.Background(), application)
+
+	// Then
+	state, _ := runner.registry.GetApplication("crashing-app")
+	assert.Equal(t, status.StateCrashed, state.State)
+	assert.Equal(t, 1, state.Restarts)
+}
+
+func TestRunWhenRestartNever(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	application := &config.Application{
+		Name: "crashing-app",
+		Path: "/",
+		Run: &config.Run{
+			Command: "exit 3",
+		},
+	}
+
+	view := ui.NewMockView(ctrl)
+	view.EXPECT().Writef("🏁  Running local app '%s' (%s)...\n", "crashing-app", "/")
+	view.EXPECT().Writef("❌  Cannot run the application %s on path %s: %v\n", "crashing-app", "/", gomock.Any())
+
+	runner := NewRunner(view, proxy.NewMockProxy(ctrl), health.NewMockChecker(ctrl), status.NewRegistry(), getMockedFiles(ctrl), &config.Project{
+		Name:         "My project name",
+		Applications: []*config.Application{application},
+	}, &config.GlobalRun{})
+
+	// When
+	runner.Run(context.Background(), application)
+
+	// Then
+	state, _ := runner.registry.GetApplication("crashing-app")
+	assert.Equal(t, status.StateCrashed, state.State)
+	assert.Equal(t, 0, state.Restarts)
+	assert.Equal(t, 3, state.ExitCode)
+}
+
 func TestStop(t *testing.T) {
 	// Given
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
+	written := make(chan bool)
+
 	view := ui.NewMockView(ctrl)
 	view.EXPECT().Writef("🏁  Running local app '%s' (%s)...\n", "test-app", "/")
-	view.EXPECT().Write(log.ColorGreen + "test-app" + log.ColorWhite + " OK Arguments Seems -to=work\n")
+	view.EXPECT().Write(log.ColorGreen + "test-app" + log.ColorWhite + " OK Arguments Seems -to=work\n").Do(func(str string) {
+		close(written)
+	})
 
 	proxyfier := proxy.NewMockProxy(ctrl)
 
 	project := getMockedProjectWithApplication()
 
-	runner := NewRunner(view, proxyfier, project, &config.GlobalRun{})
-	runner.RunAll()
+	runner := NewRunner(view, proxyfier, health.NewMockChecker(ctrl), status.NewRegistry(), getMockedFiles(ctrl), project, &config.GlobalRun{})
+	runner.RunAll(context.Background())
 
-	// Wait for goroutine to launch application and be available
+	select {
+	case <-written:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Application has not been launched")
+	}
+
+	// Wait for the application to exit, its command being forgotten
 	for i := 0; i < 50; i++ {
-		if _, ok := runner.cmds["test-app"]; ok {
+		runner.mutex.Lock()
+		_, ok := runner.cmds["test-app"]
+		runner.mutex.Unlock()
+
+		if !ok {
 			break
 		}
 
@@ -98,17 +397,17 @@ func TestStop(t *testing.T) {
 	}
 
 	// When
-	runner.Stop()
+	runner.Stop(context.Background())
 
 	// Then
-	if cmd, ok := runner.cmds["test-app"]; ok {
-		runCommand := strings.Replace(strings.Join(cmd.Args, " "), "echo <runner>", "runner", -1)
-		assert.Equal(t, "/bin/sh -c echo OK Arguments Seems -to=work", runCommand)
+	runner.mutex.Lock()
+	assert.NotContains(t, runner.cmds, "test-app")
+	assert.NotContains(t, runner.exits, "test-app")
+	runner.mutex.Unlock()
 
-		assert.True(t, cmd.ProcessState.Exited())
-	} else {
-		t.Fatal("Cannot retrieve just launched application command execution")
-	}
+	state, _ := runner.registry.GetApplication("test-app")
+	assert.Equal(t, status.StateStopped, state.State)
+	assert.Equal(t, 0, state.PID)
 }
 
 func getMockedProjectWithApplication() *config.Project {
@@ -125,3 +424,323 @@ func getMockedProjectWithApplication() *config.Project {
 		},
 	}
 }
+
+func TestAddAndRemove(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	application := &config.Application{
+		Name:     "added-app",
+		Path:     "/",
+		Hostname: "added-app.svc.local",
+		Run: &config.Run{
+			Command: "sleep 10",
+		},
+	}
+
+	view := ui.NewMockView(ctrl)
+	view.EXPECT().Writef("🏁  Running local app '%s' (%s)...\n", "added-app", "/")
+	view.EXPECT().Writef(gomock.Any(), gomock.Any()).AnyTimes()
+
+	proxyfier := proxy.NewMockProxy(ctrl)
+	proxyfier.EXPECT().AddProxyForward("added-app", proxy.NewProxyForward("added-app", "added-app.svc.local", "", "", ""))
+	proxyfier.EXPECT().RemoveProxyForward("added-app")
+
+	project := getMockedProjectWithApplication()
+
+	runner := NewRunner(view, proxyfier, health.NewMockChecker(ctrl), status.NewRegistry(), getMockedFiles(ctrl), project, &config.GlobalRun{})
+
+	// When
+	runner.Add(context.Background(), application)
+
+	for i := 0; i < 50; i++ {
+		runner.mutex.Lock()
+		_, ok := runner.cmds["added-app"]
+		runner.mutex.Unlock()
+
+		if ok {
+			break
+		}
+
+		time.Sleep(time.Duration(100 * time.Millisecond))
+	}
+
+	// Then
+	assert.Len(t, runner.applications, 2)
+	assert.Len(t, project.Applications, 1)
+
+	state, ok := runner.registry.GetApplication("added-app")
+	assert.True(t, ok)
+	assert.Equal(t, status.StateRunning, state.State)
+	assert.NotZero(t, state.PID)
+	assert.False(t, state.StartedAt.IsZero())
+
+	// When
+	runner.Remove(application)
+
+	// Then
+	assert.Len(t, runner.applications, 1)
+	assert.Equal(t, "test-app", runner.applications[0].Name)
+	assert.NotContains(t, runner.cmds, "added-app")
+
+	_, ok = runner.registry.GetApplication("added-app")
+	assert.False(t, ok)
+}
+
+func TestStopWhenApplicationsAreAdded(t *testing.T) {
+	// Given
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	view := ui.NewMockView(ctrl)
+	view.EXPECT().Writef(gomock.Any(), gomock.Any()).AnyTimes()
+
+	runner := NewRunner(view, proxy.NewMockProxy(ctrl), health.NewMockChecker(ctrl), status.NewRegistry(), getMockedFiles(ctrl), getMockedProjectWithApplication(), &config.GlobalRun{})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		for i := 0; i < 50; i++ {
+			application := &config.Application{Name: fmt.Sprintf("added-app-%d", i), Path: "/"}
+
+			runner.Add(ctx, application)
+			runner.Remove(application)
+		}
+	}()
+
+	// When
+	for i := 0; i < 50; i++ {
+		assert.Nil(t, runner.Stop(ctx))
+	}
+
+	// Then
+	<-done
+	assert.Len(t, runner.getApplications(), 1)
+}
+
+func TestStopWhenGraceful(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	defer ready.Reset()
+
+	application := &config.Application{
+		Name: "graceful-app",
+		Path: "/",
+		Run: &config.Run{
+			Command: `trap "exit 3" TERM; sleep 10 & wait`,
+		},
+	}
+
+	view := ui.NewMockView(ctrl)
+	view.EXPECT().Writef("🏁  Running local app '%s' (%s)...\n", "graceful-app", "/")
+	view.EXPECT().Writef("❌  Cannot run the application %s on path %s: %v\n", "graceful-app", "/", gomock.Any())
+
+	project := &config.Project{Name: "graceful", Applications: []*config.Application{application}}
+
+	runner := NewRunner(view, proxy.NewMockProxy(ctrl), health.NewMockChecker(ctrl), status.NewRegistry(), getMockedFiles(ctrl), project, &config.GlobalRun{})
+	runner.RunAll(context.Background())
+
+	for i := 0; i < 50 && !ready.IsReady("graceful-app"); i++ {
+		time.Sleep(time.Duration(100 * time.Millisecond))
+	}
+
+	// When
+	runner.Stop(context.Background())
+
+	// Then
+	state, _ := runner.registry.GetApplication("graceful-app")
+	assert.Equal(t, status.StateStopped, state.State)
+	assert.Equal(t, 0, state.Restarts)
+	assert.Equal(t, 3, state.ExitCode)
+}
+
+func TestStopApplication(t *testing.T) {
+	// Given
+	defer func(min, max time.Duration) {
+		restartMinDelay, restartMaxDelay = min, max
+	}(restartMinDelay, restartMaxDelay)
+
+	restartMinDelay, restartMaxDelay = 10*time.Millisecond, 20*time.Millisecond
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	defer ready.Reset()
+
+	application := &config.Application{
+		Name: "always-app",
+		Path: "/",
+		Run: &config.Run{
+			Command: "sleep 10",
+			Restart: config.RestartAlways,
+		},
+	}
+
+	view := ui.NewMockView(ctrl)
+	view.EXPECT().Writef("🏁  Running local app '%s' (%s)...\n", "always-app", "/")
+	view.EXPECT().Writef("❌  Cannot run the application %s on path %s: %v\n", "always-app", "/", gomock.Any())
+
+	project := &config.Project{Name: "always", Applications: []*config.Application{application}}
+
+	runner := NewRunner(view, proxy.NewMockProxy(ctrl), health.NewMockChecker(ctrl), status.NewRegistry(), getMockedFiles(ctrl), project, &config.GlobalRun{})
+	runner.RunAll(context.Background())
+
+	for i := 0; i < 50 && !ready.IsReady("always-app"); i++ {
+		time.Sleep(time.Duration(100 * time.Millisecond))
+	}
+
+	// When
+	err := runner.StopApplication(context.Background(), application)
+
+	// Then
+	assert.Nil(t, err)
+
+	// The application is not restarted, despite its restart policy
+	time.Sleep(100 * time.Millisecond)
+
+	state, _ := runner.registry.GetApplication("always-app")
+	assert.Equal(t, status.StateStopped, state.State)
+	assert.Equal(t, 0, state.Restarts)
+	assert.False(t, ready.IsReady("always-app"))
+}
+
+func TestStopWhenStopTimeout(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	defer ready.Reset()
+
+	application := &config.Application{
+		Name: "stubborn-app",
+		Path: "/",
+		Run: &config.Run{
+			Command:     `trap "" TERM; sleep 10`,
+			StopTimeout: "100ms",
+		},
+	}
+
+	view := ui.NewMockView(ctrl)
+	view.EXPECT().Writef("🏁  Running local app '%s' (%s)...\n", "stubborn-app", "/")
+	view.EXPECT().Writef("❌  Local app '%s' did not stop within %s, killing it\n", "stubborn-app", 100*time.Millisecond)
+	view.EXPECT().Writef("❌  Cannot run the application %s on path %s: %v\n", "stubborn-app", "/", gomock.Any())
+
+	project := &config.Project{Name: "stubborn", Applications: []*config.Application{application}}
+
+	runner := NewRunner(view, proxy.NewMockProxy(ctrl), health.NewMockChecker(ctrl), status.NewRegistry(), getMockedFiles(ctrl), project, &config.GlobalRun{})
+	runner.RunAll(context.Background())
+
+	for i := 0; i < 50 && !ready.IsReady("stubborn-app"); i++ {
+		time.Sleep(time.Duration(100 * time.Millisecond))
+	}
+
+	// When
+	runner.Stop(context.Background())
+
+	// Then
+	state, _ := runner.registry.GetApplication("stubborn-app")
+	assert.Equal(t, status.StateStopped, state.State)
+	assert.Equal(t, 0, state.Restarts)
+	assert.Equal(t, -1, state.ExitCode)
+}
+
+func TestStopWhenDependencies(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	defer ready.Reset()
+
+	dir := t.TempDir()
+
+	// Each application appends its name to a file when it is stopped
+	newApplication := func(name string, dependsOn ...string) *config.Application {
+		return &config.Application{
+			Name:      name,
+			Path:      dir,
+			DependsOn: dependsOn,
+			Run: &config.Run{
+				Command: `trap "echo ` + name + ` >> stopped; exit 0" TERM; sleep 10 & wait`,
+			},
+		}
+	}
+
+	project := &config.Project{Name: "graphql", Applications: []*config.Application{
+		newApplication("graphql", "user-api"),
+		newApplication("user-api", "postgres"),
+		newApplication("postgres"),
+	}}
+
+	view := ui.NewMockView(ctrl)
+	view.EXPECT().Writef(gomock.Any(), gomock.Any()).AnyTimes()
+
+	runner := NewRunner(view, proxy.NewMockProxy(ctrl), health.NewMockChecker(ctrl), status.NewRegistry(), getMockedFiles(ctrl), project, &config.GlobalRun{})
+	runner.RunAll(context.Background())
+
+	for i := 0; i < 50 && !ready.IsReady("graphql"); i++ {
+		time.Sleep(time.Duration(100 * time.Millisecond))
+	}
+
+	// When
+	runner.Stop(context.Background())
+
+	// Then
+	content, err := os.ReadFile(dir + "/stopped")
+	assert.Nil(t, err)
+	assert.Equal(t, "graphql\nuser-api\npostgres\n", string(content))
+}
+
+func TestStopWhenShutdownDeadline(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	defer ready.Reset()
+
+	application := &config.Application{
+		Name: "stubborn-app",
+		Path: "/",
+		Run: &config.Run{
+			Command:      `trap "" TERM; sleep 10`,
+			StopTimeout:  "10s",
+			StopCommands: []string{"exit 1"},
+		},
+	}
+
+	view := ui.NewMockView(ctrl)
+	view.EXPECT().Writef("🏁  Running local app '%s' (%s)...\n", "stubborn-app", "/")
+	view.EXPECT().Writef("❌  Local app '%s' did not stop before shutdown deadline, killing it\n", "stubborn-app")
+	view.EXPECT().Writef("❌  Cannot run the application %s on path %s: %v\n", "stubborn-app", "/", gomock.Any())
+	view.EXPECT().Writef("❌  Cannot run stop command for application '%s': %v\n", "stubborn-app", gomock.Any())
+
+	project := &config.Project{Name: "stubborn", Applications: []*config.Application{application}}
+
+	runner := NewRunner(view, proxy.NewMockProxy(ctrl), health.NewMockChecker(ctrl), status.NewRegistry(), getMockedFiles(ctrl), project, &config.GlobalRun{})
+	runner.RunAll(context.Background())
+
+	for i := 0; i < 50 && !ready.IsReady("stubborn-app"); i++ {
+		time.Sleep(time.Duration(100 * time.Millisecond))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	// When
+	err := runner.Stop(ctx)
+
+	// Then
+	assert.EqualError(t, err, "stop commands of local app 'stubborn-app' failed: exit status 1")
+	state, _ := runner.registry.GetApplication("stubborn-app")
+	assert.Equal(t, status.StateStopped, state.State)
+	assert.Equal(t, 0, state.Restarts)
+	assert.Equal(t, -1, state.ExitCode)
+}
+
+func getMockedFiles(ctrl *gomock.Controller) *log.MockFiles {
+	files := log.NewMockFiles(ctrl)
+	files.EXPECT().Write(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+	return files
+}
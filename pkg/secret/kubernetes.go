@@ -0,0 +1,70 @@
+package secret
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+var (
+	defaultKubeConfigPath = fmt.Sprintf("%s/%s", os.Getenv("HOME"), ".kube/config")
+
+	// getKubernetesSecret returns the data of a Kubernetes secret
+	getKubernetesSecret = func(kubeContext, namespace, name string) (map[string][]byte, error) {
+		clientConfig, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+			&clientcmd.ClientConfigLoadingRules{ExplicitPath: getKubeConfigPath()},
+			&clientcmd.ConfigOverrides{CurrentContext: kubeContext},
+		).ClientConfig()
+		if err != nil {
+			return nil, err
+		}
+
+		clientSet, err := kubernetes.NewForConfig(clientConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		secret, err := clientSet.CoreV1().Secrets(namespace).Get(context.Background(), name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+
+		return secret.Data, nil
+	}
+)
+
+// resolveKubernetes returns the value of a Kubernetes secret key,
+// given as "secret://k8s/<context>/<namespace>/<secret>/<key>"
+func resolveKubernetes(path string) (string, error) {
+	parts := strings.Split(path, "/")
+	if len(parts) != 4 {
+		return "", fmt.Errorf("expected 'secret://k8s/<context>/<namespace>/<secret>/<key>'")
+	}
+
+	kubeContext, namespace, name, key := parts[0], parts[1], parts[2], parts[3]
+
+	data, err := getKubernetesSecret(kubeContext, namespace, name)
+	if err != nil {
+		return "", err
+	}
+
+	value, ok := data[key]
+	if !ok {
+		return "", fmt.Errorf("key '%s' not found in secret '%s' of namespace '%s'", key, name, namespace)
+	}
+
+	return string(value), nil
+}
+
+func getKubeConfigPath() string {
+	if value := os.Getenv("MONDAY_KUBE_CONFIG"); value != "" {
+		return value
+	}
+
+	return defaultKubeConfigPath
+}
@@ -0,0 +1,85 @@
+package secret
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/eko/monday/internal/redact"
+)
+
+const (
+	// Prefix is the prefix of the values referencing a secret
+	Prefix = "secret://"
+
+	ProviderKubernetes = "k8s"
+	ProviderFile       = "file"
+	ProviderExec       = "exec"
+)
+
+// provider resolves the secret value of a reference path (without the prefix and provider name)
+type provider func(path string) (string, error)
+
+var providers = map[string]provider{
+	ProviderKubernetes: resolveKubernetes,
+	ProviderFile:       resolveFile,
+	ProviderExec:       resolveExec,
+}
+
+// IsReference indicates if the given value references a secret
+func IsReference(value string) bool {
+	return strings.HasPrefix(value, Prefix)
+}
+
+// Resolve returns the secret value referenced by the given value, or the value itself if it does
+// not reference a secret. Resolved values are registered so they are never displayed.
+func Resolve(value string) (string, error) {
+	if !IsReference(value) {
+		return value, nil
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(value, Prefix), "/", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return "", fmt.Errorf("invalid secret reference '%s', expected 'secret://<provider>/<path>'", value)
+	}
+
+	resolve, ok := providers[parts[0]]
+	if !ok {
+		return "", fmt.Errorf("unknown provider '%s' for secret reference '%s'", parts[0], value)
+	}
+
+	secret, err := resolve(parts[1])
+	if err != nil {
+		return "", fmt.Errorf("unable to resolve secret '%s': %v", value, err)
+	}
+
+	redact.Add(secret)
+
+	return secret, nil
+}
+
+// resolveFile returns the content of a file, given as "secret://file/<path>"
+func resolveFile(path string) (string, error) {
+	path = os.ExpandEnv(strings.Replace(path, "~", "$HOME", 1))
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(string(content), "\r\n"), nil
+}
+
+// resolveExec returns the output of a command, given as "secret://exec/<command>"
+func resolveExec(command string) (string, error) {
+	cmd := exec.Command("/bin/sh", "-c", command)
+	cmd.Env = os.Environ()
+
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("command failed: %v", err)
+	}
+
+	return strings.TrimRight(string(output), "\r\n"), nil
+}
@@ -0,0 +1,106 @@
+package secret
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/eko/monday/internal/redact"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveWhenNotReference(t *testing.T) {
+	// When
+	value, err := Resolve("plain value")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "plain value", value)
+}
+
+func TestResolveWhenFile(t *testing.T) {
+	// Given
+	defer redact.Reset()
+
+	filename := t.TempDir() + "/password"
+	os.WriteFile(filename, []byte("from-file\n"), 0600)
+
+	// When
+	value, err := Resolve("secret://file/" + filename)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "from-file", value)
+	assert.Equal(t, "password: ******", redact.String("password: from-file"))
+}
+
+func TestResolveWhenExec(t *testing.T) {
+	// Given
+	defer redact.Reset()
+
+	// When
+	value, err := Resolve("secret://exec/echo from-exec")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "from-exec", value)
+	assert.Equal(t, "******", redact.String("from-exec"))
+}
+
+func TestResolveWhenKubernetes(t *testing.T) {
+	// Given
+	defer redact.Reset()
+	defer func(original func(string, string, string) (map[string][]byte, error)) {
+		getKubernetesSecret = original
+	}(getKubernetesSecret)
+
+	var args []string
+	getKubernetesSecret = func(kubeContext, namespace, name string) (map[string][]byte, error) {
+		args = []string{kubeContext, namespace, name}
+		return map[string][]byte{"password": []byte("from-k8s")}, nil
+	}
+
+	// When
+	value, err := Resolve("secret://k8s/preprod/backend/database/password")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "from-k8s", value)
+	assert.Equal(t, []string{"preprod", "backend", "database"}, args)
+}
+
+func TestResolveWhenErrors(t *testing.T) {
+	// Given
+	defer func(original func(string, string, string) (map[string][]byte, error)) {
+		getKubernetesSecret = original
+	}(getKubernetesSecret)
+
+	getKubernetesSecret = func(kubeContext, namespace, name string) (map[string][]byte, error) {
+		if name == "unknown" {
+			return nil, errors.New("secret not found")
+		}
+
+		return map[string][]byte{}, nil
+	}
+
+	testCases := []struct {
+		value    string
+		expected string
+	}{
+		{value: "secret://file", expected: "invalid secret reference 'secret://file', expected 'secret://<provider>/<path>'"},
+		{value: "secret://vault/path", expected: "unknown provider 'vault' for secret reference 'secret://vault/path'"},
+		{value: "secret://k8s/preprod/database", expected: "unable to resolve secret 'secret://k8s/preprod/database': expected 'secret://k8s/<context>/<namespace>/<secret>/<key>'"},
+		{value: "secret://k8s/preprod/backend/unknown/password", expected: "unable to resolve secret 'secret://k8s/preprod/backend/unknown/password': secret not found"},
+		{value: "secret://k8s/preprod/backend/database/password", expected: "unable to resolve secret 'secret://k8s/preprod/backend/database/password': key 'password' not found in secret 'database' of namespace 'backend'"},
+		{value: "secret://exec/exit 1", expected: "unable to resolve secret 'secret://exec/exit 1': command failed: exit status 1"},
+	}
+
+	for _, testCase := range testCases {
+		// When
+		value, err := Resolve(testCase.value)
+
+		// Then
+		assert.Equal(t, "", value)
+		assert.EqualError(t, err, testCase.expected)
+	}
+}
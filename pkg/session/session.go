@@ -0,0 +1,170 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/eko/monday/internal/filename"
+)
+
+const (
+	fileExtension   = ".json"
+	socketExtension = ".sock"
+	logExtension    = ".log"
+
+	// dialTimeout is the maximum duration to connect to the socket of a session
+	dialTimeout = time.Second
+)
+
+// Session describes a running Monday session, its file is written in the sessions directory so that the client
+// commands (status, restart, stop, down, ...) can find it
+type Session struct {
+	Project   string    `json:"project"`
+	Profile   string    `json:"profile,omitempty"`
+	PID       int       `json:"pid"`
+	Socket    string    `json:"socket"`
+	Detached  bool      `json:"detached"`
+	LogFile   string    `json:"log_file,omitempty"`
+	StartedAt time.Time `json:"started_at"`
+}
+
+// IsRunning indicates if the session is still accepting connections on its socket
+func (s *Session) IsRunning() bool {
+	return IsListening(s.Socket)
+}
+
+// GetFilepath returns the path of the session file of the given project
+func GetFilepath(directory, project string) string {
+	return filepath.Join(directory, filename.Sanitize(project)+fileExtension)
+}
+
+// GetSocketPath returns the path of the control API socket of the given project
+func GetSocketPath(directory, project string) string {
+	return filepath.Join(directory, filename.Sanitize(project)+socketExtension)
+}
+
+// GetLogFilepath returns the path of the file receiving the output of the given project, when detached
+func GetLogFilepath(directory, project string) string {
+	return filepath.Join(directory, filename.Sanitize(project)+logExtension)
+}
+
+// IsListening indicates if a session is accepting connections on the socket at the given path
+func IsListening(path string) bool {
+	conn, err := net.DialTimeout("unix", path, dialTimeout)
+	if err != nil {
+		return false
+	}
+
+	conn.Close()
+
+	return true
+}
+
+// Write writes the file of the given session, only readable by the user running Monday
+func Write(directory string, session *Session) error {
+	if err := MkdirAll(directory); err != nil {
+		return err
+	}
+
+	content, err := json.MarshalIndent(session, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	path := GetFilepath(directory, session.Project)
+
+	if err := os.WriteFile(path, content, 0600); err != nil {
+		return fmt.Errorf("Unable to write the session file '%s': %v", path, err)
+	}
+
+	SetOwner(path)
+
+	return nil
+}
+
+// Read reads the session file of the given project
+func Read(directory, project string) (*Session, error) {
+	return readFile(GetFilepath(directory, project))
+}
+
+// List returns the sessions which files are found in the given directory, sorted by project
+func List(directory string) ([]*Session, error) {
+	paths, err := filepath.Glob(filepath.Join(directory, "*"+fileExtension))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*Session, 0, len(paths))
+
+	for _, path := range paths {
+		session, err := readFile(path)
+		if err != nil {
+			continue
+		}
+
+		result = append(result, session)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Project < result[j].Project
+	})
+
+	return result, nil
+}
+
+// Remove removes the session file of the given project
+func Remove(directory, project string) error {
+	path := GetFilepath(directory, project)
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("session file '%s' could not be removed: %v", path, err)
+	}
+
+	return nil
+}
+
+// MkdirAll creates the sessions directory, only accessible by the user running Monday
+func MkdirAll(directory string) error {
+	if err := os.MkdirAll(directory, 0700); err != nil {
+		return fmt.Errorf("Unable to create the sessions directory '%s': %v", directory, err)
+	}
+
+	SetOwner(directory)
+
+	return nil
+}
+
+// SetOwner gives the given file to the user who ran Monday using sudo, so it can be used without sudo
+func SetOwner(path string) {
+	uid, err := strconv.Atoi(os.Getenv("SUDO_UID"))
+	if err != nil {
+		return
+	}
+
+	gid, err := strconv.Atoi(os.Getenv("SUDO_GID"))
+	if err != nil {
+		return
+	}
+
+	os.Chown(path, uid, gid)
+}
+
+func readFile(path string) (*Session, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var session Session
+	if err := json.Unmarshal(content, &session); err != nil {
+		return nil, fmt.Errorf("Unable to read the session file '%s': %v", path, err)
+	}
+
+	return &session, nil
+}
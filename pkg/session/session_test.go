@@ -0,0 +1,63 @@
+package session
+
+import (
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPaths(t *testing.T) {
+	assert.Equal(t, "/tmp/sessions/my-project.json", GetFilepath("/tmp/sessions", "my/project"))
+	assert.Equal(t, "/tmp/sessions/my-project.sock", GetSocketPath("/tmp/sessions", "my/project"))
+	assert.Equal(t, "/tmp/sessions/my-project.log", GetLogFilepath("/tmp/sessions", "my/project"))
+}
+
+func TestWriteReadAndRemove(t *testing.T) {
+	// Given
+	directory := filepath.Join(t.TempDir(), "sessions")
+
+	session := &Session{
+		Project:   "my-project",
+		PID:       1234,
+		Socket:    GetSocketPath(directory, "my-project"),
+		Detached:  true,
+		StartedAt: time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC),
+	}
+
+	// When
+	err := Write(directory, session)
+
+	// Then
+	assert.Nil(t, err)
+
+	result, err := Read(directory, "my-project")
+	assert.Nil(t, err)
+	assert.Equal(t, session, result)
+
+	sessions, err := List(directory)
+	assert.Nil(t, err)
+	assert.Equal(t, []*Session{session}, sessions)
+
+	assert.Nil(t, Remove(directory, "my-project"))
+
+	_, err = Read(directory, "my-project")
+	assert.NotNil(t, err)
+}
+
+func TestIsRunning(t *testing.T) {
+	// Given
+	path := GetSocketPath(t.TempDir(), "my-project")
+	session := &Session{Project: "my-project", Socket: path}
+
+	assert.False(t, session.IsRunning())
+
+	listener, err := net.Listen("unix", path)
+	assert.Nil(t, err)
+	defer listener.Close()
+
+	// When - Then
+	assert.True(t, session.IsRunning())
+}
@@ -17,15 +17,17 @@ type Setuper interface {
 
 // setuper is the struct that manage the setuper of local applications
 type setuper struct {
+	files        log.Files
 	projectName  string
 	applications []*config.Application
 	view         ui.View
 	conf         *config.GlobalSetup
 }
 
-// NewSetuper instanciates a setuper struct from configuration data
-func NewSetuper(view ui.View, project *config.Project, conf *config.GlobalSetup) *setuper {
+// NewSetuper instanciates a setuper struct from configuration data, setup output being recorded in the given log files
+func NewSetuper(view ui.View, files log.Files, project *config.Project, conf *config.GlobalSetup) *setuper {
 	return &setuper{
+		files:        files,
 		projectName:  project.Name,
 		applications: project.Applications,
 		view:         view,
@@ -65,6 +67,8 @@ func (s *setuper) Setup(application *config.Application) {
 
 	stdoutStream := log.NewStreamer(log.StdOut, application.Name, s.view)
 	stderrStream := log.NewStreamer(log.StdErr, application.Name, s.view)
+	stdoutStream.Record(s.files)
+	stderrStream.Record(s.files)
 
 	commands := strings.Join(setup.Commands, "\n")
 	s.view.Writef("👉  Running commands:\n%s\n\n", commands)
@@ -77,7 +81,10 @@ func (s *setuper) Setup(application *config.Application) {
 		envs = helper.MergeMapString(setup.Env, s.conf.Env)
 	}
 
-	helper.AddEnvVariables(cmd, envs)
+	if err := helper.AddEnvVariables(cmd, envs); err != nil {
+		s.view.Writef("❌  %v\n", err)
+		return
+	}
 	if err := helper.AddEnvVariablesFromFile(cmd, setup.GetEnvFile()); err != nil {
 		s.view.Writef("❌  %v\n", err)
 		return
@@ -20,7 +20,7 @@ func TestNewSetuper(t *testing.T) {
 	project := getMockedProjectWithApplication()
 
 	// When
-	s := NewSetuper(view, project, &config.GlobalSetup{})
+	s := NewSetuper(view, log.NewMockFiles(ctrl), project, &config.GlobalSetup{})
 
 	// Then
 	assert.IsType(t, new(setuper), s)
@@ -42,6 +42,10 @@ func TestSetupAll(t *testing.T) {
 	view.EXPECT().Write(log.ColorGreen + "test-app" + log.ColorWhite + " ...and a second setup command to confirm it works\n")
 	view.EXPECT().Write("\n✅  Setup of application complete!\n\n")
 
+	files := log.NewMockFiles(ctrl)
+	files.EXPECT().Write("test-app", log.StdOut, "Starting test command setup...\n")
+	files.EXPECT().Write("test-app", log.StdOut, "...and a second setup command to confirm it works\n")
+
 	project := &config.Project{
 		Name: "My project name",
 		Applications: []*config.Application{
@@ -58,7 +62,7 @@ func TestSetupAll(t *testing.T) {
 		},
 	}
 
-	setuper := NewSetuper(view, project, &config.GlobalSetup{})
+	setuper := NewSetuper(view, files, project, &config.GlobalSetup{})
 
 	// When - Then
 	setuper.SetupAll()
@@ -0,0 +1,287 @@
+package status
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// State represents the lifecycle state of a local application or a forward
+type State string
+
+const (
+	// Local applications states
+	StatePending     State = "pending"
+	StateBuilding    State = "building"
+	StateBuildFailed State = "build-failed"
+	StateRunning     State = "running"
+	StateExited      State = "exited"
+	StateCrashed     State = "crashed"
+	StateRestarting  State = "restarting"
+
+	// Forwards states
+	StateConnecting   State = "connecting"
+	StateConnected    State = "connected"
+	StateReconnecting State = "reconnecting"
+	StatePaused       State = "paused"
+
+	// Common states
+	StateStopped State = "stopped"
+)
+
+const (
+	// KindApplication and KindForward are the kinds of resources an event is about
+	KindApplication = "application"
+	KindForward     = "forward"
+
+	// eventsBufferSize is the number of events kept for a subscriber not reading them fast enough,
+	// next ones being dropped
+	eventsBufferSize = 100
+)
+
+// Application is the runtime status of a local application
+type Application struct {
+	Name      string    `json:"name"`
+	State     State     `json:"state"`
+	PID       int       `json:"pid,omitempty"`
+	StartedAt time.Time `json:"started_at"`
+	Restarts  int       `json:"restarts"`
+	ExitCode  int       `json:"exit_code"`
+	Health    string    `json:"health,omitempty"`
+	Build     *Build    `json:"build,omitempty"`
+}
+
+// Build is the result of the latest build of a local application
+type Build struct {
+	Error      string        `json:"error,omitempty"`
+	Duration   time.Duration `json:"duration"`
+	FinishedAt time.Time     `json:"finished_at"`
+}
+
+// Succeeded indicates if the build has completed without any error
+func (b *Build) Succeeded() bool {
+	return b.Error == ""
+}
+
+// Forward is the runtime status of a forward
+type Forward struct {
+	Name        string    `json:"name"`
+	State       State     `json:"state"`
+	Target      string    `json:"target,omitempty"`
+	ConnectedAt time.Time `json:"connected_at"`
+	Reconnects  int       `json:"reconnects"`
+	LastError   string    `json:"last_error,omitempty"`
+}
+
+// Event is a lifecycle change of a local application or a forward: its state has changed or it has been removed
+type Event struct {
+	Time     time.Time `json:"time"`
+	Kind     string    `json:"kind"`
+	Name     string    `json:"name"`
+	State    State     `json:"state,omitempty"`
+	Previous State     `json:"previous,omitempty"`
+	Removed  bool      `json:"removed,omitempty"`
+}
+
+// Registry keeps the runtime status of all local applications and forwards. Components update it
+// while views, commands or APIs read it.
+type Registry interface {
+	GetApplication(name string) (Application, bool)
+	GetApplications() []Application
+	UpdateApplication(name string, update func(application *Application))
+	RemoveApplication(name string)
+	GetForward(name string) (Forward, bool)
+	GetForwards() []Forward
+	UpdateForward(name string, update func(forward *Forward))
+	RemoveForward(name string)
+	Subscribe() (<-chan Event, func())
+}
+
+// registry is the concurrency-safe in-memory status registry
+type registry struct {
+	mutex        sync.RWMutex
+	applications map[string]*Application
+	forwards     map[string]*Forward
+	subscribers  map[chan Event]bool
+}
+
+// NewRegistry instanciates an empty status registry
+func NewRegistry() *registry {
+	return &registry{
+		applications: make(map[string]*Application),
+		forwards:     make(map[string]*Forward),
+		subscribers:  make(map[chan Event]bool),
+	}
+}
+
+// GetApplication returns a copy of the status of the given local application
+func (r *registry) GetApplication(name string) (Application, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	application, ok := r.applications[name]
+	if !ok {
+		return Application{}, false
+	}
+
+	return copyApplication(application), true
+}
+
+// GetApplications returns a copy of the status of all local applications, sorted by name
+func (r *registry) GetApplications() []Application {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	result := make([]Application, 0, len(r.applications))
+	for _, application := range r.applications {
+		result = append(result, copyApplication(application))
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result
+}
+
+// UpdateApplication calls the given function with the status of the local application (created when
+// it does not exist yet) so it can be modified safely
+func (r *registry) UpdateApplication(name string, update func(application *Application)) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var previous State
+
+	application, ok := r.applications[name]
+	if ok {
+		previous = application.State
+	} else {
+		application = &Application{Name: name, State: StatePending}
+		r.applications[name] = application
+	}
+
+	update(application)
+
+	if application.State != previous {
+		r.publish(Event{Kind: KindApplication, Name: name, State: application.State, Previous: previous})
+	}
+}
+
+// RemoveApplication removes the status of the given local application
+func (r *registry) RemoveApplication(name string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if application, ok := r.applications[name]; ok {
+		delete(r.applications, name)
+		r.publish(Event{Kind: KindApplication, Name: name, Previous: application.State, Removed: true})
+	}
+}
+
+// GetForward returns a copy of the status of the given forward
+func (r *registry) GetForward(name string) (Forward, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	forward, ok := r.forwards[name]
+	if !ok {
+		return Forward{}, false
+	}
+
+	return *forward, true
+}
+
+// GetForwards returns a copy of the status of all forwards, sorted by name
+func (r *registry) GetForwards() []Forward {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	result := make([]Forward, 0, len(r.forwards))
+	for _, forward := range r.forwards {
+		result = append(result, *forward)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result
+}
+
+// UpdateForward calls the given function with the status of the forward (created when it does not
+// exist yet) so it can be modified safely
+func (r *registry) UpdateForward(name string, update func(forward *Forward)) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var previous State
+
+	forward, ok := r.forwards[name]
+	if ok {
+		previous = forward.State
+	} else {
+		forward = &Forward{Name: name, State: StateConnecting}
+		r.forwards[name] = forward
+	}
+
+	update(forward)
+
+	if forward.State != previous {
+		r.publish(Event{Kind: KindForward, Name: name, State: forward.State, Previous: previous})
+	}
+}
+
+// RemoveForward removes the status of the given forward
+func (r *registry) RemoveForward(name string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if forward, ok := r.forwards[name]; ok {
+		delete(r.forwards, name)
+		r.publish(Event{Kind: KindForward, Name: name, Previous: forward.State, Removed: true})
+	}
+}
+
+// Subscribe returns a channel receiving the lifecycle events and a function to call to unsubscribe.
+// Events are dropped when the channel is full so a slow subscriber never blocks the components.
+func (r *registry) Subscribe() (<-chan Event, func()) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	events := make(chan Event, eventsBufferSize)
+	r.subscribers[events] = true
+
+	return events, func() {
+		r.mutex.Lock()
+		defer r.mutex.Unlock()
+
+		if r.subscribers[events] {
+			delete(r.subscribers, events)
+			close(events)
+		}
+	}
+}
+
+// publish sends the given event to all subscribers, registry being locked
+func (r *registry) publish(event Event) {
+	event.Time = time.Now()
+
+	for subscriber := range r.subscribers {
+		select {
+		case subscriber <- event:
+		default:
+		}
+	}
+}
+
+// copyApplication returns a copy of the given application status, not sharing its build result
+func copyApplication(application *Application) Application {
+	result := *application
+
+	if application.Build != nil {
+		build := *application.Build
+		result.Build = &build
+	}
+
+	return result
+}
@@ -0,0 +1,158 @@
+package status
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateApplication(t *testing.T) {
+	// Given
+	registry := NewRegistry()
+
+	// When
+	registry.UpdateApplication("user-api", func(application *Application) {
+		application.State = StateRunning
+		application.PID = 1234
+		application.Build = &Build{Error: "exit status 1"}
+	})
+
+	// Then
+	application, ok := registry.GetApplication("user-api")
+	assert.True(t, ok)
+	assert.Equal(t, "user-api", application.Name)
+	assert.Equal(t, StateRunning, application.State)
+	assert.Equal(t, 1234, application.PID)
+	assert.False(t, application.Build.Succeeded())
+
+	// Returned status is a copy
+	application.Build.Error = ""
+	application, _ = registry.GetApplication("user-api")
+	assert.Equal(t, "exit status 1", application.Build.Error)
+}
+
+func TestUpdateApplicationWhenNew(t *testing.T) {
+	// Given
+	registry := NewRegistry()
+
+	// When
+	registry.UpdateApplication("user-api", func(application *Application) {})
+
+	// Then
+	application, ok := registry.GetApplication("user-api")
+	assert.True(t, ok)
+	assert.Equal(t, StatePending, application.State)
+
+	_, ok = registry.GetApplication("unknown")
+	assert.False(t, ok)
+}
+
+func TestUpdateApplicationWhenConcurrent(t *testing.T) {
+	// Given
+	registry := NewRegistry()
+
+	var wg sync.WaitGroup
+
+	// When
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			registry.UpdateApplication("user-api", func(application *Application) {
+				application.Restarts++
+			})
+			registry.GetApplications()
+		}()
+	}
+
+	wg.Wait()
+
+	// Then
+	application, _ := registry.GetApplication("user-api")
+	assert.Equal(t, 100, application.Restarts)
+}
+
+func TestGetApplications(t *testing.T) {
+	// Given
+	registry := NewRegistry()
+	registry.UpdateApplication("user-api", func(application *Application) {})
+	registry.UpdateApplication("graphql", func(application *Application) {})
+	registry.UpdateApplication("removed", func(application *Application) {})
+
+	// When
+	registry.RemoveApplication("removed")
+	applications := registry.GetApplications()
+
+	// Then
+	assert.Len(t, applications, 2)
+	assert.Equal(t, "graphql", applications[0].Name)
+	assert.Equal(t, "user-api", applications[1].Name)
+}
+
+func TestUpdateForward(t *testing.T) {
+	// Given
+	registry := NewRegistry()
+
+	// When
+	registry.UpdateForward("redis", func(forward *Forward) {})
+	registry.UpdateForward("postgres", func(forward *Forward) {
+		forward.State = StateConnected
+		forward.Target = "postgres-5d8f7c6b9-x2x4z"
+		forward.Reconnects = 2
+	})
+
+	// Then
+	forward, ok := registry.GetForward("postgres")
+	assert.True(t, ok)
+	assert.Equal(t, Forward{Name: "postgres", State: StateConnected, Target: "postgres-5d8f7c6b9-x2x4z", Reconnects: 2}, forward)
+
+	forwards := registry.GetForwards()
+	assert.Len(t, forwards, 2)
+	assert.Equal(t, StateConnecting, forwards[1].State)
+
+	registry.RemoveForward("redis")
+	assert.Len(t, registry.GetForwards(), 1)
+}
+
+func TestSubscribe(t *testing.T) {
+	// Given
+	registry := NewRegistry()
+	registry.UpdateApplication("user-api", func(application *Application) {})
+
+	events, unsubscribe := registry.Subscribe()
+
+	// When
+	registry.UpdateApplication("user-api", func(application *Application) {
+		application.State = StateRunning
+	})
+	registry.UpdateApplication("user-api", func(application *Application) {
+		application.PID = 1234
+	})
+	registry.UpdateForward("graphql", func(forward *Forward) {})
+	registry.RemoveApplication("user-api")
+
+	unsubscribe()
+
+	// Then
+	received := make([]Event, 0)
+	for event := range events {
+		event.Time = time.Time{}
+		received = append(received, event)
+	}
+
+	assert.Equal(t, []Event{
+		{Kind: KindApplication, Name: "user-api", State: StateRunning, Previous: StatePending},
+		{Kind: KindForward, Name: "graphql", State: StateConnecting},
+		{Kind: KindApplication, Name: "user-api", Previous: StateRunning, Removed: true},
+	}, received)
+
+	// Events are not sent anymore once unsubscribed
+	registry.UpdateForward("graphql", func(forward *Forward) {
+		forward.State = StateConnected
+	})
+	unsubscribe()
+}
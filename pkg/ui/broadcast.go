@@ -0,0 +1,121 @@
+package ui
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// broadcastBufferSize is the number of records a subscriber can be late on before new ones are dropped
+	broadcastBufferSize = 1000
+)
+
+// Record is an entry written to a view, sent to the terminals attached to the session. Lifecycle messages
+// are sent as written (emoji included) while the output lines of the applications have a stream.
+type Record struct {
+	View string `json:"view"`
+	Entry
+}
+
+// Broadcast keeps the last records written to the views sharing it (BufferMaxLines per view)
+// and sends the new ones to its subscribers
+type Broadcast struct {
+	mutex       sync.Mutex
+	views       []string
+	records     map[string][]Record
+	subscribers map[chan Record]bool
+}
+
+// NewBroadcast returns a new broadcast without any record
+func NewBroadcast() *Broadcast {
+	return &Broadcast{
+		records:     make(map[string][]Record),
+		subscribers: make(map[chan Record]bool),
+	}
+}
+
+// Subscribe returns the records kept so far and a channel receiving the new ones until the returned
+// function is called. Records are dropped when the subscriber does not read them fast enough.
+func (b *Broadcast) Subscribe() ([]Record, <-chan Record, func()) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	history := make([]Record, 0)
+	for _, name := range b.views {
+		history = append(history, b.records[name]...)
+	}
+
+	records := make(chan Record, broadcastBufferSize)
+	b.subscribers[records] = true
+
+	var once sync.Once
+
+	return history, records, func() {
+		once.Do(func() {
+			b.mutex.Lock()
+			defer b.mutex.Unlock()
+
+			delete(b.subscribers, records)
+			close(records)
+		})
+	}
+}
+
+// publish keeps the given record and sends it to the subscribers
+func (b *Broadcast) publish(record Record) {
+	if record.Time.IsZero() {
+		record.Time = time.Now()
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	records, ok := b.records[record.View]
+	if !ok {
+		b.views = append(b.views, record.View)
+	}
+
+	records = append(records, record)
+	if len(records) > BufferMaxLines {
+		records = records[len(records)-BufferMaxLines:]
+	}
+	b.records[record.View] = records
+
+	for subscriber := range b.subscribers {
+		select {
+		case subscriber <- record:
+		default:
+		}
+	}
+}
+
+// SetBroadcast makes the logs, forwards and proxy views publish what they write to the given broadcast.
+// It has to be called before the views are given to the components.
+func (l *Layout) SetBroadcast(broadcast *Broadcast) {
+	for _, view := range []*view{l.logsView, l.forwardsView, l.proxyView} {
+		view.broadcast = broadcast
+	}
+}
+
+// WriteRecord writes a record received from a session to the view it was written to
+func (l *Layout) WriteRecord(record Record) {
+	var view *view
+
+	switch record.View {
+	case l.logsView.GetName():
+		view = l.logsView
+	case l.forwardsView.GetName():
+		view = l.forwardsView
+	case l.proxyView.GetName():
+		view = l.proxyView
+	default:
+		return
+	}
+
+	if record.Stream != "" {
+		view.WithComponent(record.Component).WriteEntry(record.Entry)
+		return
+	}
+
+	view.WithComponent(record.Component).WithName(record.Name).Write(record.Message)
+}
@@ -0,0 +1,73 @@
+package ui
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/eko/monday/internal/redact"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBroadcast(t *testing.T) {
+	// Given
+	defer redact.Reset()
+	redact.Add("p4ssw0rd")
+
+	broadcast := NewBroadcast()
+
+	logs := NewJSONView("logs", NewJSONOutput(bytes.NewBuffer(nil)))
+	logs.broadcast = broadcast
+
+	forwards := NewJSONView("forwards", NewJSONOutput(bytes.NewBuffer(nil)))
+	forwards.broadcast = broadcast
+
+	logs.WithComponent("runner").Writef("🏁  Running local app '%s' (%s)...\n", "graphql", "/")
+
+	// When
+	history, records, unsubscribe := broadcast.Subscribe()
+
+	forwards.WithComponent("forwarder").Writef("📡  Forwarding '%s'...\n", "kubernetes-pod")
+	logs.WriteEntry(Entry{Name: "graphql", Stream: "stderr", Message: "password is p4ssw0rd"})
+
+	unsubscribe()
+	unsubscribe()
+
+	// Then
+	assert.Len(t, history, 1)
+	assert.Equal(t, "logs", history[0].View)
+	assert.Equal(t, "runner", history[0].Component)
+	assert.Equal(t, "🏁  Running local app 'graphql' (/)...\n", history[0].Message)
+	assert.False(t, history[0].Time.IsZero())
+
+	received := make([]Record, 0)
+	for record := range records {
+		received = append(received, record)
+	}
+
+	assert.Len(t, received, 2)
+	assert.Equal(t, "forwards", received[0].View)
+	assert.Equal(t, "forwarder", received[0].Component)
+	assert.Equal(t, "", received[0].Stream)
+
+	assert.Equal(t, "logs", received[1].View)
+	assert.Equal(t, "graphql", received[1].Name)
+	assert.Equal(t, "stderr", received[1].Stream)
+	assert.Equal(t, "password is ******", received[1].Message)
+}
+
+func TestBroadcastKeepsLastRecords(t *testing.T) {
+	// Given
+	broadcast := NewBroadcast()
+
+	// When
+	for i := 0; i < BufferMaxLines+10; i++ {
+		broadcast.publish(Record{View: "logs", Entry: Entry{Message: "line\n"}})
+	}
+	broadcast.publish(Record{View: "proxy", Entry: Entry{Message: "line\n"}})
+
+	// Then
+	history, _, unsubscribe := broadcast.Subscribe()
+	defer unsubscribe()
+
+	assert.Len(t, history, BufferMaxLines+1)
+}
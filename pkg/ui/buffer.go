@@ -0,0 +1,215 @@
+package ui
+
+import (
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+const (
+	// BufferMaxLines is the number of lines kept in each view of the terminal UI
+	BufferMaxLines = 5000
+
+	colorReset     = "\x1b[0m"
+	colorHighlight = "\x1b[30;43m"
+)
+
+var (
+	// streamColors lists the colors of the application names prefixing their output lines
+	streamColors = map[string]string{
+		"stdout": "\x1b[32m",
+		"stderr": "\x1b[31m",
+	}
+
+	// ansiCodes matches the escape sequences used to color the lines
+	ansiCodes = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+	// hasColors indicates if the terminal supports colors, when the UI is not enabled
+	hasColors = regexp.MustCompile(`^(xterm|screen)`).MatchString(os.Getenv("TERM"))
+)
+
+// Filter selects the lines displayed in a view and the text searched in them
+type Filter struct {
+	// Applications lists the applications (or forwards) whose lines are displayed, all of them when empty
+	Applications []string
+	StderrOnly   bool
+	Search       string
+}
+
+// matches indicates if the given line has to be displayed
+func (f Filter) matches(line *line) bool {
+	if f.StderrOnly && line.stream != "stderr" {
+		return false
+	}
+
+	if len(f.Applications) == 0 {
+		return true
+	}
+
+	for _, name := range f.Applications {
+		if line.name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// line is a line written to a view, with the application (or forward) and stream it comes from
+type line struct {
+	name   string
+	stream string
+	text   string
+	open   bool
+}
+
+// buffer keeps the last lines written to a view so they can be filtered and searched
+type buffer struct {
+	mutex    sync.Mutex
+	maxLines int
+	lines    []*line
+	evicted  int
+	filter   Filter
+}
+
+// newBuffer returns a new buffer keeping at most the given number of lines
+func newBuffer(maxLines int) *buffer {
+	return &buffer{
+		maxLines: maxLines,
+		lines:    make([]*line, 0),
+	}
+}
+
+// add appends the given text, split into lines, and returns the text to display for it. When the whole
+// buffer has to be displayed again (old lines being evicted), rerender is true.
+func (b *buffer) add(name, stream, text string) (display string, rerender bool) {
+	var result strings.Builder
+
+	for text != "" {
+		var segment string
+
+		if index := strings.Index(text, "\n"); index >= 0 {
+			segment, text = text[:index+1], text[index+1:]
+		} else {
+			segment, text = text, ""
+		}
+
+		// Text written after an incomplete line continues it
+		current := &line{name: name, stream: stream}
+		if last := len(b.lines) - 1; last >= 0 && b.lines[last].open {
+			current = b.lines[last]
+		} else {
+			b.lines = append(b.lines, current)
+		}
+
+		current.text += segment
+		current.open = !strings.HasSuffix(segment, "\n")
+
+		if b.filter.matches(current) {
+			result.WriteString(b.format(segment))
+		}
+	}
+
+	if overflow := len(b.lines) - b.maxLines; overflow > 0 {
+		b.lines = b.lines[overflow:]
+		b.evicted += overflow
+	}
+
+	// Evicted lines are only removed from the view once in a while as the whole view is displayed again
+	if b.evicted >= b.maxLines/10 {
+		b.evicted = 0
+		return "", true
+	}
+
+	return result.String(), false
+}
+
+// render returns the text of all the lines matching the filter and the rows (given the view width) of the
+// lines matching the search
+func (b *buffer) render(width int) (string, []int) {
+	var result strings.Builder
+
+	rows := make([]int, 0)
+	row := 0
+
+	for _, line := range b.lines {
+		if !b.filter.matches(line) {
+			continue
+		}
+
+		if b.filter.Search != "" && containsFold(stripColors(line.text), b.filter.Search) {
+			rows = append(rows, row)
+		}
+
+		result.WriteString(b.format(line.text))
+		row += countRows(line.text, width)
+	}
+
+	return result.String(), rows
+}
+
+// format returns the given text with the searched text highlighted
+func (b *buffer) format(text string) string {
+	if b.filter.Search == "" {
+		return text
+	}
+
+	stripped := stripColors(text)
+	if !containsFold(stripped, b.filter.Search) {
+		return text
+	}
+
+	var result strings.Builder
+
+	lower, search := strings.ToLower(stripped), strings.ToLower(b.filter.Search)
+
+	// Some characters change size once lowered, the search is then case sensitive
+	if len(lower) != len(stripped) || len(search) != len(b.filter.Search) {
+		lower, search = stripped, b.filter.Search
+	}
+
+	for {
+		index := strings.Index(lower, search)
+		if index < 0 {
+			result.WriteString(stripped)
+			break
+		}
+
+		end := index + len(search)
+		result.WriteString(stripped[:index] + colorHighlight + stripped[index:end] + colorReset)
+
+		stripped, lower = stripped[end:], lower[end:]
+	}
+
+	return result.String()
+}
+
+// formatEntry returns the text displayed for an output line of an application
+func formatEntry(entry Entry) string {
+	if color, ok := streamColors[entry.Stream]; ok {
+		return color + entry.Name + colorReset + " " + entry.Message + "\n"
+	}
+
+	return entry.Stream + entry.Message + "\n"
+}
+
+// countRows returns the number of rows a line takes in a wrapped view of the given width
+func countRows(text string, width int) int {
+	length := utf8.RuneCountInString(strings.TrimSuffix(stripColors(text), "\n"))
+
+	if width <= 0 || length <= width {
+		return 1
+	}
+
+	return (length + width - 1) / width
+}
+
+func stripColors(text string) string {
+	return ansiCodes.ReplaceAllString(text, "")
+}
+
+func containsFold(text, search string) bool {
+	return strings.Contains(strings.ToLower(text), strings.ToLower(search))
+}
@@ -0,0 +1,114 @@
+package ui
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBufferAdd(t *testing.T) {
+	// Given
+	b := newBuffer(100)
+	b.filter = Filter{Applications: []string{"graphql"}}
+
+	// When
+	graphql, _ := b.add("graphql", "stdout", "first line\nsecond ")
+	continued, _ := b.add("", "", "line\n")
+	userAPI, rerender := b.add("user-api", "stdout", "other line\n")
+
+	// Then
+	assert.Equal(t, "first line\nsecond ", graphql)
+	assert.Equal(t, "line\n", continued)
+	assert.Equal(t, "", userAPI)
+	assert.False(t, rerender)
+
+	assert.Len(t, b.lines, 3)
+	assert.Equal(t, "graphql", b.lines[1].name)
+	assert.Equal(t, "second line\n", b.lines[1].text)
+}
+
+func TestBufferAddWhenFull(t *testing.T) {
+	// Given
+	b := newBuffer(20)
+
+	// When
+	rerenders := 0
+	for i := 0; i < 30; i++ {
+		if _, rerender := b.add("graphql", "stdout", fmt.Sprintf("line %d\n", i)); rerender {
+			rerenders++
+		}
+	}
+
+	// Then
+	assert.Len(t, b.lines, 20)
+	assert.Equal(t, "line 10\n", b.lines[0].text)
+	assert.Equal(t, 5, rerenders)
+}
+
+func TestBufferRender(t *testing.T) {
+	// Given
+	b := newBuffer(100)
+	b.add("graphql", "stdout", formatEntry(Entry{Name: "graphql", Stream: "stdout", Message: "listening on :8080"}))
+	b.add("graphql", "stderr", formatEntry(Entry{Name: "graphql", Stream: "stderr", Message: "connection refused to a very long hostname"}))
+	b.add("user-api", "stderr", formatEntry(Entry{Name: "user-api", Stream: "stderr", Message: "Connection refused"}))
+	b.add("user-api", "", "🏁  Running local app 'user-api'\n")
+
+	testCases := []struct {
+		name         string
+		filter       Filter
+		expectedText string
+		expectedRows []int
+	}{
+		{
+			name:   "all lines",
+			filter: Filter{},
+			expectedText: "\x1b[32mgraphql\x1b[0m listening on :8080\n" +
+				"\x1b[31mgraphql\x1b[0m connection refused to a very long hostname\n" +
+				"\x1b[31muser-api\x1b[0m Connection refused\n" +
+				"🏁  Running local app 'user-api'\n",
+			expectedRows: []int{},
+		},
+		{
+			name:   "errors only",
+			filter: Filter{StderrOnly: true},
+			expectedText: "\x1b[31mgraphql\x1b[0m connection refused to a very long hostname\n" +
+				"\x1b[31muser-api\x1b[0m Connection refused\n",
+			expectedRows: []int{},
+		},
+		{
+			name:   "search",
+			filter: Filter{Search: "REFUSED"},
+			expectedText: "\x1b[32mgraphql\x1b[0m listening on :8080\n" +
+				"graphql connection \x1b[30;43mrefused\x1b[0m to a very long hostname\n" +
+				"user-api Connection \x1b[30;43mrefused\x1b[0m\n" +
+				"🏁  Running local app 'user-api'\n",
+			expectedRows: []int{1, 3},
+		},
+		{
+			name:         "search in selected application",
+			filter:       Filter{Applications: []string{"user-api"}, Search: "refused"},
+			expectedText: "user-api Connection \x1b[30;43mrefused\x1b[0m\n" + "🏁  Running local app 'user-api'\n",
+			expectedRows: []int{0},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			b.filter = testCase.filter
+
+			// When
+			text, rows := b.render(30)
+
+			// Then
+			assert.Equal(t, testCase.expectedText, text)
+			assert.Equal(t, testCase.expectedRows, rows)
+		})
+	}
+}
+
+func TestSplitNames(t *testing.T) {
+	// When - Then
+	assert.Equal(t, []string{"graphql", "user-api"}, splitNames(" graphql,,user-api "))
+	assert.Equal(t, []string{}, splitNames(""))
+}
@@ -0,0 +1,358 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jroimartin/gocui"
+)
+
+const (
+	ControlApplication = "app"
+	ControlForward     = "forward"
+
+	// controlsMaxWidth is the width of the controls view (on the right of the logs view), on large enough terminals
+	controlsMaxWidth = 40
+
+	controlsViewName = "controls"
+	menuViewName     = "menu"
+	confirmViewName  = "confirm"
+)
+
+var (
+	// stateColors lists the colors of the states displayed in the controls view, others being yellow
+	stateColors = map[string]string{
+		"running":      "\x1b[32m",
+		"connected":    "\x1b[32m",
+		"build-failed": "\x1b[31m",
+		"crashed":      "\x1b[31m",
+		"exited":       "\x1b[31m",
+		"stopped":      "\x1b[37m",
+		"paused":       "\x1b[37m",
+	}
+)
+
+// ControlItem is a local application or a forward listed in the controls view
+type ControlItem struct {
+	Kind  string
+	Name  string
+	State string
+}
+
+// ControlAction is an action that can be run on the selected item of the controls view. When Confirm is true,
+// the action is only run once confirmed as it interrupts a running application or connection.
+type ControlAction struct {
+	Key     rune
+	Label   string
+	Confirm bool
+	Run     func(name string)
+}
+
+// SetControls sets the function listing the items of the controls view and the actions available on each kind of item
+func (l *Layout) SetControls(items func() []ControlItem, actions map[string][]ControlAction) {
+	if !l.uiEnabled {
+		return
+	}
+
+	l.controlItems = items
+	l.controlActions = actions
+
+	keys := make(map[rune]bool)
+
+	for _, kindActions := range actions {
+		for _, action := range kindActions {
+			if keys[action.Key] {
+				continue
+			}
+
+			keys[action.Key] = true
+
+			key := action.Key
+			if err := l.gui.SetKeybinding(menuViewName, key, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+				if index := l.actionIndex(key); index >= 0 {
+					l.menuIndex = index
+					return l.selectAction()
+				}
+
+				return nil
+			}); err != nil {
+				panic(err)
+			}
+		}
+	}
+}
+
+func (l *Layout) setControlsKeyBindings() {
+	// Open the actions menu of the selected item
+	if err := l.gui.SetKeybinding("", gocui.KeyEnter, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if isDialog(v) || l.getActiveView() != l.controlsView {
+			return nil
+		}
+
+		return l.openMenu()
+	}); err != nil {
+		panic(err)
+	}
+
+	// Select an action in the menu
+	for key, move := range map[gocui.Key]int{gocui.KeyArrowUp: -1, gocui.KeyArrowDown: 1} {
+		move := move
+
+		if err := l.gui.SetKeybinding(menuViewName, key, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+			l.moveMenu(move)
+			l.renderMenu(v)
+
+			return nil
+		}); err != nil {
+			panic(err)
+		}
+	}
+
+	if err := l.gui.SetKeybinding(menuViewName, gocui.KeyEnter, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return l.selectAction()
+	}); err != nil {
+		panic(err)
+	}
+
+	// Confirm or cancel a destructive action
+	for key, confirmed := range map[rune]bool{'y': true, 'n': false} {
+		confirmed := confirmed
+
+		if err := l.gui.SetKeybinding(confirmViewName, key, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+			if err := l.closeDialog(confirmViewName); err != nil {
+				return err
+			}
+
+			l.confirmAction(confirmed)
+
+			return nil
+		}); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// moveSelection selects the previous (or next) item of the controls view
+func (l *Layout) moveSelection(move int) {
+	if len(l.controls) == 0 {
+		return
+	}
+
+	l.selected = (l.selected + move + len(l.controls)) % len(l.controls)
+	l.renderControls()
+}
+
+// renderControls displays the items of the controls view with their state, the selected one being marked
+func (l *Layout) renderControls() {
+	if l.controlItems == nil {
+		return
+	}
+
+	l.controls = l.controlItems()
+
+	if l.selected >= len(l.controls) {
+		l.selected = len(l.controls) - 1
+	}
+
+	if l.selected < 0 {
+		l.selected = 0
+	}
+
+	v := l.controlsView.GetView()
+	if v == nil {
+		return
+	}
+
+	selected := -1
+	if l.highlighted == l.controlsView {
+		selected = l.selected
+	}
+
+	v.Clear()
+	v.Write([]byte(formatControls(l.controls, selected)))
+
+	// Keep the selected item visible
+	_, height := v.Size()
+	if ox, oy := v.Origin(); l.selected < oy {
+		v.SetOrigin(ox, l.selected)
+	} else if height > 0 && l.selected >= oy+height {
+		v.SetOrigin(ox, l.selected-height+1)
+	}
+}
+
+// formatControls returns the lines of the controls view, the item at the given index being marked
+func formatControls(items []ControlItem, selected int) string {
+	width := 0
+	for _, item := range items {
+		if len(item.Name) > width {
+			width = len(item.Name)
+		}
+	}
+
+	var result strings.Builder
+
+	for index, item := range items {
+		marker := " "
+		if index == selected {
+			marker = "▸"
+		}
+
+		color, ok := stateColors[item.State]
+		if !ok {
+			color = "\x1b[33m"
+		}
+
+		fmt.Fprintf(&result, "%s %-7s %-*s %s%s%s\n", marker, item.Kind, width, item.Name, color, item.State, colorReset)
+	}
+
+	return result.String()
+}
+
+// openMenu displays the actions available on the selected item of the controls view
+func (l *Layout) openMenu() error {
+	if len(l.controls) == 0 {
+		return nil
+	}
+
+	l.dialogItem = l.controls[l.selected]
+	l.menuIndex = 0
+
+	actions := l.controlActions[l.dialogItem.Kind]
+	if len(actions) == 0 {
+		return nil
+	}
+
+	v, err := l.openDialog(menuViewName, len(actions))
+	if err != nil {
+		return err
+	}
+
+	v.Title = fmt.Sprintf(" %s '%s' (Enter: run, Esc: cancel) ", l.dialogItem.Kind, l.dialogItem.Name)
+	l.renderMenu(v)
+
+	return nil
+}
+
+// renderMenu displays the actions of the menu, the selected one being highlighted
+func (l *Layout) renderMenu(v *gocui.View) {
+	v.Clear()
+
+	for index, action := range l.controlActions[l.dialogItem.Kind] {
+		line := fmt.Sprintf(" %c  %s", action.Key, action.Label)
+		if index == l.menuIndex {
+			line = "\x1b[7m" + line + colorReset
+		}
+
+		fmt.Fprintln(v, line)
+	}
+}
+
+// actionIndex returns the index of the action of the given key in the menu, or -1 when there is none
+func (l *Layout) actionIndex(key rune) int {
+	for index, action := range l.controlActions[l.dialogItem.Kind] {
+		if action.Key == key {
+			return index
+		}
+	}
+
+	return -1
+}
+
+// moveMenu selects the previous (or next) action of the menu
+func (l *Layout) moveMenu(move int) {
+	actions := l.controlActions[l.dialogItem.Kind]
+	if len(actions) == 0 {
+		return
+	}
+
+	l.menuIndex = (l.menuIndex + move + len(actions)) % len(actions)
+}
+
+// dispatchAction runs the action selected in the menu on the item of the dialog, unless it interrupts it:
+// it returns true when the action has to be confirmed first
+func (l *Layout) dispatchAction() bool {
+	l.dialogAction = l.controlActions[l.dialogItem.Kind][l.menuIndex]
+
+	if l.dialogAction.Confirm {
+		return true
+	}
+
+	go l.dialogAction.Run(l.dialogItem.Name)
+
+	return false
+}
+
+// confirmAction runs the action waiting for a confirmation once confirmed
+func (l *Layout) confirmAction(confirmed bool) {
+	if confirmed {
+		go l.dialogAction.Run(l.dialogItem.Name)
+	}
+}
+
+// selectAction runs the action selected in the menu, once confirmed when needed
+func (l *Layout) selectAction() error {
+	if err := l.closeDialog(menuViewName); err != nil {
+		return err
+	}
+
+	if !l.dispatchAction() {
+		return nil
+	}
+
+	v, err := l.openDialog(confirmViewName, 1)
+	if err != nil {
+		return err
+	}
+
+	v.Title = " Confirmation (y: confirm, n: cancel) "
+	fmt.Fprintf(v, " %s %s '%s'?", l.dialogAction.Label, l.dialogItem.Kind, l.dialogItem.Name)
+
+	return nil
+}
+
+// openDialog displays a dialog having the given number of lines in the middle of the screen
+func (l *Layout) openDialog(name string, lines int) (*gocui.View, error) {
+	maxX, maxY := l.gui.Size()
+	x, y := (maxX-60)/2, (maxY-lines-2)/2
+
+	v, err := l.gui.SetView(name, x, y, x+60, y+lines+1)
+	if err != nil && err != gocui.ErrUnknownView {
+		return nil, err
+	}
+
+	v.Clear()
+
+	l.dialogPreviousView = l.highlighted.GetName()
+	if current := l.gui.CurrentView(); current != nil && !isDialog(current) {
+		l.dialogPreviousView = current.Name()
+	}
+
+	l.gui.SetViewOnTop(name)
+
+	_, err = l.gui.SetCurrentView(name)
+	return v, err
+}
+
+// closeDialog removes the given dialog and gives the focus back to the previous view
+func (l *Layout) closeDialog(name string) error {
+	if err := l.gui.DeleteView(name); err != nil {
+		return nil
+	}
+
+	_, err := l.gui.SetCurrentView(l.dialogPreviousView)
+	return err
+}
+
+// isDialog indicates if the given view is the prompt or a dialog, in which the keys are not global commands
+func isDialog(v *gocui.View) bool {
+	if v == nil {
+		return false
+	}
+
+	switch v.Name() {
+	case promptViewName, menuViewName, confirmViewName:
+		return true
+	}
+
+	return false
+}
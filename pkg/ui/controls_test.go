@@ -0,0 +1,209 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatControls(t *testing.T) {
+	// Given
+	items := []ControlItem{
+		{Kind: ControlApplication, Name: "graphql", State: "running"},
+		{Kind: ControlApplication, Name: "user-api", State: "building"},
+		{Kind: ControlForward, Name: "order-api", State: "paused"},
+	}
+
+	// When
+	result := formatControls(items, 1)
+
+	// Then
+	assert.Equal(t, ""+
+		"  app     graphql   \x1b[32mrunning\x1b[0m\n"+
+		"▸ app     user-api  \x1b[33mbuilding\x1b[0m\n"+
+		"  forward order-api \x1b[37mpaused\x1b[0m\n", result)
+}
+
+func TestLayoutMoveSelection(t *testing.T) {
+	// Given
+	l := getControlsLayout(nil)
+	l.renderControls()
+
+	testCases := []struct {
+		move     int
+		expected int
+	}{
+		{move: 1, expected: 1},
+		{move: 1, expected: 2},
+		{move: 1, expected: 0},
+		{move: -1, expected: 2},
+		{move: -1, expected: 1},
+	}
+
+	for _, testCase := range testCases {
+		// When
+		l.moveSelection(testCase.move)
+
+		// Then
+		assert.Equal(t, testCase.expected, l.selected)
+	}
+}
+
+func TestLayoutRenderControlsWhenItemsAreRemoved(t *testing.T) {
+	// Given
+	items := []ControlItem{
+		{Kind: ControlApplication, Name: "graphql", State: "running"},
+		{Kind: ControlApplication, Name: "user-api", State: "running"},
+	}
+
+	l := getControlsLayout(nil)
+	l.controlItems = func() []ControlItem {
+		return items
+	}
+	l.selected = 1
+
+	// When
+	items = items[:1]
+	l.renderControls()
+
+	// Then
+	assert.Equal(t, 0, l.selected)
+	assert.Len(t, l.controls, 1)
+}
+
+func TestLayoutActionIndex(t *testing.T) {
+	// Given
+	l := getControlsLayout(nil)
+	l.dialogItem = ControlItem{Kind: ControlForward, Name: "order-api"}
+
+	// When - Then
+	assert.Equal(t, 0, l.actionIndex('r'))
+	assert.Equal(t, 1, l.actionIndex('u'))
+	assert.Equal(t, -1, l.actionIndex('x'))
+}
+
+func TestLayoutMoveMenu(t *testing.T) {
+	// Given
+	l := getControlsLayout(nil)
+	l.dialogItem = ControlItem{Kind: ControlApplication, Name: "graphql"}
+
+	testCases := []struct {
+		move     int
+		expected int
+	}{
+		{move: 1, expected: 1},
+		{move: 1, expected: 2},
+		{move: 1, expected: 0},
+		{move: -1, expected: 2},
+	}
+
+	for _, testCase := range testCases {
+		// When
+		l.moveMenu(testCase.move)
+
+		// Then
+		assert.Equal(t, testCase.expected, l.menuIndex)
+	}
+}
+
+func TestLayoutDispatchAction(t *testing.T) {
+	// Given
+	testCases := []struct {
+		name            string
+		item            ControlItem
+		menuIndex       int
+		expectedConfirm bool
+		expectedRun     string
+	}{
+		{name: "application action", item: ControlItem{Kind: ControlApplication, Name: "graphql"}, menuIndex: 0, expectedConfirm: false, expectedRun: "start graphql"},
+		{name: "destructive application action", item: ControlItem{Kind: ControlApplication, Name: "graphql"}, menuIndex: 1, expectedConfirm: true},
+		{name: "forward action", item: ControlItem{Kind: ControlForward, Name: "order-api"}, menuIndex: 1, expectedConfirm: false, expectedRun: "resume order-api"},
+		{name: "destructive forward action", item: ControlItem{Kind: ControlForward, Name: "order-api"}, menuIndex: 0, expectedConfirm: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			runs := make(chan string, 1)
+
+			l := getControlsLayout(runs)
+			l.dialogItem = testCase.item
+			l.menuIndex = testCase.menuIndex
+
+			// When
+			confirm := l.dispatchAction()
+
+			// Then
+			assert.Equal(t, testCase.expectedConfirm, confirm)
+			assert.Equal(t, testCase.expectedRun, waitRun(runs))
+		})
+	}
+}
+
+func TestLayoutConfirmAction(t *testing.T) {
+	// Given
+	testCases := []struct {
+		name        string
+		confirmed   bool
+		expectedRun string
+	}{
+		{name: "confirmed", confirmed: true, expectedRun: "stop graphql"},
+		{name: "cancelled", confirmed: false, expectedRun: ""},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			runs := make(chan string, 1)
+
+			l := getControlsLayout(runs)
+			l.dialogItem = ControlItem{Kind: ControlApplication, Name: "graphql"}
+			l.menuIndex = 1
+
+			assert.True(t, l.dispatchAction())
+
+			// When
+			l.confirmAction(testCase.confirmed)
+
+			// Then
+			assert.Equal(t, testCase.expectedRun, waitRun(runs))
+		})
+	}
+}
+
+func getControlsLayout(runs chan string) *Layout {
+	action := func(key rune, label string, confirm bool) ControlAction {
+		return ControlAction{
+			Key:     key,
+			Label:   label,
+			Confirm: confirm,
+			Run: func(name string) {
+				runs <- label + " " + name
+			},
+		}
+	}
+
+	return &Layout{
+		controlsView: NewView("controls", "Controls", nil),
+		controlItems: func() []ControlItem {
+			return []ControlItem{
+				{Kind: ControlApplication, Name: "graphql", State: "running"},
+				{Kind: ControlApplication, Name: "user-api", State: "running"},
+				{Kind: ControlForward, Name: "order-api", State: "connected"},
+			}
+		},
+		controlActions: map[string][]ControlAction{
+			ControlApplication: {action('s', "start", false), action('x', "stop", true), action('b', "rebuild", true)},
+			ControlForward:     {action('r', "reconnect", true), action('u', "resume", false)},
+		},
+	}
+}
+
+// waitRun returns the action run in the background, or an empty string when none has been run
+func waitRun(runs chan string) string {
+	select {
+	case run := <-runs:
+		return run
+	case <-time.After(100 * time.Millisecond):
+		return ""
+	}
+}
@@ -0,0 +1,211 @@
+package ui
+
+import (
+	"encoding/json"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/eko/monday/internal/redact"
+)
+
+const (
+	OutputText = "text"
+	OutputJSON = "json"
+
+	LevelInfo  = "info"
+	LevelWarn  = "warn"
+	LevelError = "error"
+)
+
+var (
+	// AvailableOutputs lists the formats Monday can write its output with when the UI is not enabled
+	AvailableOutputs = map[string]bool{
+		OutputText: true,
+		OutputJSON: true,
+	}
+
+	// levels lists the message prefixes that are not informative
+	levels = map[string]string{
+		"❌":  LevelError,
+		"💔":  LevelWarn,
+		"👓":  LevelWarn,
+		"⚠️": LevelWarn,
+	}
+
+	// quotedName matches the first quoted name of a message, being the application or forward it is about
+	quotedName = regexp.MustCompile(`'([^']+)'`)
+)
+
+// Entry is a structured output line
+type Entry struct {
+	Time      time.Time `json:"time"`
+	Level     string    `json:"level"`
+	Component string    `json:"component,omitempty"`
+	Name      string    `json:"name,omitempty"`
+	Stream    string    `json:"stream,omitempty"`
+	Message   string    `json:"message"`
+}
+
+// StructuredView is implemented by the views that can write structured entries instead of plain text
+type StructuredView interface {
+	IsStructured() bool
+	WriteEntry(entry Entry)
+}
+
+// JSONOutput writes entries as JSON objects, one per line. It can be shared by multiple views.
+type JSONOutput struct {
+	mutex   sync.Mutex
+	encoder *json.Encoder
+}
+
+// NewJSONOutput returns a new JSON output writing to the given writer
+func NewJSONOutput(writer io.Writer) *JSONOutput {
+	encoder := json.NewEncoder(writer)
+	encoder.SetEscapeHTML(false)
+
+	return &JSONOutput{encoder: encoder}
+}
+
+// NewJSONView returns a new instance of a view writing its messages as JSON objects
+func NewJSONView(name string, output *JSONOutput) *view {
+	return &view{
+		name:   name,
+		output: output,
+	}
+}
+
+// WithComponent returns a copy of the view, its structured entries being attributed to the given component
+func (v *view) WithComponent(component string) *view {
+	result := *v
+	result.component = component
+
+	return &result
+}
+
+// WithName returns a copy of the view, its messages being attributed to the given application or forward
+// instead of the first name they quote
+func (v *view) WithName(name string) *view {
+	result := *v
+	result.entryName = name
+
+	return &result
+}
+
+// ViewWithName returns the given view with its messages attributed to the given application or forward,
+// when the view supports it
+func ViewWithName(v View, name string) View {
+	if named, ok := v.(*view); ok {
+		return named.WithName(name)
+	}
+
+	return v
+}
+
+// IsStructured indicates if the view writes structured entries
+func (v *view) IsStructured() bool {
+	return v.output != nil || v.buffer != nil || v.broadcast != nil
+}
+
+// WriteEntry writes the given entry, completed with the view component, secret values being masked
+func (v *view) WriteEntry(entry Entry) {
+	if v.broadcast != nil {
+		record := Record{View: v.name, Entry: entry}
+		record.Component = v.component
+		record.Message = redact.String(record.Message)
+		record.Name = redact.String(record.Name)
+
+		v.broadcast.publish(record)
+	}
+
+	v.writeEntry(entry)
+}
+
+// writeEntry writes the given entry to the JSON output, the buffer or the terminal
+func (v *view) writeEntry(entry Entry) {
+	if v.output == nil {
+		entry.Message = redact.String(entry.Message)
+
+		if v.buffer == nil {
+			text := formatEntry(entry)
+			if !hasColors {
+				text = stripColors(text)
+			}
+
+			v.write(text)
+			return
+		}
+
+		v.writeBuffer(entry.Name, entry.Stream, formatEntry(entry))
+		return
+	}
+
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+
+	if entry.Level == "" {
+		entry.Level = LevelInfo
+	}
+
+	if entry.Component == "" {
+		entry.Component = v.component
+	}
+
+	entry.Message = redact.String(entry.Message)
+	entry.Name = redact.String(entry.Name)
+
+	v.output.mutex.Lock()
+	defer v.output.mutex.Unlock()
+
+	v.output.encoder.Encode(entry)
+}
+
+// writeMessage writes a lifecycle message as an entry, its level and name being guessed from the message
+func (v *view) writeMessage(str string) {
+	message := strings.TrimSpace(str)
+	if message == "" {
+		return
+	}
+
+	level := LevelInfo
+
+	// Messages are prefixed by an emoji that gives their level
+	if prefix, rest, ok := strings.Cut(message, " "); ok && isEmoji(prefix) {
+		if value, ok := levels[prefix]; ok {
+			level = value
+		}
+
+		message = strings.TrimSpace(rest)
+	}
+
+	v.writeEntry(Entry{Level: level, Name: v.nameOf(message), Message: message})
+}
+
+// nameOf returns the application or forward a message is about, being the one of the view if any
+func (v *view) nameOf(message string) string {
+	if v.entryName != "" {
+		return v.entryName
+	}
+
+	return guessName(message)
+}
+
+// guessName returns the application or forward a message is about, being the first quoted name
+func guessName(message string) string {
+	if matches := quotedName.FindStringSubmatch(message); matches != nil {
+		return matches[1]
+	}
+
+	return ""
+}
+
+// isEmoji indicates if the given message prefix does not contain any ASCII character
+func isEmoji(prefix string) bool {
+	return strings.IndexFunc(prefix, func(r rune) bool {
+		return r < utf8.RuneSelf
+	}) == -1
+}
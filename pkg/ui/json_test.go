@@ -0,0 +1,94 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/eko/monday/internal/redact"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONViewWrite(t *testing.T) {
+	// Given
+	defer redact.Reset()
+	redact.Add("p4ssw0rd")
+
+	buffer := bytes.NewBuffer(nil)
+	v := NewJSONView("logs", NewJSONOutput(buffer)).WithComponent("runner")
+
+	// When
+	v.Writef("🏁  Running local app '%s' (%s)...\n", "graphql", "/")
+	v.Writef("❌  Local app '%s' did not stop within %s, killing it\n", "graphql", "10s")
+	v.Write("\n")
+	v.WriteEntry(Entry{Name: "graphql", Stream: "stderr", Message: "password is p4ssw0rd"})
+
+	// Then
+	entries := decodeEntries(t, buffer)
+	assert.Len(t, entries, 3)
+
+	assert.Equal(t, LevelInfo, entries[0].Level)
+	assert.Equal(t, "runner", entries[0].Component)
+	assert.Equal(t, "graphql", entries[0].Name)
+	assert.Equal(t, "", entries[0].Stream)
+	assert.Equal(t, "Running local app 'graphql' (/)...", entries[0].Message)
+	assert.False(t, entries[0].Time.IsZero())
+
+	assert.Equal(t, LevelError, entries[1].Level)
+	assert.Equal(t, "Local app 'graphql' did not stop within 10s, killing it", entries[1].Message)
+
+	assert.Equal(t, LevelInfo, entries[2].Level)
+	assert.Equal(t, "runner", entries[2].Component)
+	assert.Equal(t, "graphql", entries[2].Name)
+	assert.Equal(t, "stderr", entries[2].Stream)
+	assert.Equal(t, "password is ******", entries[2].Message)
+}
+
+func TestJSONViewWriteWithName(t *testing.T) {
+	// Given
+	buffer := bytes.NewBuffer(nil)
+	v := ViewWithName(NewJSONView("logs", NewJSONOutput(buffer)).WithComponent("runner"), "graphql")
+
+	// When
+	v.Writef("❌  Cannot run the application %s on path %s: %v\n", "graphql", "/tmp/graphql", "exit status 1")
+	v.Writef("❌  Please declare a 'run' section for application %s\n", "graphql")
+
+	// Then
+	entries := decodeEntries(t, buffer)
+	assert.Len(t, entries, 2)
+
+	for _, entry := range entries {
+		assert.Equal(t, LevelError, entry.Level)
+		assert.Equal(t, "runner", entry.Component)
+		assert.Equal(t, "graphql", entry.Name)
+	}
+}
+
+func TestViewWithNameWhenNotSupported(t *testing.T) {
+	// Given
+	v := &MockView{}
+
+	// When - Then
+	assert.Same(t, v, ViewWithName(v, "graphql"))
+}
+
+func TestViewIsStructured(t *testing.T) {
+	// When - Then
+	assert.False(t, NewEmptyView("logs").IsStructured())
+	assert.False(t, NewEmptyView("logs").WithComponent("runner").IsStructured())
+	assert.True(t, NewJSONView("logs", NewJSONOutput(bytes.NewBuffer(nil))).IsStructured())
+}
+
+func decodeEntries(t *testing.T, buffer *bytes.Buffer) []Entry {
+	entries := make([]Entry, 0)
+
+	for _, line := range strings.Split(strings.TrimSpace(buffer.String()), "\n") {
+		var entry Entry
+		assert.Nil(t, json.Unmarshal([]byte(line), &entry))
+
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
@@ -2,6 +2,7 @@ package ui
 
 import (
 	"fmt"
+	"os"
 	"time"
 
 	"github.com/jroimartin/gocui"
@@ -10,6 +11,7 @@ import (
 // Layout is the structure of the gui layout
 type Layout struct {
 	uiEnabled      bool
+	output         string
 	gui            *gocui.Gui
 	highlighted    *view
 	statusView     *view
@@ -17,13 +19,31 @@ type Layout struct {
 	logsView       *view
 	forwardsView   *view
 	proxyView      *view
+	controlsView   *view
 	viewsOrder     map[string]*view
+
+	// Logs view tabs (0 being all applications) and prompt state
+	applications       []string
+	tab                int
+	promptCallback     func(value string)
+	promptPreviousView string
+
+	// Controls view items, selection and dialogs state
+	controlItems       func() []ControlItem
+	controlActions     map[string][]ControlAction
+	controls           []ControlItem
+	selected           int
+	menuIndex          int
+	dialogItem         ControlItem
+	dialogAction       ControlAction
+	dialogPreviousView string
 }
 
-// NewLayout returns a new layout instance
-func NewLayout(uiEnabled bool) *Layout {
+// NewLayout returns a new layout instance, the given output format (text or json) being used when the UI is not enabled
+func NewLayout(uiEnabled bool, output string) *Layout {
 	layout := &Layout{
 		uiEnabled: uiEnabled,
+		output:    output,
 	}
 
 	if uiEnabled {
@@ -40,6 +60,18 @@ func NewLayout(uiEnabled bool) *Layout {
 
 // Init initializes the gui layout
 func (l *Layout) Init() {
+	if !l.uiEnabled && l.output == OutputJSON {
+		output := NewJSONOutput(os.Stdout)
+
+		l.statusView = NewJSONView("status", output)
+		l.fullscreenView = NewJSONView("fullscreen", output)
+		l.logsView = NewJSONView("logs", output)
+		l.forwardsView = NewJSONView("forwards", output)
+		l.proxyView = NewJSONView("proxy", output)
+
+		return
+	}
+
 	if !l.uiEnabled {
 		l.statusView = NewEmptyView("status")
 		l.fullscreenView = NewEmptyView("fullscreen")
@@ -66,13 +98,21 @@ func (l *Layout) Init() {
 	l.fullscreenView.GetView().Frame = false
 	l.gui.SetViewOnBottom("fullscreen")
 
-	logsView, err := l.setView("logs", " Logs ", 0, 3, maxX-1, (maxY/2)+9)
+	controlsWidth := min(controlsMaxWidth, maxX/3)
+
+	logsView, err := l.setView("logs", " Logs ", 0, 3, maxX-controlsWidth-1, (maxY/2)+9)
 	if err != nil {
 		panic(err)
 	}
 	l.logsView = logsView
 	l.logsView.GetView().Title = fmt.Sprintf("%s (Current)", l.logsView.GetTitle())
 
+	controlsView, err := l.setControlsView(controlsViewName, " Controls (Enter: actions) ", maxX-controlsWidth, 3, maxX-1, (maxY/2)+9)
+	if err != nil {
+		panic(err)
+	}
+	l.controlsView = controlsView
+
 	forwardsView, err := l.setView("forwards", " Forwards ", 0, (maxY/2)+10, (maxX/2)-1, maxY-1)
 	if err != nil {
 		panic(err)
@@ -86,7 +126,8 @@ func (l *Layout) Init() {
 	l.proxyView = proxyView
 
 	l.viewsOrder = map[string]*view{
-		logsView.GetName():     forwardsView,
+		logsView.GetName():     controlsView,
+		controlsView.GetName(): forwardsView,
 		forwardsView.GetName(): proxyView,
 		proxyView.GetName():    logsView,
 	}
@@ -97,6 +138,7 @@ func (l *Layout) Init() {
 	go func() {
 		for {
 			l.gui.Update(func(g *gocui.Gui) error {
+				l.renderControls()
 				return nil
 			})
 
@@ -141,6 +183,18 @@ func (l *Layout) setView(name, title string, xx, xy, yx, yy int) (*view, error)
 	view.Wrap = true
 	view.SelFgColor = gocui.ColorGreen
 
+	return NewBufferedView(name, title, view), nil
+}
+
+func (l *Layout) setControlsView(name, title string, xx, xy, yx, yy int) (*view, error) {
+	view, err := l.gui.SetView(name, xx, xy, yx, yy)
+	if err != nil && err != gocui.ErrUnknownView {
+		return nil, err
+	}
+
+	view.Title = title
+	view.SelFgColor = gocui.ColorGreen
+
 	return NewView(name, title, view), nil
 }
 
@@ -157,43 +211,41 @@ func (l *Layout) setStatusView(name string, xx, xy, yx, yy int) (*view, error) {
 }
 
 func (l *Layout) setKeyBindings() {
-	// Scroll up
-	if err := l.gui.SetKeybinding("", gocui.KeyArrowUp, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
-		view := l.highlighted.GetView()
-
-		if l.gui.CurrentView() == l.fullscreenView.GetView() {
-			view = l.fullscreenView.GetView()
+	// Scroll up (or select the previous item of the controls view)
+	l.setKeybinding(gocui.KeyArrowUp, func(g *gocui.Gui, v *gocui.View) error {
+		if l.getActiveView() == l.controlsView {
+			l.moveSelection(-1)
+			return nil
 		}
 
+		view := l.getActiveView().GetView()
+
 		ox, oy := view.Origin()
 		view.Autoscroll = false
 		view.SetOrigin(ox, oy-6)
 
 		return nil
-	}); err != nil {
-		panic(err)
-	}
+	})
 
-	// Scroll down
-	if err := l.gui.SetKeybinding("", gocui.KeyArrowDown, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
-		view := l.highlighted.GetView()
-
-		if l.gui.CurrentView() == l.fullscreenView.GetView() {
-			view = l.fullscreenView.GetView()
+	// Scroll down (or select the next item of the controls view)
+	l.setKeybinding(gocui.KeyArrowDown, func(g *gocui.Gui, v *gocui.View) error {
+		if l.getActiveView() == l.controlsView {
+			l.moveSelection(1)
+			return nil
 		}
 
+		view := l.getActiveView().GetView()
+
 		ox, oy := view.Origin()
 
 		view.Autoscroll = false
 		view.SetOrigin(ox, oy+6)
 
 		return nil
-	}); err != nil {
-		panic(err)
-	}
+	})
 
 	// Focus pane (highlight) - arrow left
-	if err := l.gui.SetKeybinding("", gocui.KeyArrowLeft, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+	l.setKeybinding(gocui.KeyArrowLeft, func(g *gocui.Gui, v *gocui.View) error {
 		var reversedViewsOrder = map[string]*view{}
 
 		for name, view := range l.viewsOrder {
@@ -215,12 +267,10 @@ func (l *Layout) setKeyBindings() {
 		l.highlighted.GetView().Title = fmt.Sprintf(" %s (Current)", l.highlighted.GetTitle())
 
 		return nil
-	}); err != nil {
-		panic(err)
-	}
+	})
 
 	// Focus pane (highlight) - arrow right
-	if err := l.gui.SetKeybinding("", gocui.KeyArrowRight, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+	l.setKeybinding(gocui.KeyArrowRight, func(g *gocui.Gui, v *gocui.View) error {
 		for _, view := range l.viewsOrder {
 			view.GetView().Highlight = false
 			view.GetView().Title = view.GetTitle()
@@ -233,33 +283,26 @@ func (l *Layout) setKeyBindings() {
 		l.highlighted.GetView().Title = fmt.Sprintf(" %s (Current)", l.highlighted.GetTitle())
 
 		return nil
-	}); err != nil {
-		panic(err)
-	}
+	})
 
 	// Enable autoscroll on highlighted view
-	if err := l.gui.SetKeybinding("", 'a', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
-		view := l.highlighted.GetView()
-		if l.gui.CurrentView() == l.fullscreenView.GetView() {
-			view = l.fullscreenView.GetView()
-		}
-
+	l.setKeybinding('a', func(g *gocui.Gui, v *gocui.View) error {
+		view := l.getActiveView().GetView()
 		view.Autoscroll = !view.Autoscroll
 
 		return nil
-	}); err != nil {
-		panic(err)
-	}
+	})
 
-	// Enable autoscroll on highlighted view
-	if err := l.gui.SetKeybinding("", 'f', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
-		l.fullscreenView.GetView().Clear()
+	// Toggle fullscreen on highlighted view
+	l.setKeybinding('f', func(g *gocui.Gui, v *gocui.View) error {
+		l.fullscreenView.Clear()
 
 		if l.gui.CurrentView() == l.fullscreenView.GetView() {
 			l.gui.SetViewOnBottom("fullscreen")
 			l.gui.SetCurrentView(l.highlighted.GetName())
 		} else {
 			l.fullscreenView.GetView().Title = l.highlighted.GetTitle()
+			l.fullscreenView.SetFilter(Filter{Search: l.highlighted.GetFilter().Search})
 
 			l.gui.SetViewOnTop("fullscreen")
 			l.gui.SetCurrentView("fullscreen")
@@ -269,8 +312,43 @@ func (l *Layout) setKeyBindings() {
 			}
 		}
 
+		return nil
+	})
+
+	l.setSearchKeyBindings()
+	l.setControlsKeyBindings()
+}
+
+// setKeybinding binds the given key on all views, the key being typed instead when the prompt is displayed
+// and ignored when a dialog is displayed
+func (l *Layout) setKeybinding(key interface{}, handler func(g *gocui.Gui, v *gocui.View) error) {
+	if err := l.gui.SetKeybinding("", key, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if !isDialog(v) {
+			return handler(g, v)
+		}
+
+		if v.Name() != promptViewName {
+			return nil
+		}
+
+		switch value := key.(type) {
+		case gocui.Key:
+			gocui.DefaultEditor.Edit(v, value, 0, gocui.ModNone)
+		case rune:
+			gocui.DefaultEditor.Edit(v, 0, value, gocui.ModNone)
+		}
+
 		return nil
 	}); err != nil {
 		panic(err)
 	}
 }
+
+// getActiveView returns the fullscreen view when displayed, else the highlighted one
+func (l *Layout) getActiveView() *view {
+	if l.gui.CurrentView() == l.fullscreenView.GetView() {
+		return l.fullscreenView
+	}
+
+	return l.highlighted
+}
@@ -12,7 +12,7 @@ import (
 
 func TestNewLayout(t *testing.T) {
 	// When
-	layout := NewLayout(true)
+	layout := NewLayout(true, OutputText)
 	layout.gui.Close()
 
 	// Then
@@ -22,7 +22,7 @@ func TestNewLayout(t *testing.T) {
 
 func TestInit(t *testing.T) {
 	// Given
-	layout := NewLayout(true)
+	layout := NewLayout(true, OutputText)
 	layout.gui.Close()
 
 	// When
@@ -38,7 +38,7 @@ func TestInit(t *testing.T) {
 
 func TestTestInitWhenUINotEnabled(t *testing.T) {
 	// When
-	layout := NewLayout(false)
+	layout := NewLayout(false, OutputText)
 	layout.Init()
 
 	// Then
@@ -59,9 +59,22 @@ func TestTestInitWhenUINotEnabled(t *testing.T) {
 	assert.Nil(t, layout.proxyView.GetView())
 }
 
+func TestInitWhenJSONOutput(t *testing.T) {
+	// When
+	layout := NewLayout(false, OutputJSON)
+	layout.Init()
+
+	// Then
+	assert.Nil(t, layout.gui)
+
+	assert.True(t, layout.logsView.IsStructured())
+	assert.True(t, layout.forwardsView.IsStructured())
+	assert.True(t, layout.proxyView.IsStructured())
+}
+
 func TestGetGui(t *testing.T) {
 	// Given
-	layout := NewLayout(true)
+	layout := NewLayout(true, OutputText)
 	layout.gui.Close()
 
 	layout.Init()
@@ -75,7 +88,7 @@ func TestGetGui(t *testing.T) {
 
 func TestGetLogsView(t *testing.T) {
 	// Given
-	layout := NewLayout(true)
+	layout := NewLayout(true, OutputText)
 	layout.gui.Close()
 
 	layout.Init()
@@ -92,7 +105,7 @@ func TestGetLogsView(t *testing.T) {
 
 func TestGetForwardsView(t *testing.T) {
 	// Given
-	layout := NewLayout(true)
+	layout := NewLayout(true, OutputText)
 	layout.gui.Close()
 
 	layout.Init()
@@ -109,7 +122,7 @@ func TestGetForwardsView(t *testing.T) {
 
 func TestGetProxyView(t *testing.T) {
 	// Given
-	layout := NewLayout(true)
+	layout := NewLayout(true, OutputText)
 	layout.gui.Close()
 
 	layout.Init()
@@ -126,7 +139,7 @@ func TestGetProxyView(t *testing.T) {
 
 func TestGetStatusView(t *testing.T) {
 	// Given
-	layout := NewLayout(true)
+	layout := NewLayout(true, OutputText)
 	layout.gui.Close()
 
 	layout.Init()
@@ -0,0 +1,235 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jroimartin/gocui"
+)
+
+const (
+	promptViewName = "prompt"
+)
+
+// SetApplications sets the applications of the project, each one having its own tab in the logs view
+func (l *Layout) SetApplications(applications []string) {
+	l.applications = applications
+	l.tab = 0
+
+	if l.uiEnabled {
+		l.updateLogsTitle()
+	}
+}
+
+func (l *Layout) setSearchKeyBindings() {
+	// Search in the active view
+	l.setKeybinding('/', func(g *gocui.Gui, v *gocui.View) error {
+		view := l.getActiveView()
+
+		return l.prompt(" Search (Enter: validate, Esc: cancel) ", view.GetFilter().Search, func(value string) {
+			filter := view.GetFilter()
+			filter.Search = value
+			view.SetFilter(filter)
+
+			view.ScrollToMatch(false)
+		})
+	})
+
+	// Scroll to the next or previous search match
+	l.setKeybinding('n', func(g *gocui.Gui, v *gocui.View) error {
+		l.getActiveView().ScrollToMatch(true)
+		return nil
+	})
+
+	l.setKeybinding('N', func(g *gocui.Gui, v *gocui.View) error {
+		l.getActiveView().ScrollToMatch(false)
+		return nil
+	})
+
+	// Select the applications displayed in the logs view
+	l.setKeybinding('s', func(g *gocui.Gui, v *gocui.View) error {
+		applications := strings.Join(l.logsView.GetFilter().Applications, ", ")
+
+		return l.prompt(" Applications to display, separated by commas (empty: all) ", applications, func(value string) {
+			l.selectApplications(splitNames(value))
+		})
+	})
+
+	// Display the next application tab in the logs view
+	l.setKeybinding(gocui.KeyTab, func(g *gocui.Gui, v *gocui.View) error {
+		l.nextTab()
+		return nil
+	})
+
+	// Only display the errors output in the logs view
+	l.setKeybinding('e', func(g *gocui.Gui, v *gocui.View) error {
+		l.toggleStderrOnly()
+		return nil
+	})
+
+	// Close the prompt (or a dialog) or clear the search of the active view
+	if err := l.gui.SetKeybinding("", gocui.KeyEsc, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if v != nil && v.Name() == promptViewName {
+			return l.closePrompt(false)
+		}
+
+		if isDialog(v) {
+			return l.closeDialog(v.Name())
+		}
+
+		view := l.getActiveView()
+
+		filter := view.GetFilter()
+		filter.Search = ""
+		view.SetFilter(filter)
+
+		return nil
+	}); err != nil {
+		panic(err)
+	}
+
+	if err := l.gui.SetKeybinding(promptViewName, gocui.KeyEnter, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return l.closePrompt(true)
+	}); err != nil {
+		panic(err)
+	}
+}
+
+// selectApplications only displays the lines of the given applications in the logs view, all of them when empty
+func (l *Layout) selectApplications(names []string) {
+	filter := l.logsView.GetFilter()
+	filter.Applications = names
+	l.logsView.SetFilter(filter)
+
+	l.tab = 0
+	if len(filter.Applications) == 1 {
+		l.tab = indexOf(l.applications, filter.Applications[0]) + 1
+	}
+
+	l.updateLogsTitle()
+}
+
+// nextTab displays the lines of the next application in the logs view, the first tab displaying all of them
+func (l *Layout) nextTab() {
+	l.tab = (l.tab + 1) % (len(l.applications) + 1)
+
+	filter := l.logsView.GetFilter()
+	filter.Applications = nil
+	if l.tab > 0 {
+		filter.Applications = []string{l.applications[l.tab-1]}
+	}
+
+	l.logsView.SetFilter(filter)
+	l.updateLogsTitle()
+}
+
+// toggleStderrOnly only displays the errors output in the logs view, or all the output again
+func (l *Layout) toggleStderrOnly() {
+	filter := l.logsView.GetFilter()
+	filter.StderrOnly = !filter.StderrOnly
+
+	l.logsView.SetFilter(filter)
+	l.updateLogsTitle()
+}
+
+// prompt displays an input on top of the status bar, the given function being called with the validated value
+func (l *Layout) prompt(title, value string, callback func(value string)) error {
+	maxX, _ := l.gui.Size()
+
+	v, err := l.gui.SetView(promptViewName, 0, 0, maxX-1, 2)
+	if err != nil && err != gocui.ErrUnknownView {
+		return err
+	}
+
+	v.Title = title
+	v.Editable = true
+	v.Clear()
+	v.Write([]byte(value))
+	v.SetCursor(len(value), 0)
+
+	l.promptCallback = callback
+	l.promptPreviousView = l.highlighted.GetName()
+	if current := l.gui.CurrentView(); current != nil {
+		l.promptPreviousView = current.Name()
+	}
+
+	l.gui.Cursor = true
+	l.gui.SetViewOnTop(promptViewName)
+
+	_, err = l.gui.SetCurrentView(promptViewName)
+	return err
+}
+
+// closePrompt removes the prompt and gives its value to the prompt function when validated
+func (l *Layout) closePrompt(validate bool) error {
+	v, err := l.gui.View(promptViewName)
+	if err != nil {
+		return nil
+	}
+
+	value := strings.TrimSpace(v.Buffer())
+
+	l.gui.Cursor = false
+	l.gui.DeleteView(promptViewName)
+
+	if _, err := l.gui.SetCurrentView(l.promptPreviousView); err != nil {
+		return err
+	}
+
+	if validate && l.promptCallback != nil {
+		l.promptCallback(value)
+	}
+
+	return nil
+}
+
+// updateLogsTitle displays the application tabs and the errors filter in the logs view title
+func (l *Layout) updateLogsTitle() {
+	tabs := make([]string, 0, len(l.applications)+1)
+
+	for index, name := range append([]string{"All"}, l.applications...) {
+		if index == l.tab {
+			name = fmt.Sprintf("[%s]", name)
+		}
+
+		tabs = append(tabs, name)
+	}
+
+	l.logsView.title = fmt.Sprintf(" Logs | %s ", strings.Join(tabs, " "))
+	if l.logsView.GetFilter().StderrOnly {
+		l.logsView.title += "| stderr only "
+	}
+
+	if l.logsView.GetView() == nil {
+		return
+	}
+
+	if l.highlighted == l.logsView {
+		l.logsView.GetView().Title = fmt.Sprintf(" %s (Current)", l.logsView.GetTitle())
+	} else {
+		l.logsView.GetView().Title = l.logsView.GetTitle()
+	}
+}
+
+// splitNames returns the names of a comma separated list
+func splitNames(value string) []string {
+	names := make([]string, 0)
+
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+func indexOf(values []string, value string) int {
+	for index, current := range values {
+		if current == value {
+			return index
+		}
+	}
+
+	return -1
+}
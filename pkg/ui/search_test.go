@@ -0,0 +1,218 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/jroimartin/gocui"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterMatches(t *testing.T) {
+	// Given
+	testCases := []struct {
+		name     string
+		filter   Filter
+		line     *line
+		expected bool
+	}{
+		{name: "no filter", filter: Filter{}, line: &line{name: "graphql", stream: "stdout"}, expected: true},
+		{name: "selected application", filter: Filter{Applications: []string{"user-api", "graphql"}}, line: &line{name: "graphql", stream: "stdout"}, expected: true},
+		{name: "other application", filter: Filter{Applications: []string{"user-api"}}, line: &line{name: "graphql", stream: "stdout"}, expected: false},
+		{name: "errors output", filter: Filter{StderrOnly: true}, line: &line{name: "graphql", stream: "stderr"}, expected: true},
+		{name: "standard output when errors only", filter: Filter{StderrOnly: true}, line: &line{name: "graphql", stream: "stdout"}, expected: false},
+		{name: "monday message when errors only", filter: Filter{StderrOnly: true}, line: &line{name: "graphql"}, expected: false},
+		{name: "search does not hide lines", filter: Filter{Search: "unknown"}, line: &line{name: "graphql", stream: "stdout"}, expected: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// When - Then
+			assert.Equal(t, testCase.expected, testCase.filter.matches(testCase.line))
+		})
+	}
+}
+
+func TestBufferFormat(t *testing.T) {
+	// Given
+	testCases := []struct {
+		name     string
+		search   string
+		text     string
+		expected string
+	}{
+		{name: "no search", search: "", text: "\x1b[31mgraphql\x1b[0m refused\n", expected: "\x1b[31mgraphql\x1b[0m refused\n"},
+		{name: "no match", search: "timeout", text: "\x1b[31mgraphql\x1b[0m refused\n", expected: "\x1b[31mgraphql\x1b[0m refused\n"},
+		{name: "case insensitive match", search: "REFUSED", text: "Connection refused\n", expected: "Connection \x1b[30;43mrefused\x1b[0m\n"},
+		{name: "multiple matches", search: "on", text: "Connection on :8080\n", expected: "C\x1b[30;43mon\x1b[0mnecti\x1b[30;43mon\x1b[0m \x1b[30;43mon\x1b[0m :8080\n"},
+		{name: "colors removed", search: "graphql", text: "\x1b[31mgraphql\x1b[0m refused\n", expected: "\x1b[30;43mgraphql\x1b[0m refused\n"},
+		{name: "match across colors", search: "l r", text: "\x1b[31mgraphql\x1b[0m refused\n", expected: "graphq\x1b[30;43ml r\x1b[0mefused\n"},
+		{name: "characters changing size once lowered", search: "K", text: "K-K\n", expected: "\x1b[30;43mK\x1b[0m-K\n"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			b := newBuffer(100)
+			b.filter = Filter{Search: testCase.search}
+
+			// When - Then
+			assert.Equal(t, testCase.expected, b.format(testCase.text))
+		})
+	}
+}
+
+func TestCountRows(t *testing.T) {
+	// Given
+	testCases := []struct {
+		name     string
+		text     string
+		width    int
+		expected int
+	}{
+		{name: "short line", text: "listening\n", width: 10, expected: 1},
+		{name: "line of the view width", text: "0123456789\n", width: 10, expected: 1},
+		{name: "wrapped line", text: "0123456789012345678901\n", width: 10, expected: 3},
+		{name: "colors ignored", text: "\x1b[31mgraphql\x1b[0m 12\n", width: 10, expected: 1},
+		{name: "multibyte characters", text: "🏁🏁🏁🏁🏁🏁🏁🏁🏁🏁\n", width: 10, expected: 1},
+		{name: "unknown width", text: "0123456789012345678901\n", width: 0, expected: 1},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// When - Then
+			assert.Equal(t, testCase.expected, countRows(testCase.text, testCase.width))
+		})
+	}
+}
+
+func TestViewScrollToMatch(t *testing.T) {
+	// Given
+	testCases := []struct {
+		name           string
+		search         string
+		origin         int
+		next           bool
+		expectedFound  bool
+		expectedOrigin int
+	}{
+		{name: "next match", search: "refused", origin: 0, next: true, expectedFound: true, expectedOrigin: 1},
+		{name: "next match after the current one", search: "refused", origin: 1, next: true, expectedFound: true, expectedOrigin: 3},
+		{name: "first match after the last one", search: "refused", origin: 3, next: true, expectedFound: true, expectedOrigin: 1},
+		{name: "previous match", search: "refused", origin: 4, next: false, expectedFound: true, expectedOrigin: 3},
+		{name: "previous match before the current one", search: "refused", origin: 3, next: false, expectedFound: true, expectedOrigin: 1},
+		{name: "last match before the first one", search: "refused", origin: 1, next: false, expectedFound: true, expectedOrigin: 3},
+		{name: "no match", search: "timeout", origin: 2, next: true, expectedFound: false, expectedOrigin: 2},
+		{name: "no search", search: "", origin: 2, next: true, expectedFound: false, expectedOrigin: 2},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			v := NewBufferedView("logs", "Logs", &gocui.View{Autoscroll: true})
+			v.buffer.add("graphql", "stdout", "starting\n")
+			v.buffer.add("graphql", "stderr", "connection refused\n")
+			v.buffer.add("graphql", "stdout", "retrying\n")
+			v.buffer.add("user-api", "stderr", "Connection REFUSED\n")
+			v.buffer.add("user-api", "stdout", "listening\n")
+
+			v.buffer.filter = Filter{Search: testCase.search}
+			v.GetView().SetOrigin(0, testCase.origin)
+
+			// When
+			found := v.ScrollToMatch(testCase.next)
+
+			// Then
+			assert.Equal(t, testCase.expectedFound, found)
+
+			_, origin := v.GetView().Origin()
+			assert.Equal(t, testCase.expectedOrigin, origin)
+			assert.Equal(t, !testCase.expectedFound, v.GetView().Autoscroll)
+		})
+	}
+}
+
+func TestLayoutNextTab(t *testing.T) {
+	// Given
+	l := getSearchLayout()
+
+	testCases := []struct {
+		expectedTab          int
+		expectedApplications []string
+		expectedTitle        string
+	}{
+		{expectedTab: 1, expectedApplications: []string{"graphql"}, expectedTitle: " Logs | All [graphql] user-api "},
+		{expectedTab: 2, expectedApplications: []string{"user-api"}, expectedTitle: " Logs | All graphql [user-api] "},
+		{expectedTab: 0, expectedApplications: nil, expectedTitle: " Logs | [All] graphql user-api "},
+	}
+
+	for _, testCase := range testCases {
+		// When
+		l.nextTab()
+
+		// Then
+		assert.Equal(t, testCase.expectedTab, l.tab)
+		assert.Equal(t, testCase.expectedApplications, l.logsView.GetFilter().Applications)
+		assert.Equal(t, testCase.expectedTitle, l.logsView.GetTitle())
+	}
+}
+
+func TestLayoutSelectApplications(t *testing.T) {
+	// Given
+	testCases := []struct {
+		name          string
+		applications  []string
+		expectedTab   int
+		expectedTitle string
+	}{
+		{name: "all applications", applications: []string{}, expectedTab: 0, expectedTitle: " Logs | [All] graphql user-api "},
+		{name: "single application", applications: []string{"user-api"}, expectedTab: 2, expectedTitle: " Logs | All graphql [user-api] "},
+		{name: "several applications", applications: []string{"user-api", "graphql"}, expectedTab: 0, expectedTitle: " Logs | [All] graphql user-api "},
+		{name: "forward", applications: []string{"order-api"}, expectedTab: 0, expectedTitle: " Logs | [All] graphql user-api "},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			l := getSearchLayout()
+			l.tab = 1
+
+			// When
+			l.selectApplications(testCase.applications)
+
+			// Then
+			assert.Equal(t, testCase.applications, l.logsView.GetFilter().Applications)
+			assert.Equal(t, testCase.expectedTab, l.tab)
+			assert.Equal(t, testCase.expectedTitle, l.logsView.GetTitle())
+		})
+	}
+}
+
+func TestLayoutToggleStderrOnly(t *testing.T) {
+	// Given
+	l := getSearchLayout()
+
+	// When
+	l.toggleStderrOnly()
+
+	// Then
+	assert.True(t, l.logsView.GetFilter().StderrOnly)
+	assert.Equal(t, " Logs | [All] graphql user-api | stderr only ", l.logsView.GetTitle())
+
+	// When
+	l.toggleStderrOnly()
+
+	// Then
+	assert.False(t, l.logsView.GetFilter().StderrOnly)
+	assert.Equal(t, " Logs | [All] graphql user-api ", l.logsView.GetTitle())
+}
+
+func TestIndexOf(t *testing.T) {
+	// When - Then
+	assert.Equal(t, 1, indexOf([]string{"graphql", "user-api"}, "user-api"))
+	assert.Equal(t, -1, indexOf([]string{"graphql", "user-api"}, "order-api"))
+}
+
+func getSearchLayout() *Layout {
+	return &Layout{
+		logsView:     NewBufferedView("logs", "Logs", nil),
+		applications: []string{"graphql", "user-api"},
+	}
+}
@@ -3,6 +3,7 @@ package ui
 import (
 	"fmt"
 
+	"github.com/eko/monday/internal/redact"
 	"github.com/jroimartin/gocui"
 )
 
@@ -14,9 +15,14 @@ type View interface {
 
 // view is the view structure
 type view struct {
-	name  string
-	title string
-	view  *gocui.View
+	name      string
+	title     string
+	view      *gocui.View
+	component string
+	entryName string
+	output    *JSONOutput
+	buffer    *buffer
+	broadcast *Broadcast
 }
 
 // NewView returns a new instance of a view
@@ -28,6 +34,16 @@ func NewView(name, title string, v *gocui.View) *view {
 	}
 }
 
+// NewBufferedView returns a new instance of a view keeping its last lines so they can be filtered and searched
+func NewBufferedView(name, title string, v *gocui.View) *view {
+	return &view{
+		name:   name,
+		title:  title,
+		view:   v,
+		buffer: newBuffer(BufferMaxLines),
+	}
+}
+
 // NewEmptyView returns a new instance of an empty view
 func NewEmptyView(name string) *view {
 	return &view{
@@ -50,8 +66,29 @@ func (v *view) GetView() *gocui.View {
 	return v.view
 }
 
-// Write allows to write a string to the view
+// Write allows to write a string to the view, secret values being masked
 func (v *view) Write(str string) {
+	if v.broadcast != nil {
+		v.broadcast.publish(Record{View: v.name, Entry: Entry{Component: v.component, Name: v.entryName, Message: redact.String(str)}})
+	}
+
+	if v.output != nil {
+		v.writeMessage(str)
+		return
+	}
+
+	str = redact.String(str)
+
+	if v.buffer != nil {
+		v.writeBuffer(v.nameOf(str), "", str)
+		return
+	}
+
+	v.write(str)
+}
+
+// write displays the given text in the view, or prints it when the UI is not enabled
+func (v *view) write(str string) {
 	if v.view == nil {
 		fmt.Print(str)
 		return
@@ -62,10 +99,118 @@ func (v *view) Write(str string) {
 
 // Writef allows to write a string to the view with some given arguments
 func (v *view) Writef(str string, args ...interface{}) {
+	v.Write(fmt.Sprintf(str, args...))
+}
+
+// Clear removes all the lines of the view
+func (v *view) Clear() {
+	if v.buffer != nil {
+		v.buffer.mutex.Lock()
+		defer v.buffer.mutex.Unlock()
+
+		v.buffer.lines = v.buffer.lines[:0]
+	}
+
+	if v.view != nil {
+		v.view.Clear()
+	}
+}
+
+// GetFilter returns the filter applied on the lines of the view
+func (v *view) GetFilter() Filter {
+	if v.buffer == nil {
+		return Filter{}
+	}
+
+	v.buffer.mutex.Lock()
+	defer v.buffer.mutex.Unlock()
+
+	return v.buffer.filter
+}
+
+// SetFilter changes the filter applied on the lines of the view and displays them again
+func (v *view) SetFilter(filter Filter) {
+	if v.buffer == nil {
+		return
+	}
+
+	v.buffer.mutex.Lock()
+	defer v.buffer.mutex.Unlock()
+
+	v.buffer.filter = filter
+	v.render()
+}
+
+// ScrollToMatch scrolls the view to the next (or previous) line matching the search and indicates if one was found
+func (v *view) ScrollToMatch(next bool) bool {
+	if v.buffer == nil {
+		return false
+	}
+
+	v.buffer.mutex.Lock()
+	defer v.buffer.mutex.Unlock()
+
+	width, _ := v.view.Size()
+	_, rows := v.buffer.render(width)
+
+	if len(rows) == 0 {
+		return false
+	}
+
+	ox, oy := v.view.Origin()
+	row := nextMatch(rows, oy, next)
+
+	v.view.Autoscroll = false
+	v.view.SetOrigin(ox, row)
+
+	return true
+}
+
+// nextMatch returns the row of the next (or previous) match from the given origin, starting over from the other end
+// of the view once the last match is reached
+func nextMatch(rows []int, origin int, next bool) int {
+	if next {
+		for _, row := range rows {
+			if row > origin {
+				return row
+			}
+		}
+
+		return rows[0]
+	}
+
+	for i := len(rows) - 1; i >= 0; i-- {
+		if rows[i] < origin {
+			return rows[i]
+		}
+	}
+
+	return rows[len(rows)-1]
+}
+
+// writeBuffer keeps the given text in the buffer and displays it if it matches the filter
+func (v *view) writeBuffer(name, stream, text string) {
+	v.buffer.mutex.Lock()
+	defer v.buffer.mutex.Unlock()
+
+	display, rerender := v.buffer.add(name, stream, text)
+	if rerender {
+		v.render()
+		return
+	}
+
+	v.view.Write([]byte(display))
+}
+
+// render displays again all the lines of the buffer matching the filter, buffer being locked
+func (v *view) render() {
 	if v.view == nil {
-		fmt.Print(fmt.Sprintf(str, args...))
 		return
 	}
 
-	v.view.Write([]byte(fmt.Sprintf(str, args...)))
+	width, _ := v.view.Size()
+	text, _ := v.buffer.render(width)
+
+	v.view.Clear()
+	v.view.Write([]byte(text))
 }
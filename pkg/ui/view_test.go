@@ -1,8 +1,11 @@
 package ui
 
 import (
+	"io"
+	"os"
 	"testing"
 
+	"github.com/eko/monday/internal/redact"
 	"github.com/jroimartin/gocui"
 
 	"github.com/stretchr/testify/assert"
@@ -23,3 +26,26 @@ func TestNewView(t *testing.T) {
 	assert.Equal(t, "Test View", v.GetTitle())
 	assert.Equal(t, gocuiView, v.GetView())
 }
+
+func TestViewWriteWhenSecret(t *testing.T) {
+	// Given
+	defer redact.Reset()
+	redact.Add("p4ssw0rd")
+
+	stdout := os.Stdout
+	defer func() { os.Stdout = stdout }()
+
+	reader, writer, _ := os.Pipe()
+	os.Stdout = writer
+
+	v := NewEmptyView("test-view")
+
+	// When
+	v.Writef("password is %s\n", "p4ssw0rd")
+
+	// Then
+	writer.Close()
+	output, _ := io.ReadAll(reader)
+
+	assert.Equal(t, "password is ******\n", string(output))
+}
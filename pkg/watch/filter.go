@@ -0,0 +1,286 @@
+package watch
+
+import (
+	"bufio"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	// defaultExcludes are the patterns of the files and directories not watched unless an include pattern names them
+	defaultExcludes = []string{".*", "node_modules", "vendor"}
+)
+
+// filter selects the files of a watched directory whose changes rebuild an application.
+//
+// Patterns use the path.Match syntax, '**' matching any number of directories. A pattern without any '/'
+// matches the name of a file or of one of its parent directories, while other ones match the path relative
+// to the watched directory (excluded absolute paths being made relative to it). A pattern ending with a '/'
+// only matches directories.
+//
+// Hidden files, node_modules and vendor directories are excluded by default, unless an include pattern names them
+// explicitly, without starting with a wildcard (like '.env' or '.config/**').
+type filter struct {
+	root      string
+	include   []string
+	exclude   []string
+	gitignore []gitignoreRule
+}
+
+// gitignoreRule is a pattern of a .gitignore file, a negated one including again the files matched before
+type gitignoreRule struct {
+	pattern string
+	negate  bool
+}
+
+// newFilter returns the filter of the given watched directory, reading its .gitignore file when enabled
+func newFilter(root string, include, exclude []string, gitignore bool) *filter {
+	if absolute, err := filepath.Abs(root); err == nil {
+		root = absolute
+	}
+
+	f := &filter{
+		root:    root,
+		include: include,
+		exclude: make([]string, 0, len(exclude)),
+	}
+
+	// Existing absolute paths are made relative to the watched directory, or ignored when outside of it
+	for _, pattern := range exclude {
+		if _, err := os.Stat(pattern); err == nil && filepath.IsAbs(pattern) {
+			relative, err := filepath.Rel(root, pattern)
+			if err != nil || relative == "." || strings.HasPrefix(relative, "..") {
+				continue
+			}
+
+			pattern = "/" + filepath.ToSlash(relative)
+		}
+
+		f.exclude = append(f.exclude, pattern)
+	}
+
+	if gitignore {
+		f.gitignore = readGitignore(filepath.Join(root, ".gitignore"))
+	}
+
+	return f
+}
+
+// isExcluded indicates if the given file or directory is not watched, because of an exclude pattern or
+// of the .gitignore file
+func (f *filter) isExcluded(name string, isDir bool) bool {
+	segments := f.segments(name)
+	if segments == nil {
+		return false
+	}
+
+	if f.isExcludedByDefault(segments) {
+		return true
+	}
+
+	for _, pattern := range f.exclude {
+		if matchPattern(pattern, segments, isDir) {
+			return true
+		}
+	}
+
+	ignored := false
+	for _, rule := range f.gitignore {
+		if matchPattern(rule.pattern, segments, isDir) {
+			ignored = !rule.negate
+		}
+	}
+
+	return ignored
+}
+
+// isIncluded indicates if a change of the given file rebuilds the application
+func (f *filter) isIncluded(name string) bool {
+	if f.isExcluded(name, false) {
+		return false
+	}
+
+	if len(f.include) == 0 {
+		return true
+	}
+
+	segments := f.segments(name)
+	if segments == nil {
+		return true
+	}
+
+	for _, pattern := range f.include {
+		if matchPattern(pattern, segments, false) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isExcludedByDefault indicates if a path (given as segments) or one of its parent directories matches a default
+// exclude pattern without being named by an include pattern
+func (f *filter) isExcludedByDefault(segments []string) bool {
+	for i, segment := range segments {
+		for _, pattern := range defaultExcludes {
+			if matched, _ := path.Match(pattern, segment); matched && !f.isNamed(segments[:i+1]) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// isNamed indicates if an include pattern names explicitly the last segment of the given path
+func (f *filter) isNamed(segments []string) bool {
+	for _, pattern := range f.include {
+		pattern = strings.TrimSuffix(pattern, "/")
+
+		if !strings.Contains(pattern, "/") {
+			if matched, _ := path.Match(pattern, segments[len(segments)-1]); matched && isExplicit(pattern) {
+				return true
+			}
+
+			continue
+		}
+
+		if nameSegments(strings.Split(strings.TrimPrefix(pattern, "/"), "/"), segments) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// segments returns the path of the given file relative to the watched directory, split by directory,
+// or nil for the watched directory itself
+func (f *filter) segments(name string) []string {
+	relative, err := filepath.Rel(f.root, name)
+	if err != nil || relative == "." || strings.HasPrefix(relative, "..") {
+		return nil
+	}
+
+	return strings.Split(filepath.ToSlash(relative), "/")
+}
+
+// matchPattern indicates if the given pattern matches a path (given as segments) or one of its parent directories
+func matchPattern(pattern string, segments []string, isDir bool) bool {
+	dirOnly := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+
+	anchored := strings.Contains(pattern, "/")
+	patternSegments := strings.Split(strings.TrimPrefix(pattern, "/"), "/")
+
+	last := len(segments)
+	if dirOnly && !isDir {
+		last--
+	}
+
+	for i := 0; i < last; i++ {
+		if anchored {
+			if matchSegments(patternSegments, segments[:i+1]) {
+				return true
+			}
+
+			continue
+		}
+
+		if matched, _ := path.Match(pattern, segments[i]); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchSegments indicates if the given pattern segments match all the path segments, '**' matching any number of them
+func matchSegments(pattern, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+
+	if pattern[0] == "**" {
+		for i := 0; i <= len(segments); i++ {
+			if matchSegments(pattern[1:], segments[i:]) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if len(segments) == 0 {
+		return false
+	}
+
+	matched, _ := path.Match(pattern[0], segments[0])
+
+	return matched && matchSegments(pattern[1:], segments[1:])
+}
+
+// nameSegments indicates if the given pattern segments match the path segments (or a path inside them), the last
+// path segment being matched by an explicit pattern segment
+func nameSegments(pattern, segments []string) bool {
+	if len(pattern) == 0 || len(segments) == 0 {
+		return false
+	}
+
+	if pattern[0] == "**" {
+		for i := 0; i < len(segments); i++ {
+			if nameSegments(pattern[1:], segments[i:]) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if matched, _ := path.Match(pattern[0], segments[0]); !matched {
+		return false
+	}
+
+	if len(segments) == 1 {
+		return isExplicit(pattern[0])
+	}
+
+	return nameSegments(pattern[1:], segments[1:])
+}
+
+// isExplicit indicates if the given pattern segment names a file or directory, instead of starting with a wildcard
+func isExplicit(pattern string) bool {
+	return pattern != "" && !strings.ContainsAny(pattern[:1], "*?[")
+}
+
+// readGitignore returns the rules of the given .gitignore file, if any
+func readGitignore(name string) []gitignoreRule {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	rules := make([]gitignoreRule, 0)
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		rule := gitignoreRule{pattern: line}
+		if strings.HasPrefix(line, "!") {
+			rule = gitignoreRule{pattern: line[1:], negate: true}
+		}
+
+		rule.pattern = strings.TrimPrefix(rule.pattern, "\\")
+		if rule.pattern != "" && rule.pattern != "/" {
+			rules = append(rules, rule)
+		}
+	}
+
+	return rules
+}
@@ -0,0 +1,119 @@
+package watch
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterIsIncluded(t *testing.T) {
+	// Given
+	root := t.TempDir()
+	os.WriteFile(filepath.Join(root, ".gitignore"), []byte("# Build output\n/bin/\n*.log\n!keep.log\n"), 0644)
+	os.Mkdir(filepath.Join(root, "tmp"), 0755)
+
+	filter := newFilter(
+		root,
+		[]string{"*.go", "go.mod", "assets/**/*.css"},
+		[]string{"**/testdata/", "/generated", filepath.Join(root, "tmp")},
+		true,
+	)
+
+	testCases := []struct {
+		path     string
+		expected bool
+	}{
+		{path: "main.go", expected: true},
+		{path: "pkg/server/server.go", expected: true},
+		{path: "go.mod", expected: true},
+		{path: "assets/styles/main.css", expected: true},
+		{path: "README.md", expected: false},
+		{path: "styles/main.css", expected: false},
+		{path: ".main.go.swp", expected: false},
+		{path: ".git/HEAD", expected: false},
+		{path: "vendor/github.com/lib/lib.go", expected: false},
+		{path: "pkg/server/testdata/fixture.go", expected: false},
+		{path: "generated/api.go", expected: false},
+		{path: "pkg/generated/api.go", expected: true},
+		{path: "tmp/main.go", expected: false},
+		{path: "bin/server.go", expected: false},
+		{path: "cmd/bin/main.go", expected: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.path, func(t *testing.T) {
+			// When - Then
+			assert.Equal(t, testCase.expected, filter.isIncluded(filepath.Join(root, testCase.path)))
+		})
+	}
+}
+
+func TestFilterIsIncludedWhenHiddenFiles(t *testing.T) {
+	// Given
+	root := t.TempDir()
+
+	filter := newFilter(root, []string{"*.go", ".env", ".config/**", "**/.eslintrc"}, nil, false)
+
+	testCases := []struct {
+		path     string
+		isDir    bool
+		expected bool
+	}{
+		{path: ".env", expected: true},
+		{path: "pkg/.env", expected: true},
+		{path: ".config", isDir: true, expected: true},
+		{path: ".config/app.yaml", expected: true},
+		{path: "web/.eslintrc", expected: true},
+		{path: ".git", isDir: true, expected: false},
+		{path: ".git/hooks/hook.go", expected: false},
+		{path: ".main.go.swp", expected: false},
+		{path: ".hidden.go", expected: false},
+		{path: "vendor/github.com/lib/lib.go", expected: false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.path, func(t *testing.T) {
+			// When - Then
+			name := filepath.Join(root, testCase.path)
+
+			if testCase.isDir {
+				assert.Equal(t, testCase.expected, !filter.isExcluded(name, true))
+			} else {
+				assert.Equal(t, testCase.expected, filter.isIncluded(name))
+			}
+		})
+	}
+}
+
+func TestFilterIsExcluded(t *testing.T) {
+	// Given
+	root := t.TempDir()
+	os.WriteFile(filepath.Join(root, ".gitignore"), []byte("*.log\n!keep.log\nbuild/\n"), 0644)
+
+	testCases := []struct {
+		name      string
+		path      string
+		isDir     bool
+		gitignore bool
+		expected  bool
+	}{
+		{name: "ignored file", path: "server.log", gitignore: true, expected: true},
+		{name: "included again file", path: "logs/keep.log", gitignore: true, expected: false},
+		{name: "ignored directory", path: "build", isDir: true, gitignore: true, expected: true},
+		{name: "file named as an ignored directory", path: "build", gitignore: true, expected: false},
+		{name: "file of an ignored directory", path: "build/output.txt", gitignore: true, expected: true},
+		{name: "gitignore disabled", path: "server.log", gitignore: false, expected: false},
+		{name: "watched directory", path: "", isDir: true, gitignore: true, expected: false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			filter := newFilter(root, nil, nil, testCase.gitignore)
+
+			// When - Then
+			assert.Equal(t, testCase.expected, filter.isExcluded(filepath.Join(root, testCase.path), testCase.isDir))
+		})
+	}
+}
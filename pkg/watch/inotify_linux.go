@@ -0,0 +1,211 @@
+//go:build linux
+
+package watch
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"unsafe"
+)
+
+const (
+	// inotifyMask lists the inotify events of a watched directory notified as a file change
+	inotifyMask = syscall.IN_CREATE | syscall.IN_CLOSE_WRITE | syscall.IN_DELETE | syscall.IN_MOVED_FROM | syscall.IN_MOVED_TO |
+		syscall.IN_DELETE_SELF | syscall.IN_MOVE_SELF
+)
+
+// inotifyWatcher watches directories using inotify, which notifies their changes without scanning them
+type inotifyWatcher struct {
+	fd          int
+	file        *os.File
+	filters     []*filter
+	directories map[int]watchedDirectory
+	mutex       sync.Mutex
+	events      chan string
+	errors      chan error
+}
+
+// watchedDirectory is a directory watched with an inotify watch descriptor
+type watchedDirectory struct {
+	path   string
+	filter *filter
+}
+
+// newTreeWatcher watches the root directories of the given filters and their subdirectories (excluded ones aside)
+func newTreeWatcher(filters []*filter) (treeWatcher, error) {
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC | syscall.IN_NONBLOCK)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to initialize inotify: %v", err)
+	}
+
+	// The file descriptor is non-blocking so its file uses the runtime poller: closing it interrupts a pending read
+	w := &inotifyWatcher{
+		fd:          fd,
+		file:        os.NewFile(uintptr(fd), "inotify"),
+		filters:     filters,
+		directories: make(map[int]watchedDirectory),
+		events:      make(chan string, watcherBufferSize),
+		errors:      make(chan error, watcherBufferSize),
+	}
+
+	for _, filter := range filters {
+		if _, err := w.addRecursive(filter.root, filter); err != nil {
+			w.file.Close()
+			return nil, err
+		}
+	}
+
+	go w.read()
+
+	return w, nil
+}
+
+func (w *inotifyWatcher) Events() <-chan string {
+	return w.events
+}
+
+func (w *inotifyWatcher) Errors() <-chan error {
+	return w.errors
+}
+
+// Close stops watching the directories, closing the events channel
+func (w *inotifyWatcher) Close() error {
+	return w.file.Close()
+}
+
+// addRecursive watches the given directory and its subdirectories, it returns the files found in them
+func (w *inotifyWatcher) addRecursive(root string, filter *filter) ([]string, error) {
+	files := make([]string, 0)
+
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			// Directories removed in the meantime are ignored
+			if path != root && errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+
+			return err
+		}
+
+		if !entry.IsDir() {
+			if path != root {
+				files = append(files, path)
+				return nil
+			}
+		} else if filter.isExcluded(path, true) {
+			return filepath.SkipDir
+		}
+
+		wd, err := syscall.InotifyAddWatch(w.fd, path, inotifyMask)
+		if err != nil {
+			return fmt.Errorf("Unable to watch '%s': %v", path, err)
+		}
+
+		w.mutex.Lock()
+		w.directories[wd] = watchedDirectory{path: path, filter: filter}
+		w.mutex.Unlock()
+
+		return nil
+	})
+
+	return files, err
+}
+
+// read notifies the changes read from inotify until the watcher is closed
+func (w *inotifyWatcher) read() {
+	defer close(w.events)
+
+	buffer := make([]byte, watcherBufferSize*(syscall.SizeofInotifyEvent+syscall.NAME_MAX+1))
+
+	for {
+		n, err := w.file.Read(buffer)
+		if err != nil {
+			if !errors.Is(err, os.ErrClosed) {
+				w.notifyError(fmt.Errorf("Unable to read inotify events: %v", err))
+			}
+
+			return
+		}
+
+		for offset := 0; offset+syscall.SizeofInotifyEvent <= n; {
+			event := (*syscall.InotifyEvent)(unsafe.Pointer(&buffer[offset]))
+			nameStart := offset + syscall.SizeofInotifyEvent
+			name := strings.TrimRight(string(buffer[nameStart:nameStart+int(event.Len)]), "\x00")
+
+			w.handle(int(event.Wd), event.Mask, name)
+
+			offset = nameStart + int(event.Len)
+		}
+	}
+}
+
+// handle notifies the change of a file or watches a new directory
+func (w *inotifyWatcher) handle(wd int, mask uint32, name string) {
+	// Some events have been lost, the watched directories are considered as changed
+	if mask&syscall.IN_Q_OVERFLOW != 0 {
+		for _, filter := range w.filters {
+			w.notify(filter.root)
+		}
+
+		return
+	}
+
+	w.mutex.Lock()
+	directory, ok := w.directories[wd]
+	if mask&syscall.IN_IGNORED != 0 {
+		delete(w.directories, wd)
+	}
+	w.mutex.Unlock()
+
+	if !ok || mask&syscall.IN_IGNORED != 0 {
+		return
+	}
+
+	path := directory.path
+	if name != "" {
+		path = filepath.Join(directory.path, name)
+	}
+
+	if mask&syscall.IN_ISDIR == 0 {
+		if directory.filter.isIncluded(path) {
+			w.notify(path)
+		}
+
+		return
+	}
+
+	// New directories are watched, the files they already contain being notified
+	if mask&(syscall.IN_CREATE|syscall.IN_MOVED_TO) != 0 && !directory.filter.isExcluded(path, true) {
+		files, err := w.addRecursive(path, directory.filter)
+		if err != nil {
+			w.notifyError(err)
+		}
+
+		for _, file := range files {
+			if directory.filter.isIncluded(file) {
+				w.notify(file)
+			}
+		}
+	}
+}
+
+// notify sends a file change, which is dropped when the previous ones have not been read yet
+func (w *inotifyWatcher) notify(path string) {
+	select {
+	case w.events <- path:
+	default:
+	}
+}
+
+func (w *inotifyWatcher) notifyError(err error) {
+	select {
+	case w.errors <- err:
+	default:
+	}
+}
@@ -0,0 +1,59 @@
+//go:build linux
+
+package watch
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInotifyWatcher(t *testing.T) {
+	// Given
+	root := t.TempDir()
+	library := t.TempDir()
+	os.Mkdir(filepath.Join(root, "node_modules"), 0755)
+
+	watcher, err := newTreeWatcher([]*filter{
+		newFilter(root, nil, nil, true),
+		newFilter(library, []string{"*.go"}, nil, true),
+	})
+	assert.Nil(t, err)
+	defer watcher.Close()
+
+	// When
+	os.WriteFile(filepath.Join(root, "node_modules", "index.js"), []byte("excluded"), 0644)
+	os.WriteFile(filepath.Join(library, "README.md"), []byte("not included"), 0644)
+
+	// Files of new directories are watched, including the ones written before the directory is watched
+	os.MkdirAll(filepath.Join(root, "pkg", "server"), 0755)
+	os.WriteFile(filepath.Join(root, "pkg", "server", "server.go"), []byte("package server"), 0644)
+	os.WriteFile(filepath.Join(library, "library.go"), []byte("package library"), 0644)
+
+	// Then
+	changes := make(map[string]bool)
+	timeout := time.After(2 * time.Second)
+
+	for len(changes) < 2 {
+		select {
+		case path := <-watcher.Events():
+			changes[path] = true
+		case <-timeout:
+			t.Fatalf("Some file changes have not been notified: %v", changes)
+		}
+	}
+
+	assert.Equal(t, map[string]bool{
+		filepath.Join(root, "pkg", "server", "server.go"): true,
+		filepath.Join(library, "library.go"):              true,
+	}, changes)
+
+	// Closing the watcher closes its events channel
+	assert.Nil(t, watcher.Close())
+
+	for range watcher.Events() {
+	}
+}
@@ -0,0 +1,136 @@
+//go:build !linux
+
+package watch
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	radovskyb_watcher "github.com/radovskyb/watcher"
+)
+
+const (
+	// pollingInterval is the duration between two scans of the watched directories
+	pollingInterval = 250 * time.Millisecond
+)
+
+// pollingWatcher watches directories by scanning them periodically, when inotify is not available
+type pollingWatcher struct {
+	watcher *radovskyb_watcher.Watcher
+	events  chan string
+	errors  chan error
+}
+
+// newTreeWatcher watches the root directories of the given filters and their subdirectories (excluded ones aside)
+func newTreeWatcher(filters []*filter) (treeWatcher, error) {
+	fileWatcher := radovskyb_watcher.New()
+	fileWatcher.FilterOps(radovskyb_watcher.Write, radovskyb_watcher.Create, radovskyb_watcher.Remove, radovskyb_watcher.Rename, radovskyb_watcher.Move)
+
+	// findFilter returns the filter of the watched directory containing the given file
+	findFilter := func(path string) *filter {
+		var found *filter
+		for _, filter := range filters {
+			if (path == filter.root || strings.HasPrefix(path, filter.root+string(filepath.Separator))) &&
+				(found == nil || len(filter.root) > len(found.root)) {
+				found = filter
+			}
+		}
+
+		return found
+	}
+
+	fileWatcher.AddFilterHook(func(info os.FileInfo, path string) error {
+		if filter := findFilter(path); filter != nil {
+			if info.IsDir() && filter.isExcluded(path, true) || !info.IsDir() && !filter.isIncluded(path) {
+				return radovskyb_watcher.ErrSkip
+			}
+		}
+
+		return nil
+	})
+
+	for _, filter := range filters {
+		// Excluded directories are not scanned
+		excluded := make([]string, 0)
+
+		err := filepath.WalkDir(filter.root, func(path string, entry fs.DirEntry, err error) error {
+			if err == nil && entry.IsDir() && filter.isExcluded(path, true) {
+				excluded = append(excluded, path)
+				return filepath.SkipDir
+			}
+
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if err := fileWatcher.Ignore(excluded...); err != nil {
+			return nil, err
+		}
+
+		if err := fileWatcher.AddRecursive(filter.root); err != nil {
+			return nil, err
+		}
+	}
+
+	w := &pollingWatcher{
+		watcher: fileWatcher,
+		events:  make(chan string, watcherBufferSize),
+		errors:  make(chan error, watcherBufferSize),
+	}
+
+	go func() {
+		_ = fileWatcher.Start(pollingInterval)
+	}()
+
+	// Wait for the file watcher to be started so it can be closed
+	fileWatcher.Wait()
+
+	go w.read()
+
+	return w, nil
+}
+
+func (w *pollingWatcher) Events() <-chan string {
+	return w.events
+}
+
+func (w *pollingWatcher) Errors() <-chan error {
+	return w.errors
+}
+
+// Close stops watching the directories, closing the events channel
+func (w *pollingWatcher) Close() error {
+	w.watcher.Close()
+	return nil
+}
+
+// read notifies the changes of the files scanned until the watcher is closed
+func (w *pollingWatcher) read() {
+	defer close(w.events)
+
+	for {
+		select {
+		case event := <-w.watcher.Event:
+			if event.IsDir() {
+				continue
+			}
+
+			select {
+			case w.events <- event.Path:
+			default:
+			}
+		case err := <-w.watcher.Error:
+			select {
+			case w.errors <- err:
+			default:
+			}
+		case <-w.watcher.Closed:
+			return
+		}
+	}
+}
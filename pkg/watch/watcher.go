@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
 	"time"
 
 	"github.com/eko/monday/pkg/build"
@@ -11,21 +14,38 @@ import (
 	"github.com/eko/monday/pkg/forward"
 	"github.com/eko/monday/pkg/run"
 	"github.com/eko/monday/pkg/setup"
+	"github.com/eko/monday/pkg/ui"
 	"github.com/eko/monday/pkg/write"
 	radovskyb_watcher "github.com/radovskyb/watcher"
 )
 
-var (
-	excludeDirectories = []string{".git", "node_modules", "vendor"}
+const (
+	// configWatcherName is the file watcher key used for the configuration files
+	configWatcherName = "monday:config"
+
+	// watcherBufferSize is the number of file changes or errors kept until they are handled, new ones being dropped
+	watcherBufferSize = 100
 )
 
+// treeWatcher notifies the changes of the files of some directories and of their subdirectories,
+// using inotify on Linux and scanning them periodically on other systems
+type treeWatcher interface {
+	Events() <-chan string
+	Errors() <-chan error
+	Close() error
+}
+
 type Watcher interface {
 	Watch(ctx context.Context)
+	WatchConfig(ctx context.Context, patterns []string, load func() (*config.Project, error)) error
+	Reload(ctx context.Context, project *config.Project)
+	GetProject() *config.Project
 	Stop() error
 }
 
 // Watcher monitors health of the currently forwarded ports and launched applications.
 type watcher struct {
+	view         ui.View
 	setuper      setup.Setuper
 	builder      build.Builder
 	writer       write.Writer
@@ -33,11 +53,16 @@ type watcher struct {
 	forwarder    forward.Forwarder
 	conf         *config.GlobalWatch
 	project      *config.Project
+	excludes     []string
 	fileWatchers map[string]*radovskyb_watcher.Watcher
+	treeWatchers map[string]treeWatcher
+	watchersMux  sync.Mutex
+	reloadMux    sync.Mutex
 }
 
 // NewWatcher initializes a watcher instance monitoring services using both runner and forwarder
 func NewWatcher(
+	view ui.View,
 	setuper setup.Setuper,
 	builder build.Builder,
 	writer write.Writer,
@@ -46,11 +71,8 @@ func NewWatcher(
 	conf *config.GlobalWatch,
 	project *config.Project,
 ) *watcher {
-	if conf != nil && len(conf.Exclude) > 0 {
-		excludeDirectories = append(excludeDirectories, conf.Exclude...)
-	}
-
 	return &watcher{
+		view:         view,
 		setuper:      setuper,
 		builder:      builder,
 		writer:       writer,
@@ -58,18 +80,20 @@ func NewWatcher(
 		forwarder:    forwarder,
 		conf:         conf,
 		project:      project,
+		excludes:     append([]string{}, conf.GetExclude()...),
 		fileWatchers: make(map[string]*radovskyb_watcher.Watcher, 0),
+		treeWatchers: make(map[string]treeWatcher, 0),
 	}
 }
 
 // Watch runs both local applications and forwarded ones and ensure they keep running.
-// It also relaunch them in case of file changes.
+// It also relaunch them in case of file changes, until the given context is done.
 func (w *watcher) Watch(ctx context.Context) {
 	w.setuper.SetupAll()
 	w.writer.WriteAll()
 	w.builder.BuildAll()
 
-	go w.runner.RunAll()
+	go w.runner.RunAll(ctx)
 	go w.forwarder.ForwardAll(ctx)
 
 	for _, application := range w.project.Applications {
@@ -77,59 +101,292 @@ func (w *watcher) Watch(ctx context.Context) {
 			continue
 		}
 
-		go w.watchApplication(application)
+		go w.watchApplication(ctx, application)
 	}
 }
 
-// Stop stops all currently active file watchers on local running applications
-func (w *watcher) Stop() error {
-	for _, fileWatcher := range w.fileWatchers {
-		fileWatcher.Close()
-	}
-
-	return nil
-}
-
-func (w *watcher) watchApplication(application *config.Application) error {
+// WatchConfig monitors the configuration files matching the given paths (or glob patterns) and reloads the
+// project returned by the load function each time one of them changes. Their directories are watched so
+// files created later are taken into account.
+func (w *watcher) WatchConfig(ctx context.Context, patterns []string, load func() (*config.Project, error)) error {
+	// Events are not limited per polling cycle: a directory change could otherwise hide the file one
 	fileWatcher := radovskyb_watcher.New()
-	fileWatcher.SetMaxEvents(1)
-	fileWatcher.IgnoreHiddenFiles(true)
-	fileWatcher.FilterOps(radovskyb_watcher.Write, radovskyb_watcher.Create, radovskyb_watcher.Remove)
+	fileWatcher.FilterOps(radovskyb_watcher.Write, radovskyb_watcher.Create, radovskyb_watcher.Remove, radovskyb_watcher.Rename, radovskyb_watcher.Move)
 
-	w.fileWatchers[application.Name] = fileWatcher
+	patterns = absolutePatterns(patterns)
 
-	if err := fileWatcher.AddRecursive(application.GetPath()); err != nil {
-		fmt.Printf("❌  Unable to watch directory of application '%s': %v\n", application.Name, err)
-	}
+	// Only the configuration files of the directories are listed (and compared on each scan)
+	fileWatcher.AddFilterHook(func(info os.FileInfo, fullPath string) error {
+		if !matchesAny(patterns, fullPath) {
+			return radovskyb_watcher.ErrSkip
+		}
+
+		return nil
+	})
 
-	for _, directory := range excludeDirectories {
-		if _, err := os.Stat(directory); os.IsNotExist(err) {
-			directory = fmt.Sprintf("%s/%s", application.GetPath(), directory)
+	watched := make(map[string]bool)
+	for _, pattern := range patterns {
+		dir := filepath.Dir(pattern)
+		if watched[dir] {
+			continue
 		}
+		watched[dir] = true
 
-		fileWatcher.Ignore(directory)
+		if err := fileWatcher.Add(dir); err != nil {
+			return fmt.Errorf("Unable to watch configuration directory '%s': %v", dir, err)
+		}
 	}
 
+	w.watchersMux.Lock()
+	w.fileWatchers[configWatcherName] = fileWatcher
+	w.watchersMux.Unlock()
+
 	go func() {
 		_ = fileWatcher.Start(time.Millisecond * 100)
 	}()
 
-	done := make(chan bool)
+	// Wait for the file watcher to be started so it can be closed
+	fileWatcher.Wait()
 
 	go func() {
 		for {
 			select {
 			case event := <-fileWatcher.Event:
-				fmt.Printf("👓  Watcher has detected a file change: %v\n", event)
-				w.builder.Build(application)
-				w.runner.Restart(application)
+				// Changes of the directories themselves are notified too
+				if !matchesAny(patterns, event.Path) && !matchesAny(patterns, event.OldPath) {
+					continue
+				}
+
+				w.view.Writef("👓  Watcher has detected a configuration change: %v\n", event)
+
+				project, err := load()
+				if err != nil {
+					w.view.Writef("❌  Unable to reload the configuration, keeping the current one: %v\n", err)
+					continue
+				}
+
+				w.Reload(ctx, project)
 			case err := <-fileWatcher.Error:
-				fmt.Printf("❌  An error has occured while file watching: %v\n", err)
+				w.view.Writef("❌  An error has occured while watching configuration: %v\n", err)
+			case <-fileWatcher.Closed:
+				return
+			case <-ctx.Done():
+				fileWatcher.Close()
+				return
 			}
 		}
 	}()
 
-	<-done
+	return nil
+}
+
+// absolutePatterns returns the given paths (or glob patterns) made absolute, as watched files are
+func absolutePatterns(patterns []string) []string {
+	result := make([]string, 0, len(patterns))
+
+	for _, pattern := range patterns {
+		if absolute, err := filepath.Abs(pattern); err == nil {
+			pattern = absolute
+		}
+
+		result = append(result, pattern)
+	}
+
+	return result
+}
+
+// matchesAny indicates if the given path matches one of the given paths (or glob patterns)
+func matchesAny(patterns []string, path string) bool {
+	if path == "" {
+		return false
+	}
+
+	for _, pattern := range patterns {
+		if matched, _ := filepath.Match(pattern, path); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Reload compares the given project with the running one and only stops, starts or restarts
+// the local applications and forwards that have been removed, added or modified.
+func (w *watcher) Reload(ctx context.Context, project *config.Project) {
+	w.reloadMux.Lock()
+	defer w.reloadMux.Unlock()
+
+	previous := w.project
+	w.project = project
+
+	for _, application := range previous.Applications {
+		if current := findApplication(project.Applications, application.Name); current == nil || !reflect.DeepEqual(current, application) {
+			w.view.Writef("🔄  Stopping local app '%s'...\n", application.Name)
+			w.stopApplication(application)
+		}
+	}
+
+	for _, forward := range previous.Forwards {
+		if current := findForward(project.Forwards, forward.Name); current == nil || !reflect.DeepEqual(current, forward) {
+			w.view.Writef("🔄  Stopping forward '%s'...\n", forward.Name)
+			w.forwarder.Remove(ctx, forward.Name)
+		}
+	}
+
+	for _, application := range project.Applications {
+		if existing := findApplication(previous.Applications, application.Name); existing == nil || !reflect.DeepEqual(existing, application) {
+			w.startApplication(ctx, application)
+		}
+	}
+
+	for _, forward := range project.Forwards {
+		if existing := findForward(previous.Forwards, forward.Name); existing == nil || !reflect.DeepEqual(existing, forward) {
+			go w.forwarder.Add(ctx, forward)
+		}
+	}
+}
+
+// GetProject returns the currently running project, updated on each reload
+func (w *watcher) GetProject() *config.Project {
+	w.reloadMux.Lock()
+	defer w.reloadMux.Unlock()
+
+	return w.project
+}
+
+// Stop stops all currently active file watchers on local running applications
+func (w *watcher) Stop() error {
+	w.watchersMux.Lock()
+	defer w.watchersMux.Unlock()
+
+	for _, fileWatcher := range w.fileWatchers {
+		fileWatcher.Close()
+	}
+
+	for _, treeWatcher := range w.treeWatchers {
+		treeWatcher.Close()
+	}
+
+	return nil
+}
+
+func (w *watcher) startApplication(ctx context.Context, application *config.Application) {
+	w.setuper.Setup(application)
+	w.writer.Write(application)
+	w.builder.Build(application)
+	w.runner.Add(ctx, application)
+
+	if application.Watch {
+		go w.watchApplication(ctx, application)
+	}
+}
+
+func (w *watcher) stopApplication(application *config.Application) {
+	w.watchersMux.Lock()
+	if treeWatcher, ok := w.treeWatchers[application.Name]; ok {
+		treeWatcher.Close()
+		delete(w.treeWatchers, application.Name)
+	}
+	w.watchersMux.Unlock()
+
+	w.runner.Remove(application)
+}
+
+// watchApplication rebuilds and restarts the given application when the files it watches change, a burst of changes
+// (like a branch switch) only rebuilding it once no file has changed during the debounce duration
+func (w *watcher) watchApplication(ctx context.Context, application *config.Application) {
+	options := application.WatchOptions
+
+	filters := make([]*filter, 0)
+	for _, path := range application.GetWatchPaths() {
+		excludes := append(append([]string{}, w.excludes...), options.GetExclude()...)
+		filters = append(filters, newFilter(path, options.GetInclude(), excludes, options.IsGitignoreEnabled()))
+	}
+
+	treeWatcher, err := newTreeWatcher(filters)
+	if err != nil {
+		w.view.Writef("❌  Unable to watch directory of application '%s': %v\n", application.Name, err)
+		return
+	}
+
+	w.watchersMux.Lock()
+	w.treeWatchers[application.Name] = treeWatcher
+	w.watchersMux.Unlock()
+
+	debounce := w.conf.GetDebounce()
+
+	timer := time.NewTimer(debounce)
+	timer.Stop()
+	defer timer.Stop()
+
+	changes := make([]string, 0)
+
+	for {
+		select {
+		case path, ok := <-treeWatcher.Events():
+			if !ok {
+				return
+			}
+
+			if !contains(changes, path) {
+				changes = append(changes, path)
+			}
+
+			// A timer fired but not read yet keeps its tick (before go 1.23), which would trigger another rebuild
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+
+			timer.Reset(debounce)
+		case <-timer.C:
+			if len(changes) == 1 {
+				w.view.Writef("👓  Watcher has detected a file change of local app '%s': %s\n", application.Name, changes[0])
+			} else {
+				w.view.Writef("👓  Watcher has detected %d file changes of local app '%s', including %s\n", len(changes), application.Name, changes[0])
+			}
+
+			changes = changes[:0]
+
+			w.builder.Build(application)
+			w.runner.Restart(ctx, application)
+		case err := <-treeWatcher.Errors():
+			w.view.Writef("❌  An error has occured while file watching: %v\n", err)
+		case <-ctx.Done():
+			treeWatcher.Close()
+			return
+		}
+	}
+}
+
+func findApplication(applications []*config.Application, name string) *config.Application {
+	for _, application := range applications {
+		if application.Name == name {
+			return application
+		}
+	}
+
+	return nil
+}
+
+func findForward(forwards []*config.Forward, name string) *config.Forward {
+	for _, forward := range forwards {
+		if forward.Name == name {
+			return forward
+		}
+	}
 
 	return nil
 }
+
+func contains(values []string, value string) bool {
+	for _, current := range values {
+		if current == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -13,6 +13,7 @@ import (
 	context "context"
 	reflect "reflect"
 
+	config "github.com/eko/monday/pkg/config"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -39,6 +40,32 @@ func (m *MockWatcher) EXPECT() *MockWatcherMockRecorder {
 	return m.recorder
 }
 
+// GetProject mocks base method.
+func (m *MockWatcher) GetProject() *config.Project {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProject")
+	ret0, _ := ret[0].(*config.Project)
+	return ret0
+}
+
+// GetProject indicates an expected call of GetProject.
+func (mr *MockWatcherMockRecorder) GetProject() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProject", reflect.TypeOf((*MockWatcher)(nil).GetProject))
+}
+
+// Reload mocks base method.
+func (m *MockWatcher) Reload(ctx context.Context, project *config.Project) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Reload", ctx, project)
+}
+
+// Reload indicates an expected call of Reload.
+func (mr *MockWatcherMockRecorder) Reload(ctx, project any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reload", reflect.TypeOf((*MockWatcher)(nil).Reload), ctx, project)
+}
+
 // Stop mocks base method.
 func (m *MockWatcher) Stop() error {
 	m.ctrl.T.Helper()
@@ -64,3 +91,17 @@ func (mr *MockWatcherMockRecorder) Watch(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Watch", reflect.TypeOf((*MockWatcher)(nil).Watch), ctx)
 }
+
+// WatchConfig mocks base method.
+func (m *MockWatcher) WatchConfig(ctx context.Context, patterns []string, load func() (*config.Project, error)) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchConfig", ctx, patterns, load)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WatchConfig indicates an expected call of WatchConfig.
+func (mr *MockWatcherMockRecorder) WatchConfig(ctx, patterns, load any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchConfig", reflect.TypeOf((*MockWatcher)(nil).WatchConfig), ctx, patterns, load)
+}
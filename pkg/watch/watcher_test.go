@@ -11,9 +11,9 @@ import (
 	"github.com/eko/monday/pkg/forward"
 	"github.com/eko/monday/pkg/run"
 	"github.com/eko/monday/pkg/setup"
+	"github.com/eko/monday/pkg/ui"
 	"github.com/eko/monday/pkg/write"
 	"go.uber.org/mock/gomock"
-	watcherlib "github.com/radovskyb/watcher"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -22,6 +22,7 @@ func TestNewWatcher(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
+	view := ui.NewMockView(ctrl)
 	setuper := setup.NewMockSetuper(ctrl)
 	builder := build.NewMockBuilder(ctrl)
 	writer := write.NewMockWriter(ctrl)
@@ -35,24 +36,26 @@ func TestNewWatcher(t *testing.T) {
 	}
 
 	// When
-	w := NewWatcher(setuper, builder, writer, runner, forwarder, watchConfig, project)
+	w := NewWatcher(view, setuper, builder, writer, runner, forwarder, watchConfig, project)
 
 	// Then
 	assert.IsType(t, new(watcher), w)
 	assert.Implements(t, new(Watcher), w)
 
+	assert.Equal(t, view, w.view)
 	assert.Equal(t, writer, w.writer)
 	assert.Equal(t, runner, w.runner)
 	assert.Equal(t, forwarder, w.forwarder)
 	assert.Equal(t, project, w.project)
-	assert.Equal(t, excludeDirectories, []string{
-		".git",
-		"node_modules",
-		"vendor",
-		"test-directory",
-	})
+	assert.Equal(t, []string{"test-directory"}, w.excludes)
 
 	assert.Len(t, w.fileWatchers, 0)
+	assert.Len(t, w.treeWatchers, 0)
+
+	// Default excludes are not altered by a watcher
+	other := NewWatcher(view, setuper, builder, writer, runner, forwarder, watchConfig, project)
+	assert.Equal(t, w.excludes, other.excludes)
+	assert.Equal(t, []string{".*", "node_modules", "vendor"}, defaultExcludes)
 }
 
 func TestWatch(t *testing.T) {
@@ -61,6 +64,8 @@ func TestWatch(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
+	view := ui.NewMockView(ctrl)
+
 	setuper := setup.NewMockSetuper(ctrl)
 	setuper.EXPECT().SetupAll().Times(1)
 
@@ -71,7 +76,7 @@ func TestWatch(t *testing.T) {
 	writer.EXPECT().WriteAll().Times(1)
 
 	runner := run.NewMockRunner(ctrl)
-	runner.EXPECT().RunAll().Times(1)
+	runner.EXPECT().RunAll(ctx).Times(1)
 
 	forwarder := forward.NewMockForwarder(ctrl)
 	forwarder.EXPECT().ForwardAll(ctx).Times(1)
@@ -81,9 +86,10 @@ func TestWatch(t *testing.T) {
 	dir, _ := os.Getwd()
 	writerDirectory := dir + "/../../internal/test/write"
 
-	watcher := NewWatcher(setuper, builder, writer, runner, forwarder, &config.GlobalWatch{
+	watcher := NewWatcher(view, setuper, builder, writer, runner, forwarder, &config.GlobalWatch{
 		Exclude: []string{writerDirectory},
 	}, project)
+	defer watcher.Stop()
 
 	// When - Then
 	watcher.Watch(ctx)
@@ -98,6 +104,8 @@ func TestWatchWhenFileChange(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
+	view := ui.NewMockView(ctrl)
+
 	setuper := setup.NewMockSetuper(ctrl)
 	setuper.EXPECT().SetupAll().Times(1)
 
@@ -107,39 +115,53 @@ func TestWatchWhenFileChange(t *testing.T) {
 	writer := write.NewMockWriter(ctrl)
 	writer.EXPECT().WriteAll().Times(1)
 
+	project := getProjectMock()
+
+	restarted := make(chan bool, 1)
+
+	builder.EXPECT().Build(project.Applications[0]).Times(1)
+
 	runner := run.NewMockRunner(ctrl)
-	runner.EXPECT().RunAll().Times(1)
+	runner.EXPECT().RunAll(ctx).Times(1)
+	runner.EXPECT().Restart(ctx, project.Applications[0]).Times(1).Do(func(ctx context.Context, application *config.Application) {
+		restarted <- true
+	})
 
 	forwarder := forward.NewMockForwarder(ctrl)
 	forwarder.EXPECT().ForwardAll(ctx).Times(1)
 
-	project := getProjectMock()
+	dir, _ := os.Getwd()
+	filepath := dir + "/../../internal/test/watcher-test"
 
-	watcher := NewWatcher(setuper, builder, writer, runner, forwarder, &config.GlobalWatch{}, project)
+	view.EXPECT().Writef("👓  Watcher has detected a file change of local app '%s': %s\n", "test-app", gomock.Any()).Times(1)
+
+	watcher := NewWatcher(view, setuper, builder, writer, runner, forwarder, &config.GlobalWatch{Debounce: "100ms"}, project)
+	defer watcher.Stop()
 	watcher.Watch(ctx)
 
 	// When
 	time.Sleep(time.Duration(1 * time.Second)) // Wait 1 second to be sure filesystem is watching
 
-	dir, _ := os.Getwd()
-	filepath := dir + "/../../internal/test/watcher-test"
-
-	// Create a file to trigger a file change and restart the application
+	// Create and write a file to trigger file changes, restarting the application once
 	file, err := os.Create(filepath)
 	if err != nil {
 		t.Fatal(err)
 	}
+	file.WriteString("updated")
 	file.Close()
 	defer os.Remove(filepath)
 
 	// Then
-	assert.Len(t, watcher.fileWatchers, 1)
-
-	if fileWatcher, ok := watcher.fileWatchers["test-app"]; ok {
-		assert.IsType(t, new(watcherlib.Watcher), fileWatcher)
-	} else {
-		t.Fatal("Cannot find the fileWatcher concerning application test-app")
+	select {
+	case <-restarted:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Application has not been restarted after a file change")
 	}
+
+	watcher.watchersMux.Lock()
+	assert.Len(t, watcher.treeWatchers, 1)
+	assert.Contains(t, watcher.treeWatchers, "test-app")
+	watcher.watchersMux.Unlock()
 }
 
 func TestStop(t *testing.T) {
@@ -147,6 +169,7 @@ func TestStop(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
+	view := ui.NewMockView(ctrl)
 	setuper := setup.NewMockSetuper(ctrl)
 	builder := build.NewMockBuilder(ctrl)
 	writer := write.NewMockWriter(ctrl)
@@ -155,7 +178,7 @@ func TestStop(t *testing.T) {
 
 	project := getProjectMock()
 
-	watcher := NewWatcher(setuper, builder, writer, runner, forwarder, &config.GlobalWatch{}, project)
+	watcher := NewWatcher(view, setuper, builder, writer, runner, forwarder, &config.GlobalWatch{}, project)
 
 	// When - Then
 	watcher.Stop()
@@ -176,3 +199,234 @@ func getProjectMock() *config.Project {
 		},
 	}
 }
+
+func TestWatchConfig(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	view := ui.NewMockView(ctrl)
+	setuper := setup.NewMockSetuper(ctrl)
+	builder := build.NewMockBuilder(ctrl)
+	writer := write.NewMockWriter(ctrl)
+	runner := run.NewMockRunner(ctrl)
+	forwarder := forward.NewMockForwarder(ctrl)
+
+	filename := t.TempDir() + "/monday.yaml"
+	os.WriteFile(filename, []byte("projects: []\n"), 0644)
+
+	view.EXPECT().Writef("👓  Watcher has detected a configuration change: %v\n", gomock.Any()).MinTimes(1)
+
+	watcher := NewWatcher(view, setuper, builder, writer, runner, forwarder, &config.GlobalWatch{}, getProjectMock())
+	defer watcher.Stop()
+
+	loaded := make(chan bool, 1)
+
+	// When
+	err := watcher.WatchConfig(ctx, []string{filename}, func() (*config.Project, error) {
+		loaded <- true
+		return getProjectMock(), nil
+	})
+
+	time.Sleep(500 * time.Millisecond) // Wait to be sure filesystem is watching
+	os.WriteFile(filename, []byte("projects: [] # updated\n"), 0644)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Contains(t, watcher.fileWatchers, configWatcherName)
+
+	select {
+	case <-loaded:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Configuration has not been reloaded after a file change")
+	}
+}
+
+func TestWatchConfigWhenFileIsCreated(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	view := ui.NewMockView(ctrl)
+
+	dir := t.TempDir()
+	os.WriteFile(dir+"/monday.yaml", []byte("projects: []\n"), 0644)
+
+	view.EXPECT().Writef("👓  Watcher has detected a configuration change: %v\n", gomock.Any()).MinTimes(1)
+
+	watcher := NewWatcher(
+		view,
+		setup.NewMockSetuper(ctrl),
+		build.NewMockBuilder(ctrl),
+		write.NewMockWriter(ctrl),
+		run.NewMockRunner(ctrl),
+		forward.NewMockForwarder(ctrl),
+		&config.GlobalWatch{},
+		getProjectMock(),
+	)
+	defer watcher.Stop()
+
+	loaded := make(chan bool, 1)
+
+	// When
+	err := watcher.WatchConfig(ctx, []string{dir + "/monday.yaml", dir + "/monday*.yaml"}, func() (*config.Project, error) {
+		loaded <- true
+		return getProjectMock(), nil
+	})
+
+	time.Sleep(500 * time.Millisecond) // Wait to be sure filesystem is watching
+	os.WriteFile(dir+"/notes.txt", []byte("not a configuration file\n"), 0644)
+
+	select {
+	case <-loaded:
+		t.Fatal("Configuration has been reloaded after a change of another file")
+	case <-time.After(500 * time.Millisecond):
+	}
+
+	os.WriteFile(dir+"/monday.apps.yaml", []byte("applications: []\n"), 0644)
+
+	// Then
+	assert.Nil(t, err)
+
+	select {
+	case <-loaded:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Configuration has not been reloaded after a file creation")
+	}
+}
+
+func TestWatchConfigWhenContextDone(t *testing.T) {
+	// Given
+	ctx, cancel := context.WithCancel(context.Background())
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	filename := t.TempDir() + "/monday.yaml"
+	os.WriteFile(filename, []byte("projects: []\n"), 0644)
+
+	watcher := NewWatcher(
+		ui.NewMockView(ctrl),
+		setup.NewMockSetuper(ctrl),
+		build.NewMockBuilder(ctrl),
+		write.NewMockWriter(ctrl),
+		run.NewMockRunner(ctrl),
+		forward.NewMockForwarder(ctrl),
+		&config.GlobalWatch{},
+		getProjectMock(),
+	)
+
+	err := watcher.WatchConfig(ctx, []string{filename}, nil)
+	assert.Nil(t, err)
+
+	// When
+	cancel()
+
+	// Then
+	select {
+	case <-watcher.fileWatchers[configWatcherName].Closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Configuration file watcher has not been closed once the context is done")
+	}
+}
+
+func TestWatchConfigWhenDirectoryDoesNotExist(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	watcher := NewWatcher(
+		ui.NewMockView(ctrl),
+		setup.NewMockSetuper(ctrl),
+		build.NewMockBuilder(ctrl),
+		write.NewMockWriter(ctrl),
+		run.NewMockRunner(ctrl),
+		forward.NewMockForwarder(ctrl),
+		&config.GlobalWatch{},
+		getProjectMock(),
+	)
+
+	// When
+	err := watcher.WatchConfig(context.Background(), []string{"/unknown/monday.yaml"}, nil)
+
+	// Then
+	assert.EqualError(t, err, "Unable to watch configuration directory '/unknown': stat /unknown: no such file or directory")
+}
+
+func TestReload(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	unchangedApp := &config.Application{Name: "unchanged-app", Path: "/"}
+	changedApp := &config.Application{Name: "changed-app", Path: "/", Hostname: "changed.svc.local"}
+	removedApp := &config.Application{Name: "removed-app", Path: "/"}
+	updatedApp := &config.Application{Name: "changed-app", Path: "/", Hostname: "updated.svc.local"}
+	addedApp := &config.Application{Name: "added-app", Path: "/"}
+
+	unchangedForward := &config.Forward{Name: "unchanged-forward", Type: config.ForwarderKubernetes}
+	removedForward := &config.Forward{Name: "removed-forward", Type: config.ForwarderKubernetes}
+	addedForward := &config.Forward{Name: "added-forward", Type: config.ForwarderSSH}
+
+	previous := &config.Project{
+		Name:         "My project name",
+		Applications: []*config.Application{unchangedApp, changedApp, removedApp},
+		Forwards:     []*config.Forward{unchangedForward, removedForward},
+	}
+
+	project := &config.Project{
+		Name: "My project name",
+		Applications: []*config.Application{
+			{Name: "unchanged-app", Path: "/"},
+			updatedApp,
+			addedApp,
+		},
+		Forwards: []*config.Forward{
+			{Name: "unchanged-forward", Type: config.ForwarderKubernetes},
+			addedForward,
+		},
+	}
+
+	view := ui.NewMockView(ctrl)
+	view.EXPECT().Writef("🔄  Stopping local app '%s'...\n", "changed-app")
+	view.EXPECT().Writef("🔄  Stopping local app '%s'...\n", "removed-app")
+	view.EXPECT().Writef("🔄  Stopping forward '%s'...\n", "removed-forward")
+
+	setuper := setup.NewMockSetuper(ctrl)
+	setuper.EXPECT().Setup(updatedApp)
+	setuper.EXPECT().Setup(addedApp)
+
+	builder := build.NewMockBuilder(ctrl)
+	builder.EXPECT().Build(updatedApp)
+	builder.EXPECT().Build(addedApp)
+
+	writer := write.NewMockWriter(ctrl)
+	writer.EXPECT().Write(updatedApp)
+	writer.EXPECT().Write(addedApp)
+
+	runner := run.NewMockRunner(ctrl)
+	runner.EXPECT().Remove(changedApp)
+	runner.EXPECT().Remove(removedApp)
+	runner.EXPECT().Add(ctx, updatedApp)
+	runner.EXPECT().Add(ctx, addedApp)
+
+	added := make(chan bool)
+
+	forwarder := forward.NewMockForwarder(ctrl)
+	forwarder.EXPECT().Remove(ctx, "removed-forward")
+	forwarder.EXPECT().Add(ctx, addedForward).Do(func(ctx context.Context, forward *config.Forward) {
+		close(added)
+	})
+
+	watcher := NewWatcher(view, setuper, builder, writer, runner, forwarder, &config.GlobalWatch{}, previous)
+
+	// When
+	watcher.Reload(ctx, project)
+
+	// Then
+	<-added
+
+	assert.Equal(t, project, watcher.project)
+}